@@ -0,0 +1,218 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"claude-permissions/metadata"
+	"claude-permissions/settingsfile"
+	"claude-permissions/types"
+)
+
+// applyPrune is registered by registerApplyFlags when the apply command runs
+var applyPrune *bool
+
+// registerApplyFlags adds the apply command's flags to fs
+func registerApplyFlags(fs *flag.FlagSet) {
+	applyPrune = fs.Bool("prune", false, "apply: remove rules that are not in the desired state")
+}
+
+// desiredState is the declarative content of an apply file; omitted levels are left untouched
+type desiredState struct {
+	User  *desiredLevel `json:"user,omitempty"`
+	Repo  *desiredLevel `json:"repo,omitempty"`
+	Local *desiredLevel `json:"local,omitempty"`
+}
+
+// desiredLevel lists the rules one level should contain
+type desiredLevel struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
+// levelChange is the minimal diff reconciling one level with its desired state
+type levelChange struct {
+	Level       string   `json:"level"`
+	Path        string   `json:"path"`
+	AddAllow    []string `json:"add_allow,omitempty"`
+	RemoveAllow []string `json:"remove_allow,omitempty"`
+	AddDeny     []string `json:"add_deny,omitempty"`
+	RemoveDeny  []string `json:"remove_deny,omitempty"`
+}
+
+// applyReport summarizes a reconciliation run
+type applyReport struct {
+	Changes []levelChange `json:"changes"`
+	Pruned  bool          `json:"pruned"`
+	DryRun  bool          `json:"dry_run"`
+}
+
+// empty reports whether the change leaves the level as it is
+func (c levelChange) empty() bool {
+	return len(c.AddAllow)+len(c.RemoveAllow)+len(c.AddDeny)+len(c.RemoveDeny) == 0
+}
+
+// runApplyCommand reconciles the settings files with a desired-state file
+func runApplyCommand(args []string) (int, error) {
+	if len(args) != 1 {
+		return 1, fmt.Errorf("usage: apply [--prune] [--dry-run] DESIRED.json")
+	}
+
+	desired, err := readDesiredState(args[0])
+	if err != nil {
+		return 1, err
+	}
+
+	projectDir, err := resolveProjectDir(*projectFlag)
+	if err != nil {
+		return 1, err
+	}
+
+	userLevel, repoLevel, localLevel, err := loadRawLevels(projectDir)
+	if err != nil {
+		return 1, err
+	}
+
+	report := &applyReport{Pruned: *applyPrune, DryRun: *dryRun}
+	targets := []struct {
+		level   *types.SettingsLevel
+		desired *desiredLevel
+	}{
+		{&userLevel, desired.User},
+		{&repoLevel, desired.Repo},
+		{&localLevel, desired.Local},
+	}
+
+	source := "apply:" + filepath.Base(args[0])
+	for _, target := range targets {
+		if target.desired == nil {
+			continue
+		}
+		if target.level.Path == "" {
+			return 1, fmt.Errorf("cannot apply %s level: project is not a git repository",
+				target.level.Name)
+		}
+
+		change := reconcileLevel(target.level, target.desired, *applyPrune)
+		if change.empty() {
+			continue
+		}
+		report.Changes = append(report.Changes, change)
+
+		if *dryRun {
+			continue
+		}
+		for _, rule := range change.AddAllow {
+			metadata.Record(target.level, rule, source)
+		}
+		if err := settingsfile.Write(*target.level); err != nil {
+			return 1, err
+		}
+		if err := metadata.Save(*target.level); err != nil {
+			return exitWriteError, err
+		}
+	}
+
+	if *outputFormat == formatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return 1, err
+		}
+	} else {
+		printApplyReport(report)
+	}
+	return 0, nil
+}
+
+// readDesiredState parses a desired-state file
+func readDesiredState(path string) (*desiredState, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var desired desiredState
+	if err := json.Unmarshal(data, &desired); err != nil {
+		return nil, fmt.Errorf("invalid JSON in %s: %w", path, err)
+	}
+	return &desired, nil
+}
+
+// reconcileLevel updates level to match desired and returns the minimal diff. Without prune,
+// rules missing from the desired state are kept.
+func reconcileLevel(level *types.SettingsLevel, desired *desiredLevel, prune bool) levelChange {
+	change := levelChange{Level: level.Name, Path: level.Path}
+	level.Permissions, change.AddAllow, change.RemoveAllow = reconcileRules(
+		level.Permissions, desired.Allow, prune)
+	level.Deny, change.AddDeny, change.RemoveDeny = reconcileRules(level.Deny, desired.Deny, prune)
+	return change
+}
+
+// reconcileRules returns the reconciled, sorted rule list along with the added and removed rules
+func reconcileRules(current, desired []string, prune bool) ([]string, []string, []string) {
+	wanted := make(map[string]bool, len(desired))
+	for _, rule := range desired {
+		wanted[rule] = true
+	}
+	present := make(map[string]bool, len(current))
+	for _, rule := range current {
+		present[rule] = true
+	}
+
+	var result, added, removed []string
+	for _, rule := range current {
+		if prune && !wanted[rule] {
+			removed = append(removed, rule)
+			continue
+		}
+		result = append(result, rule)
+	}
+	for rule := range wanted {
+		if !present[rule] {
+			added = append(added, rule)
+			result = append(result, rule)
+		}
+	}
+
+	sort.Strings(result)
+	sort.Strings(added)
+	sort.Strings(removed)
+	return result, added, removed
+}
+
+// printApplyReport prints the applied (or planned) changes in human-readable form
+func printApplyReport(report *applyReport) {
+	if report.DryRun {
+		fmt.Println("Dry run: no files will be modified")
+	}
+	if len(report.Changes) == 0 {
+		fmt.Println("Already up to date")
+		return
+	}
+
+	added, removed := 0, 0
+	for _, change := range report.Changes {
+		fmt.Printf("%s (%s):\n", change.Level, change.Path)
+		printRuleChanges("+", "allow", change.AddAllow)
+		printRuleChanges("-", "allow", change.RemoveAllow)
+		printRuleChanges("+", "deny", change.AddDeny)
+		printRuleChanges("-", "deny", change.RemoveDeny)
+		added += len(change.AddAllow) + len(change.AddDeny)
+		removed += len(change.RemoveAllow) + len(change.RemoveDeny)
+	}
+
+	fmt.Printf("\nSummary: %d added, %d removed across %d files\n",
+		added, removed, len(report.Changes))
+}
+
+// printRuleChanges prints one line per rule prefixed with sign and the list it belongs to
+func printRuleChanges(sign, list string, rules []string) {
+	for _, rule := range rules {
+		fmt.Printf("  %s %s %s\n", sign, list, rule)
+	}
+}
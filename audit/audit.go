@@ -0,0 +1,177 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"claude-permissions/paths"
+)
+
+// FileName is the audit log kept next to the user-level settings
+const FileName = "permission-editor-audit.jsonl"
+
+// Actions recorded for each changed rule
+const (
+	ActionMove            = "move"
+	ActionRemoveDuplicate = "remove_duplicate"
+
+	// ActionResolveContradiction removes the losing allow or deny entry; To lists the
+	// levels whose entry won
+	ActionResolveContradiction = "resolve_contradiction"
+
+	// ActionConsolidate replaces a narrow rule with a broader one; To is the broader rule
+	ActionConsolidate = "consolidate"
+
+	// ActionImport adds a rule from a permission set; From is the import source
+	ActionImport = "import"
+
+	// ActionPreset adds a rule from a built-in preset; From is "preset:<name>"
+	ActionPreset = "preset"
+
+	// ActionMoveHook and ActionReorderHook change a PreToolUse or PostToolUse hook; Rule
+	// describes the hook, e.g. "PreToolUse(Bash)"
+	ActionMoveHook    = "move_hook"
+	ActionReorderHook = "reorder_hook"
+
+	// ActionSetEnv and ActionDeleteEnv change an env variable; Rule is the variable name, as
+	// values may be secrets. Deleting a variable kept at another level sets To to that level.
+	ActionSetEnv    = "set_env"
+	ActionDeleteEnv = "delete_env"
+
+	// ActionAddDirectory, ActionRemoveDirectory, and ActionMoveDirectory change an
+	// additionalDirectories entry; Rule is the directory
+	ActionAddDirectory    = "add_directory"
+	ActionRemoveDirectory = "remove_directory"
+	ActionMoveDirectory   = "move_directory"
+
+	// ActionRemoveStale removes an allow rule whose script or path no longer exists
+	ActionRemoveStale = "remove_stale"
+
+	// ActionSetMode changes defaultMode or disableBypassPermissionsMode; Rule is the setting
+	// and its new value, e.g. "defaultMode=plan"
+	ActionSetMode = "set_mode"
+
+	// ActionEditRule replaces an allow rule in place; To is the new rule.
+	// ActionDeleteRule removes an allow rule.
+	ActionEditRule   = "edit_rule"
+	ActionDeleteRule = "delete_rule"
+
+	// ActionAddRule adds an allow rule pasted from the clipboard
+	ActionAddRule = "add_rule"
+)
+
+// Entry is one applied change; entries are stored one JSON object per line
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Rule      string    `json:"rule"`
+	From      string    `json:"from"`
+	To        string    `json:"to"` // For removed duplicates, the level that kept the rule
+	Action    string    `json:"action"`
+	ChangeID  string    `json:"change_id,omitempty"` // Shared by all entries of one save
+}
+
+// Log appends applied changes to a JSON Lines file. A nil Log records nothing.
+type Log struct {
+	path string
+}
+
+// DefaultPath returns the audit log location in the user-level Claude directory
+func DefaultPath() (string, error) {
+	dir, _, err := paths.UserSettingsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// Open returns the audit log at the default location
+func Open() (*Log, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return NewLog(path), nil
+}
+
+// NewLog returns the audit log stored at path
+func NewLog(path string) *Log {
+	return &Log{path: path}
+}
+
+// Path returns the file the log is stored in
+func (l *Log) Path() string {
+	if l == nil {
+		return ""
+	}
+	return l.path
+}
+
+// Append writes entries to the end of the log, creating the file if needed
+func (l *Log) Append(entries []Entry) error {
+	if l == nil || len(entries) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	// #nosec G304 - audit log in the user's Claude directory
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", l.path, err)
+	}
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s: %w", l.path, err)
+	}
+	return file.Close()
+}
+
+// Recent returns up to limit entries, newest first. A missing log yields no entries and
+// lines that are not valid entries are skipped.
+func (l *Log) Recent(limit int) ([]Entry, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(l.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", l.path, err)
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.Rule == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", l.path, err)
+	}
+
+	// The file is in append order
+	recent := make([]Entry, 0, min(limit, len(entries)))
+	for i := len(entries) - 1; i >= 0 && len(recent) < limit; i-- {
+		recent = append(recent, entries[i])
+	}
+	return recent, nil
+}
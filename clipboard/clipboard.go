@@ -0,0 +1,91 @@
+// Package clipboard copies and pastes text with the operating system's clipboard tools.
+// Over SSH those tools reach the remote machine, so callers use OSC52 there instead.
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrUnavailable is returned when none of the platform's clipboard tools is installed
+var ErrUnavailable = errors.New("no clipboard tool found")
+
+// tool is a clipboard command and its arguments
+type tool []string
+
+// copyTools returns the commands that write standard input to the clipboard, in the order
+// they are tried
+func copyTools() []tool {
+	switch runtime.GOOS {
+	case "darwin":
+		return []tool{{"pbcopy"}}
+	case "windows":
+		return []tool{{"clip"}}
+	}
+	tools := []tool{
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		tools = append([]tool{{"wl-copy"}}, tools...)
+	}
+	return tools
+}
+
+// pasteTools returns the commands that print the clipboard, in the order they are tried
+func pasteTools() []tool {
+	switch runtime.GOOS {
+	case "darwin":
+		return []tool{{"pbpaste"}}
+	case "windows":
+		return []tool{{"powershell", "-NoProfile", "-Command", "Get-Clipboard"}}
+	}
+	tools := []tool{
+		{"xclip", "-selection", "clipboard", "-o"},
+		{"xsel", "--clipboard", "--output"},
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		tools = append([]tool{{"wl-paste", "--no-newline"}}, tools...)
+	}
+	return tools
+}
+
+// Remote reports whether the process runs in an SSH session
+func Remote() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// Write copies text to the clipboard with the first installed tool
+func Write(text string) error {
+	for _, t := range copyTools() {
+		if _, err := exec.LookPath(t[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(t[0], t[1:]...) // #nosec G204 - fixed clipboard tools
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s failed: %w", t[0], err)
+		}
+		return nil
+	}
+	return ErrUnavailable
+}
+
+// Read returns the clipboard's text from the first installed tool
+func Read() (string, error) {
+	for _, t := range pasteTools() {
+		if _, err := exec.LookPath(t[0]); err != nil {
+			continue
+		}
+		out, err := exec.Command(t[0], t[1:]...).Output() // #nosec G204 - fixed clipboard tools
+		if err != nil {
+			return "", fmt.Errorf("%s failed: %w", t[0], err)
+		}
+		return string(out), nil
+	}
+	return "", ErrUnavailable
+}
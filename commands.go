@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// subcommand is a non-interactive mode selected by the first positional argument
+type subcommand struct {
+	summary string
+	flags   func(fs *flag.FlagSet) // Registers command-specific flags; may be nil
+	run     func(args []string) (int, error)
+}
+
+// subcommands lists the available non-interactive modes by name
+var subcommands = map[string]subcommand{
+	"apply": {
+		summary: "Reconcile settings files with a desired-state file",
+		flags:   registerApplyFlags,
+		run:     runApplyCommand,
+	},
+	"export": {
+		summary: "Write the rules of each level to a portable JSON or YAML permission set",
+		flags:   registerPermsetFlags,
+		run:     runExportCommand,
+	},
+	"import": {
+		summary: "Add the rules of an exported permission set to their levels",
+		flags:   registerPermsetFlags,
+		run:     runImportCommand,
+	},
+	"lint": {
+		summary: "Check the rules against the repository's policy file for CI",
+		flags:   registerLintFlags,
+		run:     runLintCommand,
+	},
+	"paths": {summary: "Print the resolved settings file locations", run: runPathsCommand},
+	"profiles": {
+		summary: "List, save, or delete named permission profiles",
+		run:     runProfilesCommand,
+	},
+	"report": {
+		summary: "Write a read-only HTML report of rules, duplicates, and risks",
+		flags:   registerReportFlags,
+		run:     runReportCommand,
+	},
+	"scan": {
+		summary: "Audit every repository below --root and print an aggregate report",
+		flags:   registerScanFlags,
+		run:     runScanCommand,
+	},
+	"version": {summary: "Print the version, commit, and build date", run: runVersionCommand},
+	"watch": {
+		summary: "Print a diff whenever a settings file changes",
+		flags:   registerWatchFlags,
+		run:     runWatchCommand,
+	},
+}
+
+// subcommandNames returns the subcommand names in alphabetical order
+func subcommandNames() []string {
+	names := make([]string, 0, len(subcommands))
+	for name := range subcommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// runSubcommand runs the named subcommand and returns its exit code. Global flags may
+// also follow the command name, e.g. "paths --format json".
+func runSubcommand(name string, args []string) (int, error) {
+	cmd, ok := subcommands[name]
+	if !ok {
+		return 1, fmt.Errorf("unknown command %q (available: %s)",
+			name, strings.Join(subcommandNames(), ", "))
+	}
+
+	if cmd.flags != nil {
+		cmd.flags(flag.CommandLine)
+	}
+	if err := flag.CommandLine.Parse(args); err != nil {
+		return 1, err
+	}
+	return cmd.run(flag.Args())
+}
+
+// printUsage prints flag defaults followed by the available subcommands
+func printUsage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [flags] [command]\n\nFlags:\n", os.Args[0])
+	flag.PrintDefaults()
+
+	fmt.Fprintln(out, "\nCommands:")
+	for _, name := range subcommandNames() {
+		fmt.Fprintf(out, "  %-10s %s\n", name, subcommands[name].summary)
+	}
+}
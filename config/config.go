@@ -0,0 +1,269 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"claude-permissions/keymap"
+
+	"github.com/BurntSushi/toml"
+)
+
+// AppDirName is the directory under the user config dir holding the config file
+const AppDirName = "claude-permissions"
+
+// Density values controlling how much whitespace the UI uses
+const (
+	DensityComfortable = "comfortable"
+	DensityCompact     = "compact"
+)
+
+// Bell values controlling the feedback for rejected keys and actions
+const (
+	BellVisual  = "visual"
+	BellAudible = "audible"
+	BellOff     = "off"
+)
+
+// DefaultPriority is the level order, most preferred first, used to pick where a
+// cross-level duplicate is kept
+var DefaultPriority = []string{"User", "Repo", "Local"}
+
+// Config is the user configuration loaded from config.toml
+type Config struct {
+	Display    DisplayConfig    `toml:"display"`
+	Duplicates DuplicatesConfig `toml:"duplicates"`
+	Debug      DebugConfig      `toml:"debug,omitempty"`
+
+	// Keys remaps actions to keys, e.g. up = ["k"]; omitted actions keep their defaults
+	Keys map[string][]string `toml:"keys,omitempty"`
+
+	path string
+
+	// priorityOverride comes from --priority; it takes precedence over Duplicates.Priority
+	// for this run and is never saved
+	priorityOverride []string
+}
+
+// DisplayConfig holds presentation preferences
+type DisplayConfig struct {
+	Density string `toml:"density"`
+	Bell    string `toml:"bell"`
+}
+
+// DuplicatesConfig controls how cross-level duplicates are auto-resolved
+type DuplicatesConfig struct {
+	// Priority lists all three levels, most preferred first, e.g. ["Repo", "User", "Local"]
+	Priority []string `toml:"priority"`
+
+	// SoftDeleteDays keeps removed duplicates in the sidecar for this many days instead of
+	// deleting them outright; 0 disables soft delete
+	SoftDeleteDays int `toml:"soft_delete_days"`
+}
+
+// DebugConfig restricts the HTTP debug server started with --debug-server
+type DebugConfig struct {
+	// Endpoints lists the only endpoints served, e.g. ["/snapshot", "/logs"]; empty serves all
+	Endpoints []string `toml:"endpoints,omitempty"`
+
+	// DisabledEndpoints lists endpoints that are never served, e.g. ["/input", "/reset"]
+	DisabledEndpoints []string `toml:"disabled_endpoints,omitempty"`
+}
+
+// Default returns the configuration used when no config file exists
+func Default() *Config {
+	return &Config{
+		Display:    DisplayConfig{Density: DensityComfortable, Bell: BellVisual},
+		Duplicates: DuplicatesConfig{Priority: slices.Clone(DefaultPriority)},
+	}
+}
+
+// DuplicatePriority returns the level order used to auto-resolve duplicates: the override,
+// then the configured order. A nil config yields the default order.
+func (c *Config) DuplicatePriority() []string {
+	switch {
+	case c == nil:
+		return DefaultPriority
+	case len(c.priorityOverride) > 0:
+		return c.priorityOverride
+	case len(c.Duplicates.Priority) == 0:
+		return DefaultPriority
+	}
+	return c.Duplicates.Priority
+}
+
+// OverridePriority makes levels, most preferred first, the duplicate priority for this run
+// without changing the config file
+func (c *Config) OverridePriority(levels []string) error {
+	normalized, err := NormalizePriority(levels)
+	if err != nil {
+		return err
+	}
+	c.priorityOverride = normalized
+	return nil
+}
+
+// SoftDeleteTTL returns how long removed duplicates stay disabled before they may be
+// purged, or 0 when soft delete is off
+func (c *Config) SoftDeleteTTL() time.Duration {
+	if c == nil || c.Duplicates.SoftDeleteDays <= 0 {
+		return 0
+	}
+	return time.Duration(c.Duplicates.SoftDeleteDays) * 24 * time.Hour
+}
+
+// DefaultPath returns the config file location under the user config directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, AppDirName, "config.toml"), nil
+}
+
+// Load reads the config file from the default location
+func Load() (*Config, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return Default(), err
+	}
+	return LoadFrom(path)
+}
+
+// LoadFrom reads the config file at path; a missing file yields the defaults.
+// On error the returned config is still usable (defaults plus whatever parsed).
+func LoadFrom(path string) (*Config, error) {
+	cfg := Default()
+	cfg.path = path
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return cfg, nil
+	}
+
+	if _, err := toml.DecodeFile(path, cfg); err != nil {
+		return cfg, fmt.Errorf("invalid config in %s: %w", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("invalid config in %s:\n%w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Validate checks that all values are allowed, resetting an invalid density or bell to its
+// default. Invalid key bindings are kept so saving the config does not discard them.
+func (c *Config) Validate() error {
+	var errs []error
+
+	switch c.Display.Density {
+	case DensityComfortable, DensityCompact:
+	case "":
+		c.Display.Density = DensityComfortable
+	default:
+		invalid := c.Display.Density
+		c.Display.Density = DensityComfortable
+		errs = append(errs, fmt.Errorf("display.density must be %q or %q, got %q",
+			DensityComfortable, DensityCompact, invalid))
+	}
+
+	switch c.Display.Bell {
+	case BellVisual, BellAudible, BellOff:
+	case "":
+		c.Display.Bell = BellVisual
+	default:
+		invalid := c.Display.Bell
+		c.Display.Bell = BellVisual
+		errs = append(errs, fmt.Errorf("display.bell must be %q, %q, or %q, got %q",
+			BellVisual, BellAudible, BellOff, invalid))
+	}
+
+	if err := c.validatePriority(); err != nil {
+		c.Duplicates.Priority = slices.Clone(DefaultPriority)
+		errs = append(errs, err)
+	}
+
+	if c.Duplicates.SoftDeleteDays < 0 {
+		invalid := c.Duplicates.SoftDeleteDays
+		c.Duplicates.SoftDeleteDays = 0
+		errs = append(errs, fmt.Errorf("duplicates.soft_delete_days must not be negative, got %d",
+			invalid))
+	}
+
+	if _, err := keymap.New(c.Keys); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validatePriority checks that the priority lists each level exactly once, normalizing the
+// case of level names
+func (c *Config) validatePriority() error {
+	if len(c.Duplicates.Priority) == 0 {
+		c.Duplicates.Priority = slices.Clone(DefaultPriority)
+		return nil
+	}
+	normalized, err := NormalizePriority(c.Duplicates.Priority)
+	if err != nil {
+		return fmt.Errorf("duplicates.priority %w", err)
+	}
+	c.Duplicates.Priority = normalized
+	return nil
+}
+
+// NormalizePriority checks that levels lists each level exactly once, in any case, and
+// returns them with canonical names
+func NormalizePriority(levels []string) ([]string, error) {
+	canonical := make(map[string]string, len(DefaultPriority))
+	for _, level := range DefaultPriority {
+		canonical[strings.ToLower(level)] = level
+	}
+
+	seen := make(map[string]bool)
+	normalized := make([]string, 0, len(levels))
+	for _, level := range levels {
+		name, ok := canonical[strings.ToLower(strings.TrimSpace(level))]
+		if !ok || seen[name] {
+			break
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	if len(normalized) != len(levels) || len(normalized) != len(DefaultPriority) {
+		return nil, fmt.Errorf("must list %s exactly once each, got %v",
+			strings.Join(DefaultPriority, ", "), levels)
+	}
+	return normalized, nil
+}
+
+// Path returns the file the config was loaded from (and will be saved to)
+func (c *Config) Path() string {
+	return c.path
+}
+
+// Save writes the config back to its file, creating the directory if needed
+func (c *Config) Save() error {
+	if c.path == "" {
+		return fmt.Errorf("config has no file path")
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(c); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(c.path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", c.path, err)
+	}
+	return nil
+}
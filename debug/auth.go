@@ -0,0 +1,73 @@
+package debug
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"net/url"
+
+	"claude-permissions/logging"
+)
+
+// TokenHeader is the request header carrying the token set with RequireToken
+const TokenHeader = "X-Debug-Token"
+
+// tokenParam carries the token for browsers, which cannot set headers on the inspector page or
+// its WebSocket
+const tokenParam = "token"
+
+// RequireToken makes every endpoint but /health refuse requests that do not carry token in
+// the X-Debug-Token header or the token query parameter
+func (ds *DebugServer) RequireToken(token string) {
+	ds.token = token
+}
+
+// authorize wraps next, refusing the requests refusal reports and logging them
+func (ds *DebugServer) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status, reason := ds.refusal(r)
+		if status == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		logging.For(logging.ComponentDebug).Warn("debug_request_refused",
+			"reason", reason,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"remote_addr", r.RemoteAddr,
+			"origin", r.Header.Get("Origin"))
+		writeErrorResponse(w, reason, status, nil)
+	})
+}
+
+// refusal returns the status and reason to refuse r with, or 0 to serve it. Web pages may
+// only call the server from its own origin. Without a token, the Host must also be localhost
+// or an IP address, so a page cannot reach it through a domain rebound to this machine.
+func (ds *DebugServer) refusal(r *http.Request) (int, string) {
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if parsed, err := url.Parse(origin); err != nil || parsed.Host != r.Host {
+			return http.StatusForbidden, "Cross-origin request refused"
+		}
+	}
+	if ds.token == "" {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		if host != "localhost" && net.ParseIP(host) == nil {
+			return http.StatusForbidden, "Host must be localhost or an IP address"
+		}
+		return 0, ""
+	}
+	if r.URL.Path == healthEndpoint {
+		return 0, ""
+	}
+	token := r.Header.Get(TokenHeader)
+	if token == "" {
+		token = r.URL.Query().Get(tokenParam)
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(ds.token)) != 1 {
+		return http.StatusUnauthorized, "Missing or invalid " + TokenHeader
+	}
+	return 0, ""
+}
@@ -0,0 +1,137 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"claude-permissions/logging"
+	"claude-permissions/types"
+	"claude-permissions/version"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// crashLogEntries is how many of the most recent log entries a crash report includes
+const crashLogEntries = 200
+
+// CrashReport is what the editor was doing when it panicked. The model holds the rules and
+// pending changes but not the settings files' contents, and can be loaded with POST /state.
+type CrashReport struct {
+	Panic      string       `json:"panic"`
+	Stack      string       `json:"stack,omitempty"` // Empty for a panic in a command
+	Version    version.Info `json:"version"`
+	Timestamp  string       `json:"timestamp"`
+	Logs       []LogEntry   `json:"logs"`
+	Model      *ModelState  `json:"model,omitempty"`
+	ModelError string       `json:"model_error,omitempty"` // Why the model could not be saved
+}
+
+// CrashGuard is a tea.Model that writes a crash report when its model panics, then lets the
+// panic continue so Bubble Tea restores the terminal
+type CrashGuard struct {
+	model  tea.Model
+	state  *types.Model
+	logger *Logger
+	path   string // Crash report written, if any
+}
+
+// GuardCrashes wraps model, whose state is held in state, to report its panics
+func GuardCrashes(model tea.Model, state *types.Model) *CrashGuard {
+	return &CrashGuard{model: model, state: state}
+}
+
+// SetLogger sets the log whose recent entries crash reports include
+func (g *CrashGuard) SetLogger(logger *Logger) {
+	g.logger = logger
+}
+
+// Init implements tea.Model interface
+func (g *CrashGuard) Init() tea.Cmd {
+	defer g.catch()
+	return g.model.Init()
+}
+
+// Update implements tea.Model interface
+func (g *CrashGuard) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer g.catch()
+	var cmd tea.Cmd
+	g.model, cmd = g.model.Update(msg)
+	return g, cmd
+}
+
+// View implements tea.ViewModel interface
+func (g *CrashGuard) View() string {
+	defer g.catch()
+	return viewOf(g.model)
+}
+
+// catch writes a crash report for a panic in progress and panics again with the same value
+func (g *CrashGuard) catch() {
+	if r := recover(); r != nil {
+		g.WriteReport(fmt.Sprint(r), string(debug.Stack()))
+		panic(r)
+	}
+}
+
+// ReportPath returns where the crash report was written, or "" if none was
+func (g *CrashGuard) ReportPath() string {
+	return g.path
+}
+
+// WriteReport writes a crash report to the state directory, or the temporary directory if it
+// cannot be written there, and returns its path. Only the first report of a run is written.
+func (g *CrashGuard) WriteReport(panicValue, stack string) string {
+	if g.path != "" {
+		return g.path
+	}
+	report := CrashReport{
+		Panic:     panicValue,
+		Stack:     stack,
+		Version:   version.Get(),
+		Timestamp: getCurrentTimestamp(),
+		Logs:      []LogEntry{},
+	}
+	if g.logger != nil {
+		entries := g.logger.GetAllEntries()
+		report.Logs = entries[max(0, len(entries)-crashLogEntries):]
+	}
+	report.Model, report.ModelError = g.crashState()
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return ""
+	}
+	name := fmt.Sprintf("crash-%s.json", time.Now().Format("20060102-150405"))
+	dirs := []string{os.TempDir()}
+	if dir, err := logging.StateDir(); err == nil {
+		dirs = append([]string{dir}, dirs...)
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(dir, name)
+		if os.MkdirAll(dir, 0o755) == nil && os.WriteFile(path, data, 0o600) == nil {
+			g.path = path
+			return path
+		}
+	}
+	return ""
+}
+
+// crashState serializes the model for a crash report. The panic may have left the model
+// locked or inconsistent, so it is read without waiting for the lock and a panic while
+// reading it is reported instead.
+func (g *CrashGuard) crashState() (state *ModelState, failure string) {
+	defer func() {
+		if r := recover(); r != nil {
+			state, failure = nil, fmt.Sprintf("serializing the model panicked: %v", r)
+		}
+	}()
+	if g.state.Mutex.TryRLock() {
+		defer g.state.Mutex.RUnlock()
+	}
+	extracted := extractModelState(g.state)
+	return &extracted, ""
+}
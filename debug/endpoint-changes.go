@@ -0,0 +1,90 @@
+package debug
+
+import (
+	"net/http"
+
+	"claude-permissions/types"
+)
+
+func init() {
+	RegisterEndpoint("/changes", handleChanges)
+}
+
+// ChangedFile is a settings file the next save writes
+type ChangedFile struct {
+	Level    string `json:"level"`
+	Path     string `json:"path"`
+	Selected bool   `json:"selected"` // Ticked for saving on the confirm screen
+}
+
+// ChangeSet is the pending change set as the confirm screen lists it
+type ChangeSet struct {
+	Files    []ChangedFile         `json:"files"`
+	Risks    []string              `json:"risks"` // Risky changes among the selected files
+	Sections []types.ChangeSection `json:"sections"`
+}
+
+// ChangesProvider builds the change set of a model, with the model's lock held
+type ChangesProvider func(model *types.Model) ChangeSet
+
+// changesProvider is registered by the UI, which owns the confirm screen's listing
+var changesProvider ChangesProvider
+
+// RegisterChangesProvider sets how /changes lists the pending changes
+func RegisterChangesProvider(provider ChangesProvider) {
+	changesProvider = provider
+}
+
+// ChangesResponse represents the pending changes response
+type ChangesResponse struct {
+	ChangeSet
+	Count     int    `json:"count"` // Changes across all sections
+	Timestamp string `json:"timestamp"`
+}
+
+// handleChanges handles the GET /changes endpoint, listing the pending changes with their
+// styling removed
+func handleChanges(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, "Method not allowed", http.StatusMethodNotAllowed, ds.logger)
+		return
+	}
+	model := ds.GetModel()
+	if model == nil || changesProvider == nil {
+		writeErrorResponse(w, "Model not available", http.StatusInternalServerError, ds.logger)
+		return
+	}
+
+	model.Mutex.RLock()
+	set := changesProvider(model)
+	model.Mutex.RUnlock()
+
+	response := ChangesResponse{ChangeSet: set, Timestamp: getCurrentTimestamp()}
+	if response.Files == nil {
+		response.Files = []ChangedFile{}
+	}
+	if response.Risks == nil {
+		response.Risks = []string{}
+	}
+	if response.Sections == nil {
+		response.Sections = []types.ChangeSection{}
+	}
+	for i := range response.Risks {
+		response.Risks[i] = stripANSICodes(response.Risks[i])
+	}
+	for i := range response.Sections {
+		section := &response.Sections[i]
+		section.Title = stripANSICodes(section.Title)
+		for j := range section.Changes {
+			section.Changes[j].Line = stripANSICodes(section.Changes[j].Line)
+		}
+		response.Count += len(section.Changes)
+	}
+
+	ds.logger.LogEvent("changes_listed", map[string]interface{}{
+		"count":    response.Count,
+		"sections": len(response.Sections),
+		"files":    len(response.Files),
+	})
+	writeJSONResponse(w, response, ds.logger)
+}
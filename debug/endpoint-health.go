@@ -21,6 +21,7 @@ func handleHealth(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
 	if err := json.NewEncoder(w).Encode(map[string]interface{}{
 		"status":    "ok",
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"endpoints": ds.Endpoints(),
 	}); err != nil {
 		ds.logger.LogError("health_endpoint_error", err, nil)
 	}
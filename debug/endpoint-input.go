@@ -2,8 +2,10 @@ package debug
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"claude-permissions/types"
@@ -15,9 +17,22 @@ func init() {
 	RegisterEndpoint("/input", handleInput)
 }
 
-// InputRequest represents the input injection request
+// settleTimeout bounds how long /input waits for a key to be handled and rendered
+const settleTimeout = time.Second
+
+// InputRequest represents the input injection request: one key, or a sequence of keys sent in
+// order, each once the previous one settled. Keys are names like "tab" or chords like "ctrl+c".
 type InputRequest struct {
-	Key string `json:"key"`
+	Key  string   `json:"key"`
+	Keys []string `json:"keys"`
+}
+
+// allKeys returns the keys to send, key first
+func (r InputRequest) allKeys() []string {
+	if r.Key == "" {
+		return r.Keys
+	}
+	return append([]string{r.Key}, r.Keys...)
 }
 
 // InputResponse represents the response to input injection
@@ -25,6 +40,8 @@ type InputResponse struct {
 	PreviousPanel string        `json:"previous_panel"`
 	NewPanel      string        `json:"new_panel"`
 	StateChanges  []string      `json:"state_changes"`
+	Sent          int           `json:"sent"`    // Keys sent before any error
+	Settled       bool          `json:"settled"` // Every key was handled and rendered in time
 	Success       bool          `json:"success"`
 	Error         string        `json:"error,omitempty"`
 	Snapshot      *SnapshotData `json:"snapshot,omitempty"`
@@ -47,33 +64,30 @@ func handleInput(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Parse the input request
 	var request InputRequest
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
 		writeErrorResponse(w, "Invalid JSON in request body", http.StatusBadRequest, ds.logger)
 		return
 	}
 
-	// Validate the key
-	if request.Key == "" {
-		writeErrorResponse(w, "Key field is required", http.StatusBadRequest, ds.logger)
+	keys := request.allKeys()
+	if len(keys) == 0 {
+		writeErrorResponse(w, "Key or keys field is required", http.StatusBadRequest, ds.logger)
 		return
 	}
 
 	// Capture state before input
 	beforeState := captureModelState(ds)
 
-	// Send the input to the application
-	err := sendInput(ds, request.Key)
-
-	// Give the application a moment to process the input
-	time.Sleep(50 * time.Millisecond)
+	// Send the input, waiting for each key to settle
+	sent, settled, err := sendKeys(ds, keys)
 
 	// Capture state after input
 	afterState := captureModelState(ds)
 
-	// Build response
 	response := InputResponse{
+		Sent:      sent,
+		Settled:   settled,
 		Success:   err == nil,
 		Timestamp: getCurrentTimestamp(),
 	}
@@ -93,7 +107,9 @@ func handleInput(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
 	}
 
 	ds.logger.LogEvent("input_processed", map[string]interface{}{
-		"key":           request.Key,
+		"keys":          keys,
+		"sent":          sent,
+		"settled":       settled,
 		"success":       response.Success,
 		"state_changes": len(response.StateChanges),
 		"panel_change":  response.PreviousPanel != response.NewPanel,
@@ -102,23 +118,49 @@ func handleInput(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
 	writeJSONResponse(w, response, ds.logger)
 }
 
-// sendInput sends a key input to the TUI program
-func sendInput(ds *DebugServer, key string) error {
+// sendKeys sends keys to the TUI program in order, waiting for each to settle. Every key is
+// converted before any is sent, so an unsupported key sends none.
+func sendKeys(ds *DebugServer, keys []string) (sent int, settled bool, err error) {
 	if ds.program == nil {
-		return fmt.Errorf("no program instance available")
+		return 0, false, errors.New("no program instance available")
 	}
 
-	msg, err := convertKeyToMessage(key)
-	if err != nil {
-		return err
+	msgs := make([]tea.Msg, len(keys))
+	for i, key := range keys {
+		if msgs[i], err = convertKeyToMessage(key); err != nil {
+			return 0, false, err
+		}
 	}
 
-	ds.program.Send(msg)
-	ds.logger.LogEvent("input_sent", map[string]interface{}{
-		"key": key,
-	})
+	settled = true
+	for i, msg := range msgs {
+		ds.program.Send(msg)
+		ds.logger.LogEvent("input_sent", map[string]interface{}{
+			"key": keys[i],
+		})
+		if !waitForSettle(ds) {
+			settled = false
+		}
+	}
+	return len(msgs), settled, nil
+}
 
-	return nil
+// waitForSettle waits until the messages sent so far are handled and the view rendered,
+// reporting false when that takes longer than settleTimeout
+func waitForSettle(ds *DebugServer) bool {
+	done := make(chan struct{})
+	ds.program.Send(settleMsg{done: done})
+
+	timer := time.NewTimer(settleTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	case <-ds.shutdown:
+		return false
+	}
 }
 
 // captureModelState captures a snapshot of the current model state using direct field access
@@ -136,9 +178,9 @@ func captureModelState(ds *DebugServer) ModelStateCapture {
 		SelectedItems: extractSelectedItemsForCapture(
 			model,
 		), // Extract from current column selection
-		FilterText:    "",                  // No filter in current UI implementation
-		ConfirmMode:   false,               // Removed confirm mode boolean
-		StatusMessage: model.StatusMessage, // Direct field access
+		FilterText:    "",    // No filter in current UI implementation
+		ConfirmMode:   false, // Removed confirm mode boolean
+		StatusMessage: currentStatusMessage(model),
 	}
 }
 
@@ -269,6 +311,10 @@ func stringSlicesEqual(a, b []string) bool {
 
 // convertKeyToMessage converts a string key to a tea.Msg
 func convertKeyToMessage(key string) (tea.Msg, error) {
+	if len(key) > 1 && strings.Contains(key, "+") {
+		return convertChordToMessage(key)
+	}
+
 	switch key {
 	case "up", "arrow-up":
 		return tea.KeyPressMsg(tea.Key{Code: tea.KeyUp}), nil
@@ -286,6 +332,12 @@ func convertKeyToMessage(key string) (tea.Msg, error) {
 		return tea.KeyPressMsg(tea.Key{Code: tea.KeyEscape}), nil
 	case "space":
 		return tea.KeyPressMsg(tea.Key{Code: tea.KeySpace, Text: " "}), nil
+	case "f1":
+		return tea.KeyPressMsg(tea.Key{Code: tea.KeyF1}), nil
+	case "f2":
+		return tea.KeyPressMsg(tea.Key{Code: tea.KeyF2}), nil
+	case "f3":
+		return tea.KeyPressMsg(tea.Key{Code: tea.KeyF3}), nil
 	default:
 		return convertRuneKeyToMessage(key)
 	}
@@ -300,9 +352,13 @@ var keyMappings = map[string]rune{
 	"e": 'e', "E": 'e',
 	"c": 'c', "C": 'c',
 	"q": 'q', "Q": 'q',
+	"x": 'x', "X": 'x',
+	"z": 'z', "Z": 'z',
+	"p": 'p', "P": 'p',
 	"y": 'y', "Y": 'y',
 	"n": 'n', "N": 'n',
 	"/": '/',
+	"?": '?',
 	"1": '1',
 	"2": '2',
 	"3": '3',
@@ -315,3 +371,37 @@ func convertRuneKeyToMessage(key string) (tea.Msg, error) {
 	}
 	return nil, fmt.Errorf("unsupported key: %s", key)
 }
+
+// keyModifiers maps the modifiers chords may name to their key modifiers
+var keyModifiers = map[string]tea.KeyMod{
+	"ctrl":  tea.ModCtrl,
+	"alt":   tea.ModAlt,
+	"shift": tea.ModShift,
+}
+
+// convertChordToMessage converts a chord like "ctrl+c" or "shift+tab" to a message: modifiers
+// joined by "+" to a named key or any single character
+func convertChordToMessage(chord string) (tea.Msg, error) {
+	parts := strings.Split(chord, "+")
+	base := parts[len(parts)-1]
+
+	var key tea.Key
+	if msg, err := convertKeyToMessage(base); err == nil {
+		key = tea.Key(msg.(tea.KeyPressMsg))
+	} else if runes := []rune(base); len(runes) == 1 {
+		key = tea.Key{Code: runes[0]}
+	} else {
+		return nil, fmt.Errorf("unsupported key in chord %s: %s", chord, base)
+	}
+
+	for _, name := range parts[:len(parts)-1] {
+		mod, ok := keyModifiers[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported modifier in chord %s: %s", chord, name)
+		}
+		key.Mod |= mod
+	}
+	// A chord types no text
+	key.Text = ""
+	return tea.KeyPressMsg(key), nil
+}
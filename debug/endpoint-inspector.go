@@ -0,0 +1,34 @@
+package debug
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+func init() {
+	RegisterEndpoint("/", handleInspector)
+}
+
+// inspectorPage renders the live screen from /ws, outlines the components of the layout
+// diagnostics, and injects keys with /input
+//
+//go:embed inspector.html
+var inspectorPage []byte
+
+// handleInspector handles the GET / endpoint, serving the web inspector
+func handleInspector(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
+	// "/" also matches every path no other endpoint serves
+	if r.URL.Path != "/" {
+		writeErrorResponse(w, "Not found", http.StatusNotFound, ds.logger)
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, "Method not allowed", http.StatusMethodNotAllowed, ds.logger)
+		return
+	}
+
+	ds.logger.LogEvent("inspector_served", map[string]interface{}{"remote": r.RemoteAddr})
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(inspectorPage)
+}
@@ -11,8 +11,10 @@ func init() {
 	RegisterEndpoint("/launch-confirm-changes", handleLaunchConfirmChanges)
 }
 
-// LaunchConfirmChangesRequest represents the request to launch confirm changes screen
+// LaunchConfirmChangesRequest represents the request to launch confirm changes screen. The
+// named scenario, if any, is loaded first and the mock changes applied on top of it.
 type LaunchConfirmChangesRequest struct {
+	Scenario    string `json:"scenario,omitempty"`
 	MockChanges struct {
 		PermissionMoves []struct {
 			Name string `json:"name"`
@@ -103,6 +105,15 @@ func processLaunchRequest(
 	previousScreen := screenNumberToName(getCurrentScreen(model))
 	model.Mutex.RUnlock()
 
+	// Load the scenario the changes are made in
+	if request.Scenario != "" {
+		scenario, err := LoadScenario(request.Scenario)
+		if err != nil {
+			return nil, err
+		}
+		ds.program.Send(LoadStateMsg{State: scenario.State})
+	}
+
 	// Send message to launch confirm changes screen
 	msg := LaunchConfirmChangesMsg{Request: request}
 	ds.program.Send(msg)
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 
+	"claude-permissions/settingsfile"
 	"claude-permissions/types"
 
 	"github.com/charmbracelet/bubbles/v2/table"
@@ -70,7 +71,7 @@ func handleLoadSettings(ds *DebugServer, w http.ResponseWriter, r *http.Request)
 
 	// Rebuild permissions and duplicates
 	model.Permissions = consolidatePermissions(userLevel, repoLevel, localLevel)
-	model.Duplicates = findDuplicates(model.Permissions)
+	model.Duplicates = findDuplicates(model.Permissions, model.Config.DuplicatePriority())
 
 	// Recreate duplicates table with new data
 	model.DuplicatesTable = createDuplicatesTable(model.Duplicates)
@@ -166,6 +167,7 @@ func loadSettingsLevelFromPath(name, path string) (types.SettingsLevel, error) {
 		return level, err
 	}
 
+	data, level.Normalized = settingsfile.Standardize(data)
 	var settings types.Settings
 	if err := json.Unmarshal(data, &settings); err != nil {
 		return level, err
@@ -176,6 +178,10 @@ func loadSettingsLevelFromPath(name, path string) (types.SettingsLevel, error) {
 	if level.Permissions == nil {
 		level.Permissions = []string{}
 	}
+	level.Deny = settings.Deny
+	if level.Deny == nil {
+		level.Deny = []string{}
+	}
 
 	return level, nil
 }
@@ -230,7 +236,7 @@ func consolidatePermissions(
 }
 
 // findDuplicates identifies duplicate permissions across levels
-func findDuplicates(permissions []types.Permission) []types.Duplicate {
+func findDuplicates(permissions []types.Permission, priority []string) []types.Duplicate {
 	permissionMap := make(map[string][]types.Permission)
 
 	// Group permissions by name
@@ -246,13 +252,14 @@ func findDuplicates(permissions []types.Permission) []types.Duplicate {
 				levels[i] = perm.CurrentLevel
 			}
 
-			// Auto-select keep level using priority (User > Repo > Local)
-			keepLevel := determineKeepLevel(levels)
+			// Auto-select keep level using the configured priority
+			keepLevel := determineKeepLevel(levels, priority)
 
 			duplicates = append(duplicates, types.Duplicate{
-				Name:      name,
-				Levels:    levels,
-				KeepLevel: keepLevel,
+				Name:             name,
+				Levels:           levels,
+				KeepLevel:        keepLevel,
+				DefaultKeepLevel: keepLevel,
 			})
 		}
 	}
@@ -265,9 +272,9 @@ func findDuplicates(permissions []types.Permission) []types.Duplicate {
 	return duplicates
 }
 
-// determineKeepLevel selects the highest priority level (User > Repo > Local)
-func determineKeepLevel(levels []string) string {
-	for _, level := range []string{types.LevelUser, types.LevelRepo, types.LevelLocal} {
+// determineKeepLevel selects the first level in priority (most preferred first)
+func determineKeepLevel(levels, priority []string) string {
+	for _, level := range priority {
 		for _, l := range levels {
 			if l == level {
 				return level
@@ -299,7 +306,6 @@ func createDuplicatesTable(duplicates []types.Duplicate) table.Model {
 		table.WithColumns(columns),
 		table.WithRows(rows),
 		table.WithFocused(true),
-		table.WithHeight(7),
 	)
 
 	return t
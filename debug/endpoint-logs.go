@@ -1,31 +1,172 @@
 package debug
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"claude-permissions/logging"
 )
 
 func init() {
 	RegisterEndpoint("/logs", handleLogs)
 }
 
+// logFilterKeys are the standard log attributes that /logs accepts as query parameters
+var logFilterKeys = []string{
+	logging.KeyScreen,
+	logging.KeyComponent,
+	logging.KeyLevelName,
+	logging.KeyPermission,
+	logging.KeyChangeID,
+	logging.KeyProject,
+}
+
+// Bounds of the /logs page size and long-poll wait
+const (
+	maxLogLimit = 1000 // The whole buffer
+	maxLogWait  = 30 * time.Second
+)
+
 // LogResponse represents the logs endpoint response
 type LogResponse struct {
-	Entries []LogEntry `json:"entries"`
+	Entries     []LogEntry `json:"entries"`
+	NextSinceID int64      `json:"next_since_id"` // since_id that reads the entries after these
+	HasMore     bool       `json:"has_more"`      // More entries matched than limit returned
+}
+
+// LogClearResponse represents the response to clearing the logs
+type LogClearResponse struct {
+	Cleared   int    `json:"cleared"`
+	Timestamp string `json:"timestamp"`
 }
 
-// handleLogs handles the GET /logs endpoint
+// logQuery selects the entries a /logs request reads
+type logQuery struct {
+	sinceID int64             // Only entries with a greater ID
+	limit   int               // At most this many, oldest first
+	wait    time.Duration     // How long to wait for a first match
+	level   string            // Only entries of this level: debug, info, warning, or error
+	event   string            // Only entries of this event
+	attrs   map[string]string // Only entries with these standard attributes
+}
+
+// handleLogs handles the /logs endpoint. GET reads entries without removing them, so several
+// clients can follow the log: ?since_id= pages through it, ?level=, ?event=, and the standard
+// attributes such as ?component=save filter it, and ?wait=SECONDS waits for a match to be
+// logged. DELETE clears the buffer.
 func handleLogs(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodDelete:
+		cleared := ds.logger.Clear()
+		writeJSONResponse(w, LogClearResponse{
+			Cleared:   cleared,
+			Timestamp: getCurrentTimestamp(),
+		}, ds.logger)
+		return
+	default:
 		writeErrorResponse(w, "Method not allowed", http.StatusMethodNotAllowed, ds.logger)
 		return
 	}
 
-	// Get all entries and clear the buffer
-	entries := ds.logger.GetAndClearEntries()
+	query, err := parseLogQuery(r.URL.Query())
+	if err != nil {
+		writeErrorResponse(w, err.Error(), http.StatusBadRequest, ds.logger)
+		return
+	}
+
+	deadline := time.NewTimer(query.wait)
+	defer deadline.Stop()
+	for {
+		// Take the wakeup channel first so an entry logged while reading is not missed
+		logged := ds.logger.changed()
+		response := queryLogs(ds.logger.GetAllEntries(), query)
+		if len(response.Entries) > 0 || query.wait == 0 {
+			writeJSONResponse(w, response, ds.logger)
+			return
+		}
+		select {
+		case <-logged:
+		case <-deadline.C:
+			query.wait = 0
+		case <-r.Context().Done():
+			return
+		case <-ds.shutdown:
+			query.wait = 0
+		}
+	}
+}
 
-	response := LogResponse{
-		Entries: entries,
+// parseLogQuery reads the query parameters of a /logs request
+func parseLogQuery(values url.Values) (logQuery, error) {
+	query := logQuery{
+		limit: maxLogLimit,
+		level: values.Get("level"),
+		event: values.Get("event"),
+		attrs: make(map[string]string),
+	}
+	for _, key := range logFilterKeys {
+		if value := values.Get(key); value != "" {
+			query.attrs[key] = value
+		}
 	}
 
-	writeJSONResponse(w, response, ds.logger)
+	var err error
+	if value := values.Get("since_id"); value != "" {
+		if query.sinceID, err = strconv.ParseInt(value, 10, 64); err != nil || query.sinceID < 0 {
+			return query, fmt.Errorf("since_id must be a non-negative integer")
+		}
+	}
+	if value := values.Get("limit"); value != "" {
+		if query.limit, err = strconv.Atoi(value); err != nil ||
+			query.limit < 1 || query.limit > maxLogLimit {
+			return query, fmt.Errorf("limit must be between 1 and %d", maxLogLimit)
+		}
+	}
+	if value := values.Get("wait"); value != "" {
+		seconds, err := strconv.ParseFloat(value, 64)
+		wait := time.Duration(seconds * float64(time.Second))
+		if err != nil || wait < 0 || wait > maxLogWait {
+			return query, fmt.Errorf("wait must be between 0 and %.0f seconds",
+				maxLogWait.Seconds())
+		}
+		query.wait = wait
+	}
+	return query, nil
+}
+
+// queryLogs returns the page of entries query selects
+func queryLogs(entries []LogEntry, query logQuery) LogResponse {
+	response := LogResponse{Entries: []LogEntry{}, NextSinceID: query.sinceID}
+	for _, entry := range entries {
+		if entry.ID <= query.sinceID || !logEntryMatches(entry, query) {
+			continue
+		}
+		if len(response.Entries) == query.limit {
+			response.HasMore = true
+			break
+		}
+		response.Entries = append(response.Entries, entry)
+		response.NextSinceID = entry.ID
+	}
+	return response
+}
+
+// logEntryMatches reports whether the entry has the queried level and event and every
+// queried attribute with that value
+func logEntryMatches(entry LogEntry, query logQuery) bool {
+	if (query.level != "" && entry.Level != query.level) ||
+		(query.event != "" && entry.Event != query.event) {
+		return false
+	}
+	for key, want := range query.attrs {
+		value, ok := entry.Data[key]
+		if !ok || fmt.Sprint(value) != want {
+			return false
+		}
+	}
+	return true
 }
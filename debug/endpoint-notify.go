@@ -0,0 +1,69 @@
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"claude-permissions/notify"
+)
+
+func init() {
+	RegisterEndpoint("/notify", handleNotify)
+}
+
+// NotifyRequest represents a notification to queue in the status bar
+type NotifyRequest struct {
+	Message  string `json:"message"`
+	Severity string `json:"severity"` // info (default), warn, or error
+}
+
+// NotifyResponse represents the response to a queued notification
+type NotifyResponse struct {
+	Queued    int    `json:"queued"`
+	Timestamp string `json:"timestamp"`
+}
+
+// handleNotify handles the POST /notify endpoint
+func handleNotify(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, "Method not allowed", http.StatusMethodNotAllowed, ds.logger)
+		return
+	}
+
+	var request NotifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeErrorResponse(w, "Invalid JSON in request body", http.StatusBadRequest, ds.logger)
+		return
+	}
+	if request.Message == "" {
+		writeErrorResponse(w, "Message field is required", http.StatusBadRequest, ds.logger)
+		return
+	}
+
+	severity := notify.Severity(request.Severity)
+	switch severity {
+	case "":
+		severity = notify.SeverityInfo
+	case notify.SeverityInfo, notify.SeverityWarn, notify.SeverityError:
+	default:
+		writeErrorResponse(w, "Severity must be info, warn, or error", http.StatusBadRequest,
+			ds.logger)
+		return
+	}
+
+	model := ds.GetModel()
+	if model == nil {
+		writeErrorResponse(w, "Model not available", http.StatusInternalServerError, ds.logger)
+		return
+	}
+	model.Notifications.Push(severity, request.Message)
+
+	ds.logger.LogEvent("notification_queued", map[string]interface{}{
+		"severity": string(severity),
+		"message":  request.Message,
+	})
+	writeJSONResponse(w, NotifyResponse{
+		Queued:    model.Notifications.Len(),
+		Timestamp: getCurrentTimestamp(),
+	}, ds.logger)
+}
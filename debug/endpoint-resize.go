@@ -0,0 +1,77 @@
+package debug
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+func init() {
+	RegisterEndpoint("/resize", handleResize)
+}
+
+// maxResizeDimension bounds the sizes /resize accepts
+const maxResizeDimension = 1000
+
+// ResizeRequest represents a synthetic terminal resize
+type ResizeRequest struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// ResizeResponse represents the response to a resize, with the screen at the new size
+type ResizeResponse struct {
+	Width     int           `json:"width"`
+	Height    int           `json:"height"`
+	Snapshot  *SnapshotData `json:"snapshot,omitempty"`
+	Timestamp string        `json:"timestamp"`
+}
+
+// handleResize handles the POST /resize endpoint, sending the program a tea.WindowSizeMsg so
+// layouts can be tested at any size without resizing a real terminal
+func handleResize(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, "Method not allowed", http.StatusMethodNotAllowed, ds.logger)
+		return
+	}
+
+	var request ResizeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeErrorResponse(w, "Invalid JSON in request body", http.StatusBadRequest, ds.logger)
+		return
+	}
+	if request.Width < 1 || request.Width > maxResizeDimension ||
+		request.Height < 1 || request.Height > maxResizeDimension {
+		writeErrorResponse(w, fmt.Sprintf("Width and height must be between 1 and %d",
+			maxResizeDimension), http.StatusBadRequest, ds.logger)
+		return
+	}
+	if ds.program == nil {
+		writeErrorResponse(w, "No program instance available", http.StatusInternalServerError,
+			ds.logger)
+		return
+	}
+
+	// Snapshots report the injected size from now on, not the real terminal's
+	ds.SetTerminalSize(request.Width, request.Height)
+	ds.program.Send(tea.WindowSizeMsg{Width: request.Width, Height: request.Height})
+	time.Sleep(100 * time.Millisecond)
+
+	response := ResizeResponse{
+		Width:     request.Width,
+		Height:    request.Height,
+		Timestamp: getCurrentTimestamp(),
+	}
+	if snapshot, err := captureSnapshot(ds, true); err == nil {
+		response.Snapshot = snapshot
+	}
+
+	ds.logger.LogEvent("terminal_resized", map[string]interface{}{
+		"width":  request.Width,
+		"height": request.Height,
+	})
+	writeJSONResponse(w, response, ds.logger)
+}
@@ -0,0 +1,110 @@
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+func init() {
+	RegisterEndpoint("/scenario", handleScenario)
+}
+
+// ScenarioRequest names the scenario to load
+type ScenarioRequest struct {
+	Name string `json:"name"`
+}
+
+// ScenarioSummary describes an available scenario
+type ScenarioSummary struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ScenarioListResponse lists the available scenarios
+type ScenarioListResponse struct {
+	Scenarios []ScenarioSummary `json:"scenarios"`
+	Timestamp string            `json:"timestamp"`
+}
+
+// ScenarioResponse reports the scenario loaded
+type ScenarioResponse struct {
+	Success     bool   `json:"success"`
+	Scenario    string `json:"scenario"`
+	Description string `json:"description"`
+	Permissions int    `json:"permissions"`
+	Duplicates  int    `json:"duplicates"`
+	Timestamp   string `json:"timestamp"`
+}
+
+// handleScenario handles GET /scenario, listing the scenarios, and POST /scenario, loading
+// one in place of the model
+func handleScenario(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		listScenarios(ds, w)
+		return
+	case http.MethodPost:
+	default:
+		writeErrorResponse(w, "Method not allowed", http.StatusMethodNotAllowed, ds.logger)
+		return
+	}
+
+	var req ScenarioRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name == "" {
+		writeErrorResponse(w, "Request body must name a scenario", http.StatusBadRequest, ds.logger)
+		return
+	}
+	scenario, err := LoadScenario(req.Name)
+	if err != nil {
+		writeErrorResponse(w, err.Error(), http.StatusBadRequest, ds.logger)
+		return
+	}
+
+	model := ds.GetModel()
+	if model == nil || ds.program == nil {
+		writeErrorResponse(w, "Model not available", http.StatusInternalServerError, ds.logger)
+		return
+	}
+	ds.program.Send(LoadStateMsg{State: scenario.State})
+	time.Sleep(100 * time.Millisecond)
+
+	model.Mutex.RLock()
+	response := ScenarioResponse{
+		Success:     true,
+		Scenario:    scenario.Name,
+		Description: scenario.Description,
+		Permissions: len(model.Permissions),
+		Duplicates:  len(model.Duplicates),
+		Timestamp:   getCurrentTimestamp(),
+	}
+	model.Mutex.RUnlock()
+
+	ds.logger.LogEvent("scenario_loaded", map[string]interface{}{
+		"scenario":    response.Scenario,
+		"permissions": response.Permissions,
+		"duplicates":  response.Duplicates,
+	})
+
+	writeJSONResponse(w, response, ds.logger)
+}
+
+// listScenarios responds with the name and description of each scenario
+func listScenarios(ds *DebugServer, w http.ResponseWriter) {
+	scenarios, err := ListScenarios()
+	if err != nil {
+		writeErrorResponse(w, err.Error(), http.StatusInternalServerError, ds.logger)
+		return
+	}
+
+	response := ScenarioListResponse{
+		Scenarios: []ScenarioSummary{},
+		Timestamp: getCurrentTimestamp(),
+	}
+	for _, scenario := range scenarios {
+		response.Scenarios = append(response.Scenarios,
+			ScenarioSummary{Name: scenario.Name, Description: scenario.Description})
+	}
+
+	writeJSONResponse(w, response, ds.logger)
+}
@@ -0,0 +1,207 @@
+package debug
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"claude-permissions/types"
+)
+
+func init() {
+	RegisterEndpoint("/snapshot/compare", handleSnapshotCompare)
+}
+
+// goldenDir holds the golden snapshots, relative to the working directory like the scenarios
+const goldenDir = "testdata/golden"
+
+// volatilePatterns match text that changes between runs, such as clock times
+var volatilePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}(:\d{2})?(Z|[+-]\d{2}:?\d{2})?`),
+	regexp.MustCompile(`\b\d{1,2}:\d{2}(:\d{2})?\b`),
+	regexp.MustCompile(`\(\d+(\.\d+)?(ms|s|m|h)\)`),
+}
+
+// SnapshotCompareRequest names the golden file and extra regions to ignore
+type SnapshotCompareRequest struct {
+	Golden string   `json:"golden"`           // Name in testdata/golden, or a path ending in .txt
+	Ignore []string `json:"ignore,omitempty"` // Regular expressions masked on both sides
+	Update bool     `json:"update,omitempty"` // Write the current view as the golden file
+}
+
+// SnapshotLineDiff is a line that differs from the golden file
+type SnapshotLineDiff struct {
+	Line     int    `json:"line"` // 1-based
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// SnapshotCompareResponse reports how the view differs from the golden file
+type SnapshotCompareResponse struct {
+	Match         bool               `json:"match"`
+	Golden        string             `json:"golden"`
+	Updated       bool               `json:"updated"`
+	Width         int                `json:"width"`
+	Height        int                `json:"height"`
+	LinesCompared int                `json:"lines_compared"`
+	Differences   []SnapshotLineDiff `json:"differences"`
+	Timestamp     string             `json:"timestamp"`
+}
+
+// handleSnapshotCompare handles the POST /snapshot/compare endpoint
+func handleSnapshotCompare(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeErrorResponse(w, "Method not allowed", http.StatusMethodNotAllowed, ds.logger)
+		return
+	}
+
+	var req SnapshotCompareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Golden == "" {
+		writeErrorResponse(w, "Request body must name a golden file", http.StatusBadRequest,
+			ds.logger)
+		return
+	}
+	ignore, err := compileIgnorePatterns(req.Ignore)
+	if err != nil {
+		writeErrorResponse(w, err.Error(), http.StatusBadRequest, ds.logger)
+		return
+	}
+
+	snapshot, err := captureSnapshot(ds, true)
+	if err != nil {
+		writeErrorResponse(w, err.Error(), http.StatusInternalServerError, ds.logger)
+		return
+	}
+	model := ds.GetModel()
+	model.Mutex.RLock()
+	actual := normalizeSnapshot(model, snapshot.Content, ignore)
+	response := SnapshotCompareResponse{
+		Golden: goldenPath(req.Golden), Width: model.Width, Height: model.Height,
+		Differences: []SnapshotLineDiff{}, Timestamp: getCurrentTimestamp(),
+	}
+	model.Mutex.RUnlock()
+
+	status, err := compareGolden(&response, actual, ignore, req.Update)
+	if err != nil {
+		writeErrorResponse(w, err.Error(), status, ds.logger)
+		return
+	}
+
+	ds.logger.LogEvent("snapshot_compared", map[string]interface{}{
+		"golden":      response.Golden,
+		"match":       response.Match,
+		"updated":     response.Updated,
+		"differences": len(response.Differences),
+	})
+
+	writeJSONResponse(w, response, ds.logger)
+}
+
+// goldenPath returns the file of the named golden snapshot
+func goldenPath(name string) string {
+	if strings.HasSuffix(name, ".txt") {
+		return name
+	}
+	return filepath.Join(goldenDir, name+".txt")
+}
+
+// compileIgnorePatterns compiles the regions a request asks to ignore
+func compileIgnorePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// normalizeSnapshot masks the volatile regions of a plain-text view: settings paths and the
+// project directory, clock times and durations, and the regions matching ignore. Trailing
+// blanks are dropped from each line.
+func normalizeSnapshot(model *types.Model, content string, ignore []*regexp.Regexp) []string {
+	var replacements []string
+	for _, level := range editableLevels(model) {
+		if level.Path != "" {
+			replacements = append(replacements, level.Path, "<"+strings.ToLower(level.Name)+">")
+		}
+	}
+	if model.ProjectRoot != "" {
+		replacements = append(replacements, model.ProjectRoot, "<project>")
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		line = replacer.Replace(line)
+		for _, re := range volatilePatterns {
+			line = re.ReplaceAllString(line, "<time>")
+		}
+		for _, re := range ignore {
+			line = re.ReplaceAllString(line, "<ignored>")
+		}
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// compareGolden fills in how actual differs from the golden file, with the regions matching
+// ignore masked in both, or writes actual as the golden file when update is set; it returns
+// the status to respond with on error
+func compareGolden(
+	response *SnapshotCompareResponse, actual []string, ignore []*regexp.Regexp, update bool,
+) (int, error) {
+	if update {
+		if err := os.MkdirAll(filepath.Dir(response.Golden), 0o755); err != nil {
+			return http.StatusInternalServerError, err
+		}
+		content := strings.Join(actual, "\n") + "\n"
+		if err := os.WriteFile(response.Golden, []byte(content), 0o644); err != nil {
+			return http.StatusInternalServerError, err
+		}
+		response.Match, response.Updated, response.LinesCompared = true, true, len(actual)
+		return http.StatusOK, nil
+	}
+
+	data, err := os.ReadFile(response.Golden)
+	if errors.Is(err, os.ErrNotExist) {
+		return http.StatusNotFound, fmt.Errorf("golden file %s not found; compare with "+
+			"\"update\": true to create it", response.Golden)
+	} else if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	expected := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	for i, line := range expected {
+		for _, re := range ignore {
+			line = re.ReplaceAllString(line, "<ignored>")
+		}
+		expected[i] = line
+	}
+
+	response.LinesCompared = max(len(expected), len(actual))
+	for i := range response.LinesCompared {
+		var want, got string
+		if i < len(expected) {
+			want = expected[i]
+		}
+		if i < len(actual) {
+			got = actual[i]
+		}
+		if want != got {
+			response.Differences = append(response.Differences,
+				SnapshotLineDiff{Line: i + 1, Expected: want, Actual: got})
+		}
+	}
+	response.Match = len(response.Differences) == 0
+	return http.StatusOK, nil
+}
@@ -1,6 +1,7 @@
 package debug
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"claude-permissions/types"
@@ -15,6 +16,7 @@ type StateResponse struct {
 	UI        UIState    `json:"ui"`
 	Data      DataState  `json:"data"`
 	Files     FilesState `json:"files"`
+	Model     ModelState `json:"model"`
 	Errors    []string   `json:"errors"`
 	Timestamp string     `json:"timestamp"`
 }
@@ -48,9 +50,14 @@ type FilesState struct {
 	LocalPath   string `json:"local_path"`
 }
 
-// handleState handles the GET /state endpoint
+// handleState handles the GET and POST /state endpoint
 func handleState(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		handleLoadState(ds, w, r)
+		return
+	default:
 		writeErrorResponse(w, "Method not allowed", http.StatusMethodNotAllowed, ds.logger)
 		return
 	}
@@ -82,6 +89,7 @@ func extractApplicationState(model *types.Model) StateResponse {
 		UI:     extractUIState(model),
 		Data:   extractDataState(model),
 		Files:  extractFilesState(model),
+		Model:  extractModelState(model),
 		Errors: []string{}, // No more reflection errors
 	}
 }
@@ -98,7 +106,7 @@ func extractUIState(model *types.Model) UIState {
 		FilterText:    "",                                          // No filter in current UI implementation
 		ConfirmMode:   model.ConfirmMode,                           // Direct field access
 		ConfirmText:   model.ConfirmText,                           // Direct field access
-		StatusMessage: model.StatusMessage,                         // Direct field access
+		StatusMessage: currentStatusMessage(model),
 	}
 }
 
@@ -162,3 +170,38 @@ func extractPendingEdits(model *types.Model) []string {
 	// In the future, this could check for actual permission edit state
 	return edits
 }
+
+// handleLoadState handles POST /state, replacing the model with the posted one and
+// responding with the state it results in
+func handleLoadState(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
+	var state ModelState
+	if err := json.NewDecoder(r.Body).Decode(&state); err != nil {
+		writeErrorResponse(w, "Invalid JSON in request body", http.StatusBadRequest, ds.logger)
+		return
+	}
+	if err := state.Validate(); err != nil {
+		writeErrorResponse(w, err.Error(), http.StatusBadRequest, ds.logger)
+		return
+	}
+
+	model := ds.GetModel()
+	if model == nil || ds.program == nil {
+		writeErrorResponse(w, "Model not available", http.StatusInternalServerError, ds.logger)
+		return
+	}
+
+	// The application applies the state so its tables and scroll offsets follow
+	ds.program.Send(LoadStateMsg{State: state})
+	waitForSettle(ds)
+
+	response := extractApplicationState(model)
+	response.Timestamp = getCurrentTimestamp()
+
+	ds.logger.LogEvent("state_loaded", map[string]interface{}{
+		"permissions_count": response.Data.PermissionsCount,
+		"duplicates_count":  response.Data.DuplicatesCount,
+		"rule_edits":        len(response.Model.RuleEdits),
+	})
+
+	writeJSONResponse(w, response, ds.logger)
+}
@@ -0,0 +1,92 @@
+package debug
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"claude-permissions/types"
+)
+
+func init() {
+	RegisterEndpoint("/ws", handleWebSocket)
+}
+
+// handleWebSocket handles the /ws endpoint: a WebSocket streaming a JSON Event for each
+// message handled, each change of the model, and each change of the view. The stream opens
+// with the current state and view.
+func handleWebSocket(ds *DebugServer, w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeErrorResponse(w, "Method not allowed", http.StatusMethodNotAllowed, ds.logger)
+		return
+	}
+	model := ds.GetModel()
+	if model == nil {
+		writeErrorResponse(w, "Model not available", http.StatusInternalServerError, ds.logger)
+		return
+	}
+
+	// Subscribe before the handshake so no change between the first frames and the stream
+	// is missed
+	stream := events.subscribe()
+	defer events.unsubscribe(stream)
+
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errUnsupportedVersion) {
+			status = http.StatusUpgradeRequired
+		}
+		writeErrorResponse(w, err.Error(), status, ds.logger)
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	ds.logger.LogEvent("websocket_opened", map[string]interface{}{"remote": r.RemoteAddr})
+
+	closed := make(chan struct{})
+	go conn.readUntilClose(closed)
+
+	if err := sendInitialEvents(ds, conn, model); err != nil {
+		return
+	}
+	for {
+		select {
+		case data := <-stream:
+			if err := conn.WriteText(data); err != nil {
+				return
+			}
+		case <-closed:
+			ds.logger.LogEvent("websocket_closed", map[string]interface{}{"remote": r.RemoteAddr})
+			return
+		case <-ds.shutdown:
+			return
+		}
+	}
+}
+
+// sendInitialEvents sends the current state and view
+func sendInitialEvents(ds *DebugServer, conn *wsConn, model *types.Model) error {
+	model.Mutex.RLock()
+	state := extractModelState(model)
+	width, height := model.Width, model.Height
+	model.Mutex.RUnlock()
+
+	frames := []Event{{Type: EventState, State: &state}}
+	if ds.viewProvider != nil {
+		frames = append(frames, Event{
+			Type: EventRender, Content: stripANSICodes(ds.viewProvider.GetView()),
+			Width: width, Height: height,
+		})
+	}
+	for _, frame := range frames {
+		frame.Timestamp = getCurrentTimestamp()
+		data, err := json.Marshal(frame)
+		if err != nil {
+			return err
+		}
+		if err := conn.WriteText(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"claude-permissions/types"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// Event types streamed by /ws
+const (
+	EventUpdate = "update" // A message was handled
+	EventState  = "state"  // The model changed
+	EventRender = "render" // The view changed
+)
+
+// Event is one frame of the /ws stream
+type Event struct {
+	Type      string      `json:"type"`
+	Msg       string      `json:"msg,omitempty"`   // Update: the kind of message handled
+	Key       string      `json:"key,omitempty"`   // Update: the key pressed
+	State     *ModelState `json:"state,omitempty"` // State: the model after the change
+	Content   string      `json:"content,omitempty"`
+	Width     int         `json:"width,omitempty"` // Render: the terminal size
+	Height    int         `json:"height,omitempty"`
+	Timestamp string      `json:"timestamp"`
+}
+
+// eventBuffer is how many frames a subscriber may fall behind before frames are dropped
+const eventBuffer = 256
+
+// eventHub fans events out to the /ws subscribers
+type eventHub struct {
+	mutex       sync.Mutex
+	subscribers map[chan []byte]struct{}
+}
+
+// events carries the events of the running program to every /ws connection
+var events = &eventHub{subscribers: make(map[chan []byte]struct{})}
+
+// subscribe returns a channel receiving each event published from now on
+func (h *eventHub) subscribe() chan []byte {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	ch := make(chan []byte, eventBuffer)
+	h.subscribers[ch] = struct{}{}
+	return ch
+}
+
+// unsubscribe stops sending events to ch
+func (h *eventHub) unsubscribe(ch chan []byte) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	delete(h.subscribers, ch)
+}
+
+// active reports whether anyone is subscribed, so events are only built when they are read
+func (h *eventHub) active() bool {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return len(h.subscribers) > 0
+}
+
+// publish sends event to every subscriber, dropping it for those too far behind
+func (h *eventHub) publish(event Event) {
+	event.Timestamp = getCurrentTimestamp()
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- data:
+		default:
+		}
+	}
+}
+
+// Observer is a tea.Model that publishes what its model handles, and how the model and view
+// change as a result, to the /ws stream
+type Observer struct {
+	model     tea.Model
+	state     *types.Model
+	lastState []byte
+	lastView  string
+	settled   []chan struct{} // Closed once the next view is rendered
+}
+
+// settleMsg asks the Observer to close done once every message sent before it has been handled
+// and the view rendered, so /input can wait for its keys instead of sleeping
+type settleMsg struct {
+	done chan struct{}
+}
+
+// Observe wraps model, whose state is held in state, to stream its changes
+func Observe(model tea.Model, state *types.Model) *Observer {
+	return &Observer{model: model, state: state}
+}
+
+// Init implements tea.Model interface
+func (o *Observer) Init() tea.Cmd {
+	return o.model.Init()
+}
+
+// Update passes msg to the model, then publishes it and the new state if it changed
+func (o *Observer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if settle, ok := msg.(settleMsg); ok {
+		o.settled = append(o.settled, settle.done)
+		return o, nil
+	}
+
+	var cmd tea.Cmd
+	o.model, cmd = o.model.Update(msg)
+	if !events.active() {
+		return o, cmd
+	}
+
+	update := Event{Type: EventUpdate, Msg: fmt.Sprintf("%T", msg)}
+	if codec, _, ok := codecFor(msg); ok {
+		update.Msg = codec.Kind
+	}
+	if key, ok := msg.(tea.KeyPressMsg); ok {
+		update.Key = key.String()
+	}
+	events.publish(update)
+
+	o.state.Mutex.RLock()
+	state := extractModelState(o.state)
+	o.state.Mutex.RUnlock()
+	if data, err := json.Marshal(state); err == nil && !bytes.Equal(data, o.lastState) {
+		o.lastState = data
+		events.publish(Event{Type: EventState, State: &state})
+	}
+	return o, cmd
+}
+
+// View renders the model, publishing the view when it changed
+func (o *Observer) View() string {
+	view := viewOf(o.model)
+	for _, done := range o.settled {
+		close(done)
+	}
+	o.settled = nil
+	if view != o.lastView && events.active() {
+		o.lastView = view
+		o.state.Mutex.RLock()
+		width, height := o.state.Width, o.state.Height
+		o.state.Mutex.RUnlock()
+		events.publish(Event{
+			Type: EventRender, Content: stripANSICodes(view), Width: width, Height: height,
+		})
+	}
+	return view
+}
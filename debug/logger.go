@@ -21,6 +21,7 @@ type Logger struct {
 	entries    []LogEntry
 	nextID     int64
 	maxEntries int
+	logged     chan struct{} // Closed and replaced when an entry is added
 }
 
 // NewLogger creates a new logger instance
@@ -29,6 +30,7 @@ func NewLogger() *Logger {
 		entries:    make([]LogEntry, 0),
 		nextID:     1,
 		maxEntries: 1000, // Circular buffer of 1000 entries
+		logged:     make(chan struct{}),
 	}
 }
 
@@ -116,21 +118,17 @@ func (l *Logger) addEntry(entry LogEntry) {
 		// Remove the oldest entry
 		l.entries = l.entries[1:]
 	}
-}
 
-// GetAndClearEntries returns all current entries and clears the buffer
-func (l *Logger) GetAndClearEntries() []LogEntry {
-	l.mutex.Lock()
-	defer l.mutex.Unlock()
-
-	// Return all entries
-	result := make([]LogEntry, len(l.entries))
-	copy(result, l.entries)
-
-	// Clear the buffer
-	l.entries = make([]LogEntry, 0)
+	// Wake the readers waiting for new entries
+	close(l.logged)
+	l.logged = make(chan struct{})
+}
 
-	return result
+// changed returns a channel closed when the next entry is added
+func (l *Logger) changed() <-chan struct{} {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.logged
 }
 
 // GetAllEntries returns all current entries
@@ -150,13 +148,15 @@ func (l *Logger) GetNextID() int64 {
 	return l.nextID
 }
 
-// Clear clears all log entries
-func (l *Logger) Clear() {
+// Clear clears all log entries, returning how many there were. IDs keep counting up, so
+// readers paging by ID miss nothing logged afterwards.
+func (l *Logger) Clear() int {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
 
+	cleared := len(l.entries)
 	l.entries = make([]LogEntry, 0)
-	l.nextID = 1
+	return cleared
 }
 
 // SetMaxEntries sets the maximum number of entries to keep
@@ -290,6 +290,8 @@ func (l *Logger) LogStatusMessage(message string) {
 // DebugSlogHandler implements slog.Handler to route logs to the debug server
 type DebugSlogHandler struct {
 	logger *Logger
+	attrs  []slog.Attr // Added by WithAttrs, already qualified by any groups
+	group  string      // Dotted prefix for attribute keys added after WithGroup
 }
 
 // NewDebugSlogHandler creates a new slog handler that routes to the debug server
@@ -309,8 +311,11 @@ func (h *DebugSlogHandler) Enabled(_ context.Context, level slog.Level) bool {
 func (h *DebugSlogHandler) Handle(_ context.Context, r slog.Record) error {
 	// Convert slog attributes to map for debug server
 	data := make(map[string]interface{})
-	r.Attrs(func(attr slog.Attr) bool {
+	for _, attr := range h.attrs {
 		data[attr.Key] = attr.Value.Any()
+	}
+	r.Attrs(func(attr slog.Attr) bool {
+		data[h.group+attr.Key] = attr.Value.Any()
 		return true
 	})
 
@@ -339,16 +344,20 @@ func (h *DebugSlogHandler) Handle(_ context.Context, r slog.Record) error {
 	return nil
 }
 
-// WithAttrs returns a new handler with the given attributes added
+// WithAttrs returns a new handler with the given attributes added to every entry
 func (h *DebugSlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
-	// For simplicity, return the same handler
-	// In a more sophisticated implementation, we'd maintain attribute context
-	return h
+	combined := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	combined = append(combined, h.attrs...)
+	for _, attr := range attrs {
+		combined = append(combined, slog.Attr{Key: h.group + attr.Key, Value: attr.Value})
+	}
+	return &DebugSlogHandler{logger: h.logger, attrs: combined, group: h.group}
 }
 
-// WithGroup returns a new handler with the given group name
+// WithGroup returns a new handler that prefixes later attribute keys with "name."
 func (h *DebugSlogHandler) WithGroup(name string) slog.Handler {
-	// For simplicity, return the same handler
-	// In a more sophisticated implementation, we'd maintain group context
-	return h
+	if name == "" {
+		return h
+	}
+	return &DebugSlogHandler{logger: h.logger, attrs: h.attrs, group: h.group + name + "."}
 }
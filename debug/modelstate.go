@@ -0,0 +1,349 @@
+package debug
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"claude-permissions/types"
+)
+
+// ModelState is the complete serialized model, as returned by GET /state and loaded by POST
+// /state and scenarios. Levels hold each rule where it currently is; pending moves are
+// permissions whose current level differs from their original one.
+type ModelState struct {
+	Levels         LevelsState          `json:"levels"`
+	Permissions    []PermissionState    `json:"permissions"`    // Derived from levels when omitted
+	Duplicates     []DuplicateState     `json:"duplicates"`     // Derived from levels when omitted
+	Contradictions []ContradictionState `json:"contradictions"` // Winner "" is unresolved
+	RuleEdits      []RuleEditState      `json:"rule_edits"`     // Replacement "" deletes the rule
+	Consolidations []ConsolidationState `json:"consolidations"`
+	StaleRemovals  []StaleRuleState     `json:"stale_removals"`
+	Cursor         CursorState          `json:"cursor"`
+	ActiveModal    string               `json:"active_modal,omitempty"` // Ignored by POST
+}
+
+// LevelsState holds the three editable settings levels
+type LevelsState struct {
+	User  LevelState `json:"user"`
+	Repo  LevelState `json:"repo"`
+	Local LevelState `json:"local"`
+}
+
+// LevelState is one settings level
+type LevelState struct {
+	Path      string   `json:"path"`
+	Exists    bool     `json:"exists"`
+	Allow     []string `json:"allow"`
+	Deny      []string `json:"deny"`
+	LoadError string   `json:"load_error,omitempty"`
+}
+
+// PermissionState is an allow rule with the level it was loaded at and the level it is at
+type PermissionState struct {
+	Name          string `json:"name"`
+	CurrentLevel  string `json:"current_level"`
+	OriginalLevel string `json:"original_level"`
+	Added         bool   `json:"added,omitempty"`
+}
+
+// DuplicateState is a rule allowed at several levels and the level chosen to keep it
+type DuplicateState struct {
+	Name             string   `json:"name"`
+	Levels           []string `json:"levels"`
+	KeepLevel        string   `json:"keep_level"`
+	DefaultKeepLevel string   `json:"default_keep_level"`
+}
+
+// ContradictionState is a rule both allowed and denied
+type ContradictionState struct {
+	Name        string   `json:"name"`
+	AllowLevels []string `json:"allow_levels"`
+	DenyLevels  []string `json:"deny_levels"`
+	Winner      string   `json:"winner"`
+}
+
+// RuleEditState is a pending edit or deletion of an allow rule
+type RuleEditState struct {
+	Level       string `json:"level"`
+	Rule        string `json:"rule"`
+	Replacement string `json:"replacement"`
+}
+
+// ConsolidationState is an accepted consolidation suggestion
+type ConsolidationState struct {
+	Level       string   `json:"level"`
+	Replacement string   `json:"replacement"`
+	Rules       []string `json:"rules"`
+}
+
+// StaleRuleState is a stale rule marked for removal
+type StaleRuleState struct {
+	Level  string `json:"level"`
+	Rule   string `json:"rule"`
+	Target string `json:"target"`
+}
+
+// CursorState is where the cursor is on each screen
+type CursorState struct {
+	CurrentScreen         int    `json:"current_screen"`
+	FocusedColumn         int    `json:"focused_column"` // 0=LOCAL, 1=REPO, 2=USER
+	ColumnSelections      [3]int `json:"column_selections"`
+	DuplicatesCursor      int    `json:"duplicates_cursor"`
+	ContradictionCursor   int    `json:"contradiction_cursor"`
+	ContradictionsFocused bool   `json:"contradictions_focused"`
+}
+
+// LoadStateMsg asks the application to replace its model with State
+type LoadStateMsg struct {
+	State ModelState
+}
+
+// extractModelState serializes the levels, rules, pending changes, and cursors of the model
+func extractModelState(model *types.Model) ModelState {
+	state := ModelState{
+		Levels: LevelsState{
+			User:  extractLevelState(model.UserLevel),
+			Repo:  extractLevelState(model.RepoLevel),
+			Local: extractLevelState(model.LocalLevel),
+		},
+		Permissions:    []PermissionState{},
+		Duplicates:     []DuplicateState{},
+		Contradictions: []ContradictionState{},
+		RuleEdits:      []RuleEditState{},
+		Consolidations: []ConsolidationState{},
+		StaleRemovals:  []StaleRuleState{},
+		Cursor: CursorState{
+			CurrentScreen:         model.CurrentScreen,
+			FocusedColumn:         model.FocusedColumn,
+			ColumnSelections:      model.ColumnSelections,
+			DuplicatesCursor:      model.DuplicatesTable.Cursor(),
+			ContradictionCursor:   model.ContradictionCursor,
+			ContradictionsFocused: model.ContradictionsFocused,
+		},
+	}
+	if model.ActiveModal != nil {
+		state.ActiveModal = strings.TrimPrefix(fmt.Sprintf("%T", model.ActiveModal), "*ui.")
+	}
+
+	for _, perm := range model.Permissions {
+		state.Permissions = append(state.Permissions, PermissionState{
+			Name:          perm.Name,
+			CurrentLevel:  perm.CurrentLevel,
+			OriginalLevel: perm.OriginalLevel,
+			Added:         perm.Added,
+		})
+	}
+	for _, dup := range model.Duplicates {
+		state.Duplicates = append(state.Duplicates, DuplicateState(dup))
+	}
+	for _, c := range model.Contradictions {
+		state.Contradictions = append(state.Contradictions, ContradictionState(c))
+	}
+	for _, edit := range model.RuleEdits {
+		state.RuleEdits = append(state.RuleEdits, RuleEditState(edit))
+	}
+	for _, c := range model.Consolidations {
+		state.Consolidations = append(state.Consolidations, ConsolidationState(c))
+	}
+	for _, stale := range model.StaleRemovals {
+		state.StaleRemovals = append(state.StaleRemovals, StaleRuleState(stale))
+	}
+	return state
+}
+
+// extractLevelState serializes a settings level
+func extractLevelState(level types.SettingsLevel) LevelState {
+	return LevelState{
+		Path:      level.Path,
+		Exists:    level.Exists,
+		Allow:     append([]string{}, level.Permissions...),
+		Deny:      append([]string{}, level.Deny...),
+		LoadError: level.LoadError,
+	}
+}
+
+// Validate reports the first level name in the state that is not User, Repo, or Local, or
+// the first cursor that points outside the screens or columns
+func (s ModelState) Validate() error {
+	var levels []string
+	for _, perm := range s.Permissions {
+		levels = append(levels, perm.CurrentLevel, perm.OriginalLevel)
+	}
+	for _, dup := range s.Duplicates {
+		levels = append(levels, dup.Levels...)
+		levels = append(levels, dup.KeepLevel)
+	}
+	for _, c := range s.Contradictions {
+		levels = append(append(levels, c.AllowLevels...), c.DenyLevels...)
+	}
+	for _, edit := range s.RuleEdits {
+		levels = append(levels, edit.Level)
+	}
+	for _, c := range s.Consolidations {
+		levels = append(levels, c.Level)
+	}
+	for _, stale := range s.StaleRemovals {
+		levels = append(levels, stale.Level)
+	}
+
+	valid := []string{types.LevelUser, types.LevelRepo, types.LevelLocal, ""}
+	for _, level := range levels {
+		if !slices.Contains(valid, level) {
+			return fmt.Errorf("unknown level %q: use User, Repo, or Local", level)
+		}
+	}
+	if screen := s.Cursor.CurrentScreen; screen < types.ScreenDuplicates ||
+		screen > types.ScreenPolicy {
+		return fmt.Errorf("current_screen %d is out of range %d-%d", screen,
+			types.ScreenDuplicates, types.ScreenPolicy)
+	}
+	if s.Cursor.FocusedColumn < 0 || s.Cursor.FocusedColumn > 2 {
+		return fmt.Errorf("focused_column %d is out of range 0-2", s.Cursor.FocusedColumn)
+	}
+	for i, selection := range s.Cursor.ColumnSelections {
+		if selection < 0 {
+			return fmt.Errorf("column_selections[%d] is negative", i)
+		}
+	}
+	return nil
+}
+
+// Apply replaces the levels, rules, pending changes, and cursors of model with the state,
+// closing any modal. Permissions and duplicates omitted from the state are derived from the
+// levels as a load would, and column selections past the end of their column select its
+// last rule. The caller rebuilds the duplicates table.
+func (s ModelState) Apply(model *types.Model) {
+	applyLevelState(&model.UserLevel, types.LevelUser, s.Levels.User)
+	applyLevelState(&model.RepoLevel, types.LevelRepo, s.Levels.Repo)
+	applyLevelState(&model.LocalLevel, types.LevelLocal, s.Levels.Local)
+
+	model.Permissions = nil
+	for _, perm := range s.Permissions {
+		model.Permissions = append(model.Permissions, types.Permission{
+			Name:          perm.Name,
+			CurrentLevel:  perm.CurrentLevel,
+			OriginalLevel: perm.OriginalLevel,
+			Added:         perm.Added,
+		})
+	}
+	if s.Permissions == nil {
+		model.Permissions = derivePermissions(model)
+	}
+
+	model.Duplicates = nil
+	for _, dup := range s.Duplicates {
+		model.Duplicates = append(model.Duplicates, types.Duplicate(dup))
+	}
+	if s.Duplicates == nil {
+		model.Duplicates = deriveDuplicates(model)
+	}
+
+	model.Contradictions = nil
+	for _, c := range s.Contradictions {
+		model.Contradictions = append(model.Contradictions, types.Contradiction(c))
+	}
+	model.RuleEdits = nil
+	for _, edit := range s.RuleEdits {
+		model.RuleEdits = append(model.RuleEdits, types.RuleEdit(edit))
+	}
+	model.Consolidations = nil
+	for _, c := range s.Consolidations {
+		model.Consolidations = append(model.Consolidations, types.Consolidation(c))
+	}
+	model.StaleRemovals = nil
+	for _, stale := range s.StaleRemovals {
+		model.StaleRemovals = append(model.StaleRemovals, types.StaleRule(stale))
+	}
+
+	model.CurrentScreen = s.Cursor.CurrentScreen
+	model.FocusedColumn = s.Cursor.FocusedColumn
+	for i, level := range [...]string{types.LevelLocal, types.LevelRepo, types.LevelUser} {
+		count := 0
+		for _, perm := range model.Permissions {
+			if perm.CurrentLevel == level {
+				count++
+			}
+		}
+		model.ColumnSelections[i] = max(min(s.Cursor.ColumnSelections[i], count-1), 0)
+	}
+	model.ContradictionCursor = s.Cursor.ContradictionCursor
+	model.ContradictionsFocused = s.Cursor.ContradictionsFocused
+	model.ActiveModal = nil
+	model.ConfirmMode = false
+}
+
+// applyLevelState replaces the rules and load state of level, and its path unless the state
+// leaves it empty
+func applyLevelState(level *types.SettingsLevel, name string, state LevelState) {
+	level.Name = name
+	if state.Path != "" {
+		level.Path = state.Path
+	}
+	level.Exists = state.Exists
+	level.Permissions = append([]string{}, state.Allow...)
+	level.Deny = append([]string{}, state.Deny...)
+	level.LoadError = state.LoadError
+}
+
+// derivePermissions lists each allow rule of the levels once, at the first of User, Repo,
+// and Local that has it
+func derivePermissions(model *types.Model) []types.Permission {
+	seen := make(map[string]bool)
+	var permissions []types.Permission
+	for _, level := range editableLevels(model) {
+		for _, rule := range level.Permissions {
+			if seen[rule] {
+				continue
+			}
+			seen[rule] = true
+			permissions = append(permissions, types.Permission{
+				Name:          rule,
+				CurrentLevel:  level.Name,
+				OriginalLevel: level.Name,
+			})
+		}
+	}
+	sort.Slice(permissions, func(i, j int) bool {
+		return strings.ToLower(permissions[i].Name) < strings.ToLower(permissions[j].Name)
+	})
+	return permissions
+}
+
+// deriveDuplicates lists the allow rules found at several levels, keeping each at the
+// level the configured priority prefers
+func deriveDuplicates(model *types.Model) []types.Duplicate {
+	found := make(map[string][]string)
+	for _, level := range editableLevels(model) {
+		for _, rule := range level.Permissions {
+			found[rule] = append(found[rule], level.Name)
+		}
+	}
+
+	var duplicates []types.Duplicate
+	for rule, levels := range found {
+		if len(levels) < 2 {
+			continue
+		}
+		keep := levels[0]
+		for _, level := range model.Config.DuplicatePriority() {
+			if slices.Contains(levels, level) {
+				keep = level
+				break
+			}
+		}
+		duplicates = append(duplicates, types.Duplicate{
+			Name: rule, Levels: levels, KeepLevel: keep, DefaultKeepLevel: keep,
+		})
+	}
+	sort.Slice(duplicates, func(i, j int) bool {
+		return strings.ToLower(duplicates[i].Name) < strings.ToLower(duplicates[j].Name)
+	})
+	return duplicates
+}
+
+// editableLevels returns the User, Repo, and Local levels in that order
+func editableLevels(model *types.Model) []types.SettingsLevel {
+	return []types.SettingsLevel{model.UserLevel, model.RepoLevel, model.LocalLevel}
+}
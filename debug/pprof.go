@@ -0,0 +1,20 @@
+package debug
+
+import "net/http/pprof"
+
+// pprofPrefix is where EnableProfiling mounts the profiles
+const pprofPrefix = "/debug/pprof/"
+
+// EnableProfiling serves the net/http/pprof profiles under /debug/pprof/, so CPU and heap
+// profiles can be taken while the editor runs. Endpoint filters do not apply to them; call it
+// before Start.
+func (ds *DebugServer) EnableProfiling() {
+	ds.mux.HandleFunc(pprofPrefix, pprof.Index)
+	ds.mux.HandleFunc(pprofPrefix+"cmdline", pprof.Cmdline)
+	ds.mux.HandleFunc(pprofPrefix+"profile", pprof.Profile)
+	ds.mux.HandleFunc(pprofPrefix+"symbol", pprof.Symbol)
+	ds.mux.HandleFunc(pprofPrefix+"trace", pprof.Trace)
+	ds.endpoints = append(ds.endpoints, pprofPrefix)
+
+	ds.logger.LogEvent("profiling_enabled", map[string]interface{}{"path": pprofPrefix})
+}
@@ -0,0 +1,275 @@
+package debug
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// RecordedMsg is one message of a recorded session, one JSON object per line of the file
+type RecordedMsg struct {
+	At   int64           `json:"at_ms"` // Milliseconds since the recording started
+	Kind string          `json:"kind"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// MsgCodec records and recreates one kind of message. Messages of no registered kind, such
+// as the results of saves, are not recorded; replaying the input recreates them.
+type MsgCodec struct {
+	Kind   string
+	Encode func(msg tea.Msg) (data interface{}, ok bool)
+	Decode func(data json.RawMessage) (tea.Msg, error)
+}
+
+var (
+	codecsMutex sync.RWMutex
+	codecs      []MsgCodec
+)
+
+// RegisterMsgCodec adds a kind of message to record and replay; packages register their
+// own timer messages in init
+func RegisterMsgCodec(codec MsgCodec) {
+	codecsMutex.Lock()
+	defer codecsMutex.Unlock()
+	codecs = append(codecs, codec)
+}
+
+// SignalMsgCodec returns a codec for a message type without fields, such as a timer tick
+func SignalMsgCodec[T any](kind string) MsgCodec {
+	return MsgCodec{
+		Kind: kind,
+		Encode: func(msg tea.Msg) (interface{}, bool) {
+			_, ok := msg.(T)
+			return nil, ok
+		},
+		Decode: func(json.RawMessage) (tea.Msg, error) {
+			var msg T
+			return msg, nil
+		},
+	}
+}
+
+func init() {
+	RegisterMsgCodec(MsgCodec{
+		Kind: "key",
+		Encode: func(msg tea.Msg) (interface{}, bool) {
+			key, ok := msg.(tea.KeyPressMsg)
+			return tea.Key(key), ok
+		},
+		Decode: func(data json.RawMessage) (tea.Msg, error) {
+			var key tea.Key
+			err := json.Unmarshal(data, &key)
+			return tea.KeyPressMsg(key), err
+		},
+	})
+	RegisterMsgCodec(MsgCodec{
+		Kind: "resize",
+		Encode: func(msg tea.Msg) (interface{}, bool) {
+			size, ok := msg.(tea.WindowSizeMsg)
+			return size, ok
+		},
+		Decode: func(data json.RawMessage) (tea.Msg, error) {
+			var size tea.WindowSizeMsg
+			err := json.Unmarshal(data, &size)
+			return size, err
+		},
+	})
+	RegisterMsgCodec(textMsgCodec[tea.PasteMsg]("paste"))
+	RegisterMsgCodec(textMsgCodec[tea.ClipboardMsg]("clipboard"))
+}
+
+// textMsgCodec returns a codec for a message that is a string
+func textMsgCodec[T ~string](kind string) MsgCodec {
+	return MsgCodec{
+		Kind: kind,
+		Encode: func(msg tea.Msg) (interface{}, bool) {
+			text, ok := msg.(T)
+			return string(text), ok
+		},
+		Decode: func(data json.RawMessage) (tea.Msg, error) {
+			var text string
+			err := json.Unmarshal(data, &text)
+			return T(text), err
+		},
+	}
+}
+
+// codecFor returns the codec recording msg, if any, and the data it records
+func codecFor(msg tea.Msg) (MsgCodec, interface{}, bool) {
+	codecsMutex.RLock()
+	defer codecsMutex.RUnlock()
+	for _, codec := range codecs {
+		if data, ok := codec.Encode(msg); ok {
+			return codec, data, true
+		}
+	}
+	return MsgCodec{}, nil, false
+}
+
+// codecOf returns the codec registered for kind
+func codecOf(kind string) (MsgCodec, bool) {
+	codecsMutex.RLock()
+	defer codecsMutex.RUnlock()
+	for _, codec := range codecs {
+		if codec.Kind == kind {
+			return codec, true
+		}
+	}
+	return MsgCodec{}, false
+}
+
+// Recorder is a tea.Model that writes the keys, resizes, and timer ticks its model receives
+// to a session file, with the time each arrived, for Replayer to feed back
+type Recorder struct {
+	model tea.Model
+	file  *os.File
+	out   *bufio.Writer
+	start time.Time
+}
+
+// NewRecorder records the messages model receives to path, replacing any earlier recording
+func NewRecorder(model tea.Model, path string) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording %s: %w", path, err)
+	}
+	return &Recorder{model: model, file: file, out: bufio.NewWriter(file), start: time.Now()}, nil
+}
+
+// Init implements tea.Model interface
+func (r *Recorder) Init() tea.Cmd {
+	return r.model.Init()
+}
+
+// Update records msg and passes it to the model
+func (r *Recorder) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if codec, data, ok := codecFor(msg); ok {
+		recorded := RecordedMsg{At: time.Since(r.start).Milliseconds(), Kind: codec.Kind}
+		if data != nil {
+			recorded.Data, _ = json.Marshal(data)
+		}
+		line, _ := json.Marshal(recorded)
+		_, _ = r.out.Write(append(line, '\n'))
+	}
+
+	var cmd tea.Cmd
+	r.model, cmd = r.model.Update(msg)
+	return r, cmd
+}
+
+// View implements tea.ViewModel interface
+func (r *Recorder) View() string {
+	return viewOf(r.model)
+}
+
+// Close finishes the recording
+func (r *Recorder) Close() error {
+	if err := r.out.Flush(); err != nil {
+		_ = r.file.Close()
+		return fmt.Errorf("failed to write recording %s: %w", r.file.Name(), err)
+	}
+	return r.file.Close()
+}
+
+// replayMsg delivers the recorded message at index
+type replayMsg struct {
+	index int
+}
+
+// Replayer is a tea.Model that feeds its model a recorded session at the recorded times. Live
+// messages of the recorded kinds are dropped so the session replays the same way every time,
+// except ctrl+c, which quits.
+type Replayer struct {
+	model tea.Model
+	msgs  []tea.Msg
+	at    []time.Duration
+	start time.Time
+}
+
+// NewReplayer reads the session recorded at path to feed to model
+func NewReplayer(model tea.Model, path string) (*Replayer, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	r := &Replayer{model: model}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var recorded RecordedMsg
+		if err := json.Unmarshal(scanner.Bytes(), &recorded); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		codec, ok := codecOf(recorded.Kind)
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: unknown message kind %q", path, line, recorded.Kind)
+		}
+		msg, err := codec.Decode(recorded.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		r.msgs = append(r.msgs, msg)
+		r.at = append(r.at, time.Duration(recorded.At)*time.Millisecond)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read recording %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Init starts the model and the replay
+func (r *Replayer) Init() tea.Cmd {
+	r.start = time.Now()
+	return tea.Batch(r.model.Init(), r.next(0))
+}
+
+// next waits until the recorded message at index is due
+func (r *Replayer) next(index int) tea.Cmd {
+	if index >= len(r.msgs) {
+		return nil
+	}
+	return tea.Tick(time.Until(r.start.Add(r.at[index])), func(time.Time) tea.Msg {
+		return replayMsg{index: index}
+	})
+}
+
+// Update passes the model the recorded messages as they fall due and messages of kinds that
+// are not recorded
+func (r *Replayer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if replay, ok := msg.(replayMsg); ok {
+		var cmd tea.Cmd
+		r.model, cmd = r.model.Update(r.msgs[replay.index])
+		return r, tea.Batch(cmd, r.next(replay.index+1))
+	}
+
+	if key, ok := msg.(tea.KeyPressMsg); ok && key.String() == "ctrl+c" {
+		return r, tea.Quit
+	}
+	if _, _, recorded := codecFor(msg); recorded {
+		return r, nil
+	}
+
+	var cmd tea.Cmd
+	r.model, cmd = r.model.Update(msg)
+	return r, cmd
+}
+
+// View implements tea.ViewModel interface
+func (r *Replayer) View() string {
+	return viewOf(r.model)
+}
+
+// viewOf renders model, which has no view unless it implements tea.ViewModel
+func viewOf(model tea.Model) string {
+	if view, ok := model.(tea.ViewModel); ok {
+		return view.View()
+	}
+	return ""
+}
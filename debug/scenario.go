@@ -0,0 +1,69 @@
+package debug
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ScenarioDir holds the named scenarios, relative to the working directory like the rest of
+// the test data
+const ScenarioDir = "testdata/scenarios"
+
+// Scenario is a named model state, such as "50-duplicates" or "empty-levels", that puts the
+// editor in a known situation for testing
+type Scenario struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	State       ModelState `json:"state"`
+}
+
+// LoadScenario reads the scenario called name from ScenarioDir, or from name itself when it
+// is the path of a JSON file
+func LoadScenario(name string) (Scenario, error) {
+	path := name
+	if !strings.HasSuffix(name, ".json") {
+		path = filepath.Join(ScenarioDir, name+".json")
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Scenario{}, fmt.Errorf("scenario %q not found in %s", name, ScenarioDir)
+	} else if err != nil {
+		return Scenario{}, fmt.Errorf("failed to read scenario %s: %w", path, err)
+	}
+
+	var scenario Scenario
+	if err := json.Unmarshal(data, &scenario); err != nil {
+		return Scenario{}, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	if err := scenario.State.Validate(); err != nil {
+		return Scenario{}, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+	scenario.Name = strings.TrimSuffix(filepath.Base(path), ".json")
+	return scenario, nil
+}
+
+// ListScenarios returns the scenarios in ScenarioDir sorted by name; unreadable ones are
+// skipped
+func ListScenarios() ([]Scenario, error) {
+	paths, err := filepath.Glob(filepath.Join(ScenarioDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var scenarios []Scenario
+	for _, path := range paths {
+		if scenario, err := LoadScenario(path); err == nil {
+			scenarios = append(scenarios, scenario)
+		}
+	}
+	sort.Slice(scenarios, func(i, j int) bool {
+		return scenarios[i].Name < scenarios[j].Name
+	})
+	return scenarios, nil
+}
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,12 +24,16 @@ type ViewProvider interface {
 // DebugServer represents the HTTP debug server
 type DebugServer struct {
 	server       *http.Server
+	mux          *http.ServeMux
 	program      *tea.Program
 	model        *types.Model
 	viewProvider ViewProvider
 	mutex        sync.RWMutex
 	logger       *Logger
 	shutdown     chan struct{}
+	endpoints    []string // Paths served; the rest answer 403
+	terminalSize [2]int   // Size reported instead of the tty's when set
+	token        string   // Required of requests when set
 }
 
 // EndpointHandler represents a handler function for debug endpoints
@@ -45,12 +52,64 @@ func RegisterEndpoint(path string, handler EndpointHandler) {
 	endpointRegistry[path] = handler
 }
 
-// NewDebugServer creates a new debug server instance
+// healthEndpoint is always served so clients can check whether the server is up
+const healthEndpoint = "/health"
+
+// EndpointFilter selects which registered endpoints a server serves, so it can be left on for
+// observability without exposing input or state mutation
+type EndpointFilter struct {
+	Enabled  []string // Only these endpoints are served; empty serves all
+	Disabled []string // These endpoints are never served
+}
+
+// Validate reports endpoints in the filter that are not registered
+func (f EndpointFilter) Validate() error {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	var unknown []string
+	for _, path := range append(slices.Clone(f.Enabled), f.Disabled...) {
+		if _, ok := endpointRegistry[path]; !ok {
+			unknown = append(unknown, path)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown debug endpoint(s) %s; available: %s",
+			strings.Join(unknown, ", "), strings.Join(registeredPaths(), ", "))
+	}
+	return nil
+}
+
+// allows reports whether the filter serves path
+func (f EndpointFilter) allows(path string) bool {
+	if path == healthEndpoint {
+		return true
+	}
+	if slices.Contains(f.Disabled, path) {
+		return false
+	}
+	return len(f.Enabled) == 0 || slices.Contains(f.Enabled, path)
+}
+
+// registeredPaths returns the registered endpoint paths in sorted order; the caller holds
+// registryMutex
+func registeredPaths() []string {
+	paths := make([]string, 0, len(endpointRegistry))
+	for path := range endpointRegistry {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// NewDebugServer creates a new debug server instance listening on addr, a host:port, and
+// serving the endpoints filter allows
 func NewDebugServer(
-	port int,
+	addr string,
 	program *tea.Program,
 	model *types.Model,
 	viewProvider ViewProvider,
+	filter EndpointFilter,
 ) *DebugServer {
 	logger := NewLogger()
 
@@ -64,31 +123,47 @@ func NewDebugServer(
 
 	mux := http.NewServeMux()
 
-	// Register all self-registered endpoints
+	// Register all self-registered endpoints; filtered ones are refused rather than unknown
 	registryMutex.RLock()
-	for path, handler := range endpointRegistry {
+	for _, path := range registeredPaths() {
+		if !filter.allows(path) {
+			mux.HandleFunc(path, handleDisabled)
+			continue
+		}
 		// Create a closure to capture the handler and ds
-		capturedHandler := handler
+		capturedHandler := endpointRegistry[path]
 		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
 			capturedHandler(ds, w, r)
 		})
+		ds.endpoints = append(ds.endpoints, path)
 	}
 	registryMutex.RUnlock()
 
+	ds.mux = mux
 	ds.server = &http.Server{
-		Addr:              fmt.Sprintf(":%d", port),
-		Handler:           mux,
+		Addr:              addr,
+		Handler:           ds.authorize(mux),
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
 	return ds
 }
 
+// handleDisabled answers requests to endpoints the server was configured not to serve
+func handleDisabled(w http.ResponseWriter, r *http.Request) {
+	http.Error(w, "Endpoint disabled", http.StatusForbidden)
+}
+
+// Endpoints returns the paths the server serves
+func (ds *DebugServer) Endpoints() []string {
+	return ds.endpoints
+}
+
 // Start starts the debug server in a goroutine
 func (ds *DebugServer) Start() error {
 	go func() {
 		ds.logger.LogEvent("server_start", map[string]interface{}{
-			"port": ds.server.Addr,
+			"addr": ds.server.Addr,
 		})
 
 		if err := ds.server.ListenAndServe(); err != http.ErrServerClosed {
@@ -126,6 +201,25 @@ func (ds *DebugServer) GetModel() *types.Model {
 	return ds.model
 }
 
+// SetTerminalSize sets the terminal size snapshots report, for runs without a terminal or
+// with a size injected by /resize
+func (ds *DebugServer) SetTerminalSize(width, height int) {
+	ds.mutex.Lock()
+	defer ds.mutex.Unlock()
+	ds.terminalSize = [2]int{width, height}
+}
+
+// terminalDimensions returns the size set with SetTerminalSize, or else the tty's
+func (ds *DebugServer) terminalDimensions() (width, height int) {
+	ds.mutex.RLock()
+	size := ds.terminalSize
+	ds.mutex.RUnlock()
+	if size != [2]int{} {
+		return size[0], size[1]
+	}
+	return getTerminalDimensions()
+}
+
 // Logger returns the debug server's logger instance
 func (ds *DebugServer) Logger() *Logger {
 	return ds.logger
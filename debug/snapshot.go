@@ -0,0 +1,228 @@
+package debug
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"claude-permissions/types"
+)
+
+// ComponentPosition represents the calculated position and dimensions of a UI component
+type ComponentPosition struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// LayoutCalculations represents UI layout metrics and component sizing data
+type LayoutCalculations struct {
+	AvailableHeight int                    `json:"available_height"` // Height of the content
+	FixedHeight     int                    `json:"fixed_height"`     // Height of everything else
+	ComponentSizes  map[string]interface{} `json:"component_sizes"`
+}
+
+// SnapshotData represents the combined screen snapshot and layout data
+type SnapshotData struct {
+	// Rendered content
+	Content        string `json:"content"`
+	Width          int    `json:"width"`
+	Height         int    `json:"height"`
+	CursorPosition [2]int `json:"cursor_position"`
+	Raw            bool   `json:"raw"`
+
+	// Layout diagnostics
+	Terminal           [2]int                       `json:"terminal"`
+	Components         map[string]ComponentPosition `json:"components"`
+	LayoutWarnings     []string                     `json:"layout_warnings"`
+	LayoutCalculations LayoutCalculations           `json:"layout_calculations"`
+
+	// Dimension validation
+	DimensionMismatch bool   `json:"dimension_mismatch"`
+	MismatchDetails   string `json:"mismatch_details,omitempty"`
+
+	Timestamp string `json:"timestamp"`
+}
+
+// LayoutProvider places the rendered sections of a model's screen, such as its header,
+// content, and footer, with the model's lock held
+type LayoutProvider func(model *types.Model) map[string]ComponentPosition
+
+// layoutProvider is registered by the UI, which renders the sections
+var layoutProvider LayoutProvider
+
+// RegisterLayoutProvider sets how snapshots find where the screen's sections are
+func RegisterLayoutProvider(provider LayoutProvider) {
+	layoutProvider = provider
+}
+
+// layoutDiagnostics is where the screen's sections are and what is wrong with their placement
+type layoutDiagnostics struct {
+	terminal     [2]int
+	components   map[string]ComponentPosition
+	warnings     []string
+	calculations LayoutCalculations
+}
+
+// captureSnapshot captures current application snapshot data
+func captureSnapshot(ds *DebugServer, raw bool) (*SnapshotData, error) {
+	model := ds.GetModel()
+	if model == nil {
+		return nil, fmt.Errorf("model not available")
+	}
+
+	width, height := ds.terminalDimensions()
+	content := getViewContent(ds, model)
+
+	if raw {
+		content = stripANSICodes(content)
+	}
+
+	cursorPos := estimateCursorPosition(content)
+	layout := extractLayoutDiagnostics(model)
+	renderedWidth, renderedHeight := calculateContentDimensions(content)
+	dimensionMismatch, mismatchDetails := checkDimensionMismatch(
+		width, height, renderedWidth, renderedHeight, model)
+
+	return &SnapshotData{
+		Content:        content,
+		Width:          width,
+		Height:         height,
+		CursorPosition: cursorPos,
+		Raw:            raw,
+
+		Terminal:           layout.terminal,
+		Components:         layout.components,
+		LayoutWarnings:     layout.warnings,
+		LayoutCalculations: layout.calculations,
+
+		DimensionMismatch: dimensionMismatch,
+		MismatchDetails:   mismatchDetails,
+
+		Timestamp: getCurrentTimestamp(),
+	}, nil
+}
+
+// getViewContent gets the rendered view content from ViewProvider or model fallback
+func getViewContent(ds *DebugServer, model *types.Model) string {
+	if ds.viewProvider != nil {
+		return ds.viewProvider.GetView()
+	}
+
+	model.Mutex.RLock()
+	defer model.Mutex.RUnlock()
+	return fmt.Sprintf("Permissions: %d, Duplicates: %d",
+		len(model.Permissions), len(model.Duplicates))
+}
+
+// calculateContentDimensions calculates rendered content width and height
+func calculateContentDimensions(content string) (width, height int) {
+	contentLines := strings.Split(content, "\n")
+	height = len(contentLines)
+	width = 0
+	for _, line := range contentLines {
+		lineWidth := visualWidth(line)
+		if lineWidth > width {
+			width = lineWidth
+		}
+	}
+	return width, height
+}
+
+// checkDimensionMismatch checks for mismatches between terminal and rendered dimensions
+func checkDimensionMismatch(
+	termWidth, termHeight, renderedWidth, renderedHeight int,
+	model *types.Model,
+) (bool, string) {
+	if renderedWidth != termWidth || renderedHeight != termHeight {
+		return true, fmt.Sprintf("Terminal: %dx%d, Rendered: %dx%d, Model: %dx%d",
+			termWidth, termHeight, renderedWidth, renderedHeight, model.Width, model.Height)
+	}
+	return false, ""
+}
+
+// estimateCursorPosition attempts to estimate cursor position based on content
+func estimateCursorPosition(content string) [2]int {
+	lines := strings.Split(content, "\n")
+
+	// Find the last non-empty line for Y position
+	y := len(lines) - 1
+	for y >= 0 && strings.TrimSpace(lines[y]) == "" {
+		y--
+	}
+
+	// Use the length of the last non-empty line for X position
+	x := 0
+	if y >= 0 && y < len(lines) {
+		// Strip ANSI codes to get actual text length
+		cleanLine := stripANSICodes(lines[y])
+		x = len(cleanLine)
+	}
+
+	return [2]int{x, y}
+}
+
+// extractLayoutDiagnostics places the sections the UI rendered and warns about those that do
+// not fit the terminal
+func extractLayoutDiagnostics(model *types.Model) layoutDiagnostics {
+	model.Mutex.RLock()
+	defer model.Mutex.RUnlock()
+
+	layout := layoutDiagnostics{
+		terminal:   [2]int{model.Width, model.Height},
+		components: make(map[string]ComponentPosition),
+		warnings:   []string{},
+		calculations: LayoutCalculations{
+			ComponentSizes: map[string]interface{}{
+				"duplicates_table": map[string]int{
+					"width":  model.DuplicatesTable.Width(),
+					"height": model.DuplicatesTable.Height(),
+				},
+			},
+		},
+	}
+	if layoutProvider == nil || model.Width == 0 || model.Height == 0 {
+		layout.warnings = append(layout.warnings, "Layout not rendered yet")
+		return layout
+	}
+
+	layout.components = layoutProvider(model)
+	names := make([]string, 0, len(layout.components))
+	for name, c := range layout.components {
+		names = append(names, name)
+		switch name {
+		case "content":
+			layout.calculations.AvailableHeight = c.H
+			layout.calculations.ComponentSizes["content_area"] = map[string]int{
+				"width": c.W, "height": c.H,
+			}
+		case "modal": // Overlays the other sections
+		default:
+			layout.calculations.FixedHeight += c.H
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		layout.warnings = append(layout.warnings,
+			placementWarnings(name, layout.components[name], model.Width, model.Height)...)
+	}
+	return layout
+}
+
+// placementWarnings reports how a component placed at c overflows a width by height screen
+func placementWarnings(name string, c ComponentPosition, width, height int) []string {
+	var warnings []string
+	if c.H < 1 || c.W < 1 {
+		warnings = append(warnings, fmt.Sprintf("%s is %dx%d, leaving it no room", name, c.W, c.H))
+	}
+	if overflow := c.Y + c.H - height; overflow > 0 {
+		warnings = append(warnings,
+			fmt.Sprintf("%s ends %d line(s) below the terminal", name, overflow))
+	}
+	if overflow := c.X + c.W - width; overflow > 0 {
+		warnings = append(warnings,
+			fmt.Sprintf("%s ends %d column(s) right of the terminal", name, overflow))
+	}
+	return warnings
+}
@@ -0,0 +1,215 @@
+package debug
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // SHA-1 is what the WebSocket handshake specifies
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// websocketGUID is appended to the client key to accept a WebSocket handshake (RFC 6455)
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// websocketVersion is the only protocol version the server speaks (RFC 6455)
+const websocketVersion = "13"
+
+// WebSocket frame opcodes
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+// closeProtocolError is the close status sent to a client that breaks the protocol
+const closeProtocolError = 1002
+
+// errUnsupportedVersion rejects handshakes for a protocol version other than 13
+var errUnsupportedVersion = errors.New("unsupported WebSocket version; only 13 is supported")
+
+// errProtocol is returned for client frames RFC 6455 forbids
+var errProtocol = errors.New("WebSocket protocol error")
+
+// maxClientFrame bounds the frames read from clients, which only send control frames
+const maxClientFrame = 1 << 16
+
+// wsConn is the server side of a WebSocket connection. Only the server sends data; frames
+// from the client are read to answer pings and notice the close.
+type wsConn struct {
+	conn    net.Conn
+	rw      *bufio.ReadWriter
+	writeMu sync.Mutex // Serializes the frames the writer and the pong replies send
+}
+
+// upgradeWebSocket completes the WebSocket handshake of r and takes over its connection
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") || key == "" {
+		return nil, errors.New("not a WebSocket handshake")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != websocketVersion {
+		w.Header().Set("Sec-WebSocket-Version", websocketVersion)
+		return nil, errUnsupportedVersion
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("connection cannot be upgraded")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade connection: %w", err)
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	sum := sha1.Sum([]byte(key + websocketGUID)) //nolint:gosec // Required by RFC 6455
+	_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\n" +
+		"Connection: Upgrade\r\nSec-WebSocket-Accept: " +
+		base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n")
+	if err := rw.Flush(); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to upgrade connection: %w", err)
+	}
+	return &wsConn{conn: conn, rw: rw}, nil
+}
+
+// headerContains reports whether a comma-separated header lists token, ignoring case
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeFrame sends one unfragmented frame
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xFFFF:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+	if _, err := c.rw.Write(header); err != nil {
+		return err
+	}
+	if _, err := c.rw.Write(payload); err != nil {
+		return err
+	}
+	return c.rw.Flush()
+}
+
+// WriteText sends a text frame
+func (c *wsConn) WriteText(payload []byte) error {
+	return c.writeFrame(opText, payload)
+}
+
+// wsFrame is one frame read from the client
+type wsFrame struct {
+	fin     bool // Last frame of its message
+	opcode  byte
+	payload []byte
+}
+
+// readFrame reads one frame from the client, unmasking its payload. Frames with reserved bits
+// set, unmasked frames, and fragmented or oversized control frames are protocol errors.
+func (c *wsConn) readFrame() (wsFrame, error) {
+	var head [2]byte
+	if _, err := io.ReadFull(c.rw, head[:]); err != nil {
+		return wsFrame{}, err
+	}
+	frame := wsFrame{fin: head[0]&0x80 != 0, opcode: head[0] & 0x0F}
+	length := uint64(head[1] & 0x7F)
+	if head[0]&0x70 != 0 || head[1]&0x80 == 0 {
+		return frame, fmt.Errorf("%w: reserved bits set or frame not masked", errProtocol)
+	}
+	if frame.opcode >= opClose && (!frame.fin || length > 125) {
+		return frame, fmt.Errorf("%w: fragmented or oversized control frame", errProtocol)
+	}
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+			return frame, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.rw, ext[:]); err != nil {
+			return frame, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+	if length > maxClientFrame {
+		return frame, fmt.Errorf("client frame of %d bytes is too large", length)
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.rw, mask[:]); err != nil {
+		return frame, err
+	}
+	frame.payload = make([]byte, length)
+	if _, err := io.ReadFull(c.rw, frame.payload); err != nil {
+		return frame, err
+	}
+	for i := range frame.payload {
+		frame.payload[i] ^= mask[i%4]
+	}
+	return frame, nil
+}
+
+// readUntilClose answers the client's pings until it closes the connection, breaks the
+// protocol, or the connection fails, then closes done. Data messages are discarded, but
+// their fragments must still arrive in order.
+func (c *wsConn) readUntilClose(done chan<- struct{}) {
+	defer close(done)
+	fragmented := false // A data message's final fragment is still to come
+	for {
+		frame, err := c.readFrame()
+		if err == nil && frame.opcode < opClose {
+			// A continuation must follow a started message, and a new message must not
+			if (frame.opcode == opContinuation) != fragmented {
+				err = fmt.Errorf("%w: unexpected continuation or data frame", errProtocol)
+			}
+			fragmented = !frame.fin
+		}
+		if errors.Is(err, errProtocol) {
+			_ = c.writeFrame(opClose, binary.BigEndian.AppendUint16(nil, closeProtocolError))
+		}
+		if err != nil {
+			return
+		}
+		switch frame.opcode {
+		case opClose:
+			_ = c.writeFrame(opClose, nil)
+			return
+		case opPing:
+			_ = c.writeFrame(opPong, frame.payload)
+		}
+	}
+}
+
+// Close closes the connection
+func (c *wsConn) Close() error {
+	return c.conn.Close()
+}
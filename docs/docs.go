@@ -0,0 +1,64 @@
+package docs
+
+import (
+	_ "embed"
+	"strings"
+
+	"claude-permissions/rules"
+)
+
+// toolsDoc is the reference for permission rule semantics, one "## " section per tool family
+//
+//go:embed tools.md
+var toolsDoc string
+
+// Section titles in tools.md
+const (
+	TitleBash      = "Bash"
+	TitleWebFetch  = "WebFetch"
+	TitlePathTools = "Path Tools"
+	TitleMCPTools  = "MCP Tools"
+	TitleOther     = "Other Tools"
+)
+
+// Section documents how rules for one tool family are matched
+type Section struct {
+	Title   string
+	Summary string // The first paragraph, a one-sentence summary
+	Body    string // Everything after the heading, including the summary
+}
+
+// sections indexes tools.md by title
+var sections = parseSections(toolsDoc)
+
+// parseSections splits markdown into its "## " sections
+func parseSections(doc string) map[string]Section {
+	parsed := make(map[string]Section)
+	for _, chunk := range strings.Split(doc, "## ")[1:] {
+		title, body, _ := strings.Cut(chunk, "\n")
+		body = strings.TrimSpace(body)
+		summary, _, _ := strings.Cut(body, "\n\n")
+		parsed[title] = Section{
+			Title:   title,
+			Summary: strings.Join(strings.Fields(summary), " "),
+			Body:    body,
+		}
+	}
+	return parsed
+}
+
+// For returns the section documenting the tool of a permission rule
+func For(rule string) Section {
+	tool := rules.Parse(rule).Tool
+	switch {
+	case tool == rules.ToolBash:
+		return sections[TitleBash]
+	case tool == rules.ToolWebFetch:
+		return sections[TitleWebFetch]
+	case rules.IsPathTool(tool):
+		return sections[TitlePathTools]
+	case strings.HasPrefix(tool, "mcp__"):
+		return sections[TitleMCPTools]
+	}
+	return sections[TitleOther]
+}
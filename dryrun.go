@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"claude-permissions/types"
+)
+
+// dryRunReport describes the changes the editor would make without touching any files
+type dryRunReport struct {
+	SameLevelRemovals    []sameLevelRemoval    `json:"same_level_removals"`
+	DuplicateResolutions []duplicateResolution `json:"duplicate_resolutions"`
+	Conflicts            int                   `json:"conflicts"`
+}
+
+// sameLevelRemoval describes a repeated entry removed from within a single level
+type sameLevelRemoval struct {
+	Level      string `json:"level"`
+	Permission string `json:"permission"`
+}
+
+// duplicateResolution describes how a cross-level duplicate would be auto-resolved
+type duplicateResolution struct {
+	Permission string   `json:"permission"`
+	KeepLevel  string   `json:"keep_level"`
+	RemoveFrom []string `json:"remove_from"`
+}
+
+// runDryRun prints the planned changes in the requested format and returns the exit code
+func runDryRun(format string) (int, error) {
+	report, err := buildDryRunReport()
+	if err != nil {
+		return 1, err
+	}
+
+	if format == formatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return 1, err
+		}
+	} else {
+		printDryRunReport(report)
+	}
+
+	if report.Conflicts > 0 {
+		return exitConflicts, nil
+	}
+	return 0, nil
+}
+
+// buildDryRunReport loads all levels and records what auto-resolution would change
+func buildDryRunReport() (*dryRunReport, error) {
+	projectDir, err := resolveProjectDir(*projectFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	userLevel, repoLevel, localLevel, err := loadRawLevels(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dryRunReport{
+		SameLevelRemovals:    []sameLevelRemoval{},
+		DuplicateResolutions: []duplicateResolution{},
+	}
+
+	for _, level := range []*types.SettingsLevel{&userLevel, &repoLevel, &localLevel} {
+		for _, perm := range autoResolveSameLevelDuplicates(level) {
+			report.SameLevelRemovals = append(report.SameLevelRemovals, sameLevelRemoval{
+				Level:      level.Name,
+				Permission: perm,
+			})
+		}
+	}
+
+	priority, err := loadDuplicatePriority()
+	if err != nil {
+		return nil, err
+	}
+	for _, dup := range detectDuplicates(userLevel, repoLevel, localLevel, priority) {
+		removeFrom := []string{}
+		for _, level := range dup.Levels {
+			if level != dup.KeepLevel {
+				removeFrom = append(removeFrom, level)
+			}
+		}
+		report.DuplicateResolutions = append(report.DuplicateResolutions, duplicateResolution{
+			Permission: dup.Name,
+			KeepLevel:  dup.KeepLevel,
+			RemoveFrom: removeFrom,
+		})
+	}
+	report.Conflicts = len(report.DuplicateResolutions)
+
+	return report, nil
+}
+
+// printDryRunReport prints a human-readable version of the report
+func printDryRunReport(report *dryRunReport) {
+	fmt.Println("Dry run: no files will be modified")
+
+	if len(report.SameLevelRemovals) > 0 {
+		fmt.Println("\nSame-level duplicates to remove:")
+		for _, removal := range report.SameLevelRemovals {
+			fmt.Printf("  • %s: %s\n", removal.Level, removal.Permission)
+		}
+	}
+
+	if len(report.DuplicateResolutions) > 0 {
+		fmt.Println("\nCross-level duplicates to resolve:")
+		for _, res := range report.DuplicateResolutions {
+			fmt.Printf("  • %s: keep in %s, remove from %s\n",
+				res.Permission, res.KeepLevel, strings.Join(res.RemoveFrom, ", "))
+		}
+	}
+
+	fmt.Printf("\nSummary: %d same-level removals, %d conflicts\n",
+		len(report.SameLevelRemovals), report.Conflicts)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"claude-permissions/settingsfile"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Exit codes of the non-interactive modes, so scripts can branch on the outcome
+const (
+	exitOK         = 0
+	exitFailure    = 1 // Any failure not listed below, such as a bad flag or missing file
+	exitConflicts  = 2 // Cross-level duplicates remain, or lint found a rule of error severity
+	exitParseError = 3 // A settings, desired-state, permission set, or policy file is invalid
+	exitWriteError = 4 // A settings file could not be written
+)
+
+// exitKinds names each failing exit code in the JSON error envelope
+var exitKinds = map[int]string{
+	exitFailure:    "error",
+	exitConflicts:  "conflicts",
+	exitParseError: "parse_error",
+	exitWriteError: "write_error",
+}
+
+// errorEnvelope is printed instead of "Error: ..." when a mode fails with --format json
+type errorEnvelope struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Kind    string `json:"kind"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// exitCodeFor returns the exit code for err: parse and write failures have their own
+// codes, and everything else is exitFailure
+func exitCodeFor(err error) int {
+	var (
+		parseErr     *settingsfile.ParseError
+		writeErr     *settingsfile.WriteError
+		commitErr    *settingsfile.CommitError
+		syntaxErr    *json.SyntaxError
+		typeErr      *json.UnmarshalTypeError
+		tomlParseErr toml.ParseError
+	)
+	switch {
+	case errors.As(err, &writeErr), errors.As(err, &commitErr):
+		return exitWriteError
+	case errors.As(err, &parseErr), errors.As(err, &syntaxErr), errors.As(err, &typeErr),
+		errors.As(err, &tomlParseErr):
+		return exitParseError
+	}
+	return exitFailure
+}
+
+// exitWithError reports err and exits. A generic exitFailure code is refined by the type of
+// err; with --format json the error is printed as an envelope on standard output.
+func exitWithError(code int, err error) {
+	if code == exitFailure {
+		code = exitCodeFor(err)
+	}
+	if *outputFormat == formatJSON {
+		var envelope errorEnvelope
+		envelope.Error.Code = code
+		envelope.Error.Kind = exitKinds[code]
+		envelope.Error.Message = err.Error()
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(envelope); encodeErr == nil {
+			os.Exit(code)
+		}
+	}
+	fmt.Printf("Error: %v\n", err)
+	os.Exit(code)
+}
@@ -0,0 +1,74 @@
+// Package gitrepo commits settings files with the git command line tool
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnavailable is returned when git is not installed
+var ErrUnavailable = errors.New("git not found")
+
+// ErrNotCommitted is returned by Show when HEAD has no version of the file
+var ErrNotCommitted = errors.New("not committed")
+
+// Contains reports whether path lies inside the repository at root
+func Contains(root, path string) bool {
+	if root == "" || path == "" {
+		return false
+	}
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Commit stages paths in the repository at root and commits them, and only them, with
+// message. Changes already staged for other files stay staged.
+func Commit(root, message string, paths ...string) error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return ErrUnavailable
+	}
+	if err := run(root, append([]string{"add", "--"}, paths...)...); err != nil {
+		return err
+	}
+	return run(root, append([]string{"commit", "--message", message, "--"}, paths...)...)
+}
+
+// Show returns the contents of path as committed at HEAD of the repository at root
+func Show(root, path string) ([]byte, error) {
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, ErrUnavailable
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not in %s: %w", path, root, err)
+	}
+	object := "HEAD:" + filepath.ToSlash(rel)
+	if err := run(root, "cat-file", "-e", object); err != nil {
+		return nil, ErrNotCommitted
+	}
+	return output(root, "show", object)
+}
+
+// run runs git in root, folding its output into the error when it fails
+func run(root string, args ...string) error {
+	_, err := output(root, args...)
+	return err
+}
+
+// output runs git in root and returns its standard output, folding its standard error into
+// the error when it fails
+func output(root string, args ...string) ([]byte, error) {
+	// #nosec G204 - fixed git subcommands
+	cmd := exec.Command("git", append([]string{"-C", root}, args...)...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s failed: %w: %s", args[0], err,
+			strings.TrimSpace(stderr.String()+string(out)))
+	}
+	return out, nil
+}
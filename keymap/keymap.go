@@ -0,0 +1,188 @@
+package keymap
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Actions that can be bound to keys in the [keys] table of config.toml
+const (
+	Up            = "up"
+	Down          = "down"
+	Left          = "left"
+	Right         = "right"
+	SwitchScreen  = "switch_screen"
+	PrevScreen    = "prev_screen"
+	Save          = "save"
+	Reset         = "reset"
+	Quit          = "quit"
+	MoveLocal     = "move_local"
+	MoveRepo      = "move_repo"
+	MoveUser      = "move_user"
+	FocusLocal    = "focus_local"
+	FocusRepo     = "focus_repo"
+	FocusUser     = "focus_user"
+	DismissHint   = "dismiss_hint"
+	ToggleDensity = "toggle_density"
+	SwitchProject = "switch_project"
+	Help          = "help"
+	AllowWins     = "allow_wins"
+	DenyWins      = "deny_wins"
+	Suggest       = "suggest"
+	Export        = "export"
+	Import        = "import"
+	Presets       = "presets"
+	Profiles      = "profiles"
+	Docs          = "docs"
+	MoveHookUp    = "move_hook_up"
+	MoveHookDown  = "move_hook_down"
+	Add           = "add"
+	Edit          = "edit"
+	Delete        = "delete"
+	Stale         = "stale"
+	Modes         = "modes"
+	Top           = "top"
+	Bottom        = "bottom"
+	HalfPageDown  = "half_page_down"
+	HalfPageUp    = "half_page_up"
+	Jump          = "jump"
+	Inspect       = "inspect"
+	Copy          = "copy"
+	Paste         = "paste"
+	BulkResolve   = "bulk_resolve"
+	GitDiff       = "git_diff"
+)
+
+// Defaults are the built-in bindings; the first key of each action is its display key
+var Defaults = map[string][]string{
+	Up:            {"up", "k"},
+	Down:          {"down", "j"},
+	Left:          {"left", "h"},
+	Right:         {"right", "l"},
+	SwitchScreen:  {"tab"},
+	PrevScreen:    {"shift+tab"},
+	Save:          {"enter"},
+	Reset:         {"esc"},
+	Quit:          {"q"},
+	MoveLocal:     {"1"},
+	MoveRepo:      {"2"},
+	MoveUser:      {"3"},
+	FocusLocal:    {"f1"},
+	FocusRepo:     {"f2"},
+	FocusUser:     {"f3"},
+	DismissHint:   {"x"},
+	ToggleDensity: {"z"},
+	SwitchProject: {"p"},
+	Help:          {"?"},
+	AllowWins:     {"a"},
+	DenyWins:      {"d"},
+	Suggest:       {"s"},
+	Export:        {"e"},
+	Import:        {"i"},
+	Presets:       {"t"},
+	Profiles:      {"w"},
+	Docs:          {"D"},
+	MoveHookUp:    {"["},
+	MoveHookDown:  {"]"},
+	Add:           {"n"},
+	Edit:          {"c"},
+	Delete:        {"delete", "backspace"},
+	Stale:         {"r"},
+	Modes:         {"m"},
+	Top:           {"g"},
+	Bottom:        {"G"},
+	HalfPageDown:  {"ctrl+d"},
+	HalfPageUp:    {"ctrl+u"},
+	Jump:          {"f"},
+	Inspect:       {"v"},
+	Copy:          {"y"},
+	Paste:         {"P"},
+	BulkResolve:   {"!"},
+	GitDiff:       {"H"},
+}
+
+// KeyMap resolves pressed keys to actions. The zero value uses the default bindings.
+type KeyMap struct {
+	bindings map[string][]string // action -> keys
+	actions  map[string]string   // key -> action
+}
+
+// Default returns the built-in key map
+func Default() KeyMap {
+	keys, _ := build(Defaults)
+	return keys
+}
+
+// New merges overrides (action -> keys) into the defaults. An overridden action loses its
+// default keys. On a validation error the default key map is returned with the error.
+func New(overrides map[string][]string) (KeyMap, error) {
+	bindings := make(map[string][]string, len(Defaults))
+	for action, keys := range Defaults {
+		bindings[action] = keys
+	}
+
+	var errs []error
+	for _, action := range sortedActions(overrides) {
+		keys := overrides[action]
+		if _, ok := Defaults[action]; !ok {
+			errs = append(errs, fmt.Errorf("unknown action %q in [keys]", action))
+			continue
+		}
+		if len(keys) == 0 {
+			errs = append(errs, fmt.Errorf("action %q in [keys] has no keys", action))
+			continue
+		}
+		bindings[action] = keys
+	}
+
+	keys, conflicts := build(bindings)
+	errs = append(errs, conflicts...)
+	if len(errs) > 0 {
+		return Default(), errors.Join(errs...)
+	}
+	return keys, nil
+}
+
+// build indexes bindings by key, reporting keys bound to more than one action
+func build(bindings map[string][]string) (KeyMap, []error) {
+	var errs []error
+	actions := make(map[string]string)
+	for _, action := range sortedActions(bindings) {
+		for _, key := range bindings[action] {
+			if other, ok := actions[key]; ok && other != action {
+				errs = append(errs, fmt.Errorf("key %q is bound to both %q and %q",
+					key, other, action))
+				continue
+			}
+			actions[key] = action
+		}
+	}
+	return KeyMap{bindings: bindings, actions: actions}, errs
+}
+
+// Action returns the action bound to key, or "" when the key is unbound
+func (k KeyMap) Action(key string) string {
+	if k.actions == nil {
+		return Default().Action(key)
+	}
+	return k.actions[key]
+}
+
+// Keys returns the keys bound to action
+func (k KeyMap) Keys(action string) []string {
+	if k.bindings == nil {
+		return Defaults[action]
+	}
+	return k.bindings[action]
+}
+
+// sortedActions returns the map's actions in a stable order for deterministic errors
+func sortedActions(bindings map[string][]string) []string {
+	actions := make([]string, 0, len(bindings))
+	for action := range bindings {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	return actions
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"claude-permissions/policy"
+	"claude-permissions/types"
+)
+
+// lintPolicyFile is registered by registerLintFlags when the lint command runs
+var lintPolicyFile *string
+
+// registerLintFlags adds the lint command's flags to fs
+func registerLintFlags(fs *flag.FlagSet) {
+	lintPolicyFile = fs.String("policy", "",
+		"lint: check against FILE instead of the repository's .claude/"+policy.FileName)
+}
+
+// lintReport lists the violations of a policy file
+type lintReport struct {
+	Policy     string                  `json:"policy"`
+	Violations []types.PolicyViolation `json:"violations"`
+}
+
+// runLintCommand checks every level against the repository's policy file, failing with
+// exitConflicts when a rule of error severity is broken
+func runLintCommand(_ []string) (int, error) {
+	projectDir, err := resolveProjectDir(*projectFlag)
+	if err != nil {
+		return 1, err
+	}
+	userLevel, repoLevel, localLevel, _, err := loadAllLevels(projectDir)
+	if err != nil {
+		return 1, err
+	}
+
+	path := *lintPolicyFile
+	if path == "" {
+		path = policy.Path(findProjectRoot(projectDir))
+	}
+	p, err := policy.Load(path)
+	if err != nil {
+		return 1, err
+	}
+	if p == nil {
+		return 1, fmt.Errorf("no policy file at %s", path)
+	}
+
+	report := lintReport{
+		Policy:     path,
+		Violations: policy.Check(p, localLevel, repoLevel, userLevel),
+	}
+	if report.Violations == nil {
+		report.Violations = []types.PolicyViolation{}
+	}
+
+	if *outputFormat == formatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return 1, err
+		}
+	} else {
+		printLintReport(report)
+	}
+
+	if policy.HasErrors(report.Violations) {
+		return exitConflicts, nil
+	}
+	return 0, nil
+}
+
+// printLintReport prints each violation under its policy rule
+func printLintReport(report lintReport) {
+	fmt.Printf("Policy: %s\n", report.Policy)
+
+	errors, warnings := 0, 0
+	lastRule := ""
+	for _, v := range report.Violations {
+		if v.Rule != lastRule {
+			fmt.Printf("\n%s (%s)", v.Rule, v.Severity)
+			if v.Description != "" {
+				fmt.Printf(": %s", v.Description)
+			}
+			fmt.Println()
+			lastRule = v.Rule
+		}
+		fmt.Printf("  • %s: %s\n", v.Level, v.Permission)
+		if v.Severity == policy.SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+
+	fmt.Printf("\nSummary: %d error(s), %d warning(s)\n", errors, warnings)
+}
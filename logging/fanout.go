@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// FanoutHandler passes each record to every handler that is enabled for its level, so logs
+// can go to the debug server and a file at once
+type FanoutHandler struct {
+	handlers []slog.Handler
+}
+
+// NewFanoutHandler combines handlers
+func NewFanoutHandler(handlers ...slog.Handler) *FanoutHandler {
+	return &FanoutHandler{handlers: handlers}
+}
+
+// Enabled implements slog.Handler, reporting whether any handler wants the level
+func (h *FanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle implements slog.Handler
+func (h *FanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, r.Level) {
+			errs = append(errs, handler.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs implements slog.Handler
+func (h *FanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return &FanoutHandler{handlers: handlers}
+}
+
+// WithGroup implements slog.Handler
+func (h *FanoutHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return &FanoutHandler{handlers: handlers}
+}
@@ -0,0 +1,136 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// EnvXDGStateHome is the environment variable naming the base directory for state such as logs
+const EnvXDGStateHome = "XDG_STATE_HOME"
+
+// Rotation limits of the log file
+const (
+	MaxFileSize    = 5 << 20 // Bytes written before the file is rotated
+	MaxFileBackups = 3       // Rotated files kept beside the current one, as editor.log.1 and on
+)
+
+// StateDir returns the editor's directory under $XDG_STATE_HOME, which defaults to
+// ~/.local/state; logs and crash reports are written there
+func StateDir() (string, error) {
+	dir := os.Getenv(EnvXDGStateHome)
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to determine state directory: %w", err)
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "claude-permissions"), nil
+}
+
+// DefaultFilePath returns the log file location in the state directory
+func DefaultFilePath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "editor.log"), nil
+}
+
+// RotatingFile is a log file that is renamed aside once it grows past a size, keeping a
+// fixed number of older files
+type RotatingFile struct {
+	mutex   sync.Mutex
+	path    string
+	maxSize int64
+	backups int
+	file    *os.File
+	size    int64
+}
+
+// OpenRotatingFile opens path for appending, creating it and its directory if needed
+func OpenRotatingFile(path string, maxSize int64, backups int) (*RotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	rf := &RotatingFile{path: path, maxSize: maxSize, backups: backups}
+	if err := rf.open(); err != nil {
+		return nil, err
+	}
+	return rf, nil
+}
+
+// Path returns the location of the current log file
+func (rf *RotatingFile) Path() string {
+	return rf.path
+}
+
+// Write appends p to the file, rotating it first if p would take it past the size limit
+func (rf *RotatingFile) Write(p []byte) (int, error) {
+	rf.mutex.Lock()
+	defer rf.mutex.Unlock()
+
+	if rf.file == nil {
+		return 0, os.ErrClosed
+	}
+	if rf.size > 0 && rf.size+int64(len(p)) > rf.maxSize {
+		if err := rf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := rf.file.Write(p)
+	rf.size += int64(n)
+	return n, err
+}
+
+// Close closes the current log file
+func (rf *RotatingFile) Close() error {
+	rf.mutex.Lock()
+	defer rf.mutex.Unlock()
+
+	if rf.file == nil {
+		return nil
+	}
+	err := rf.file.Close()
+	rf.file = nil
+	return err
+}
+
+// open opens the current log file, continuing from its size
+func (rf *RotatingFile) open() error {
+	file, err := os.OpenFile(rf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	rf.file = file
+	rf.size = info.Size()
+	return nil
+}
+
+// rotate shifts path.N to path.N+1, dropping the oldest, moves the current file to path.1,
+// and starts a new one
+func (rf *RotatingFile) rotate() error {
+	if err := rf.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	rf.file = nil
+
+	for n := rf.backups - 1; n >= 1; n-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", rf.path, n), fmt.Sprintf("%s.%d", rf.path, n+1))
+	}
+	if rf.backups > 0 {
+		if err := os.Rename(rf.path, rf.path+".1"); err != nil {
+			return fmt.Errorf("failed to rotate log file: %w", err)
+		}
+	} else if err := os.Remove(rf.path); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return rf.open()
+}
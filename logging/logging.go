@@ -0,0 +1,126 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// Standard attribute keys; log calls use these instead of ad-hoc names so debug server
+// queries can filter on them
+const (
+	KeyScreen     = "screen"
+	KeyComponent  = "component"
+	KeyLevelName  = "level_name"
+	KeyPermission = "permission"
+	KeyChangeID   = "change_id"
+	KeyPath       = "path"
+	KeyProject    = "project"
+	KeyError      = "error"
+)
+
+// Components that emit logs
+const (
+	ComponentSave      = "save"
+	ComponentSession   = "session"
+	ComponentProject   = "project"
+	ComponentConfig    = "config"
+	ComponentHints     = "hints"
+	ComponentHistory   = "history"
+	ComponentSidecar   = "sidecar"
+	ComponentSettings  = "settings"
+	ComponentClipboard = "clipboard"
+	ComponentCrash     = "crash"
+	ComponentDebug     = "debug_server"
+)
+
+// Screen returns the attribute for the screen that was active
+func Screen(name string) slog.Attr { return slog.String(KeyScreen, name) }
+
+// Component returns the attribute for the subsystem emitting the log
+func Component(name string) slog.Attr { return slog.String(KeyComponent, name) }
+
+// LevelName returns the attribute for a settings level (Local, Repo, User, Managed)
+func LevelName(name string) slog.Attr { return slog.String(KeyLevelName, name) }
+
+// Permission returns the attribute for a permission rule
+func Permission(rule string) slog.Attr { return slog.String(KeyPermission, rule) }
+
+// ChangeID returns the attribute correlating all logs of one save
+func ChangeID(id string) slog.Attr { return slog.String(KeyChangeID, id) }
+
+// Path returns the attribute for a file path
+func Path(path string) slog.Attr { return slog.String(KeyPath, path) }
+
+// Project returns the attribute for a project root
+func Project(root string) slog.Attr { return slog.String(KeyProject, root) }
+
+// Err returns the attribute for an error
+func Err(err error) slog.Attr { return slog.Any(KeyError, err) }
+
+// changeCounter disambiguates change IDs created within the same millisecond
+var changeCounter atomic.Uint64
+
+// NewChangeID returns an identifier for one save, unique within the process
+func NewChangeID() string {
+	return fmt.Sprintf("%s-%d", time.Now().UTC().Format("20060102T150405.000"),
+		changeCounter.Add(1))
+}
+
+// contextKey is the context key for attributes added by WithAttrs
+type contextKey struct{}
+
+// WithAttrs returns a context carrying attrs in addition to those already in ctx
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	combined := make([]slog.Attr, 0, len(existing)+len(attrs))
+	combined = append(append(combined, existing...), attrs...)
+	return context.WithValue(ctx, contextKey{}, combined)
+}
+
+// FromContext returns the default logger with the attributes carried by ctx
+func FromContext(ctx context.Context) *slog.Logger {
+	attrs, _ := ctx.Value(contextKey{}).([]slog.Attr)
+	logger := slog.Default()
+	for _, attr := range attrs {
+		logger = logger.With(attr)
+	}
+	return logger
+}
+
+// For returns the default logger tagged with component
+func For(component string) *slog.Logger {
+	return slog.Default().With(Component(component))
+}
+
+// ContextHandler adds the attributes carried by the record's context, so calls such as
+// slog.InfoContext(ctx, ...) include them
+type ContextHandler struct {
+	slog.Handler
+}
+
+// NewContextHandler wraps next with context attribute propagation
+func NewContextHandler(next slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: next}
+}
+
+// Handle implements slog.Handler
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if attrs, ok := ctx.Value(contextKey{}).([]slog.Attr); ok {
+		r = r.Clone()
+		r.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -1,19 +1,32 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
+	"net"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"claude-permissions/audit"
+	"claude-permissions/config"
 	"claude-permissions/debug"
+	"claude-permissions/keymap"
+	"claude-permissions/logging"
+	"claude-permissions/notify"
+	"claude-permissions/policy"
+	"claude-permissions/state"
 	"claude-permissions/types"
 	"claude-permissions/ui"
+	"claude-permissions/version"
 
 	"github.com/charmbracelet/bubbles/v2/table"
-	"github.com/charmbracelet/bubbles/v2/timer"
 	tea "github.com/charmbracelet/bubbletea/v2"
 )
 
@@ -22,87 +35,284 @@ var (
 	userFile    = flag.String("user-file", "", "Override user level settings file path")
 	repoFile    = flag.String("repo-file", "", "Override repo level settings file path")
 	localFile   = flag.String("local-file", "", "Override local level settings file path")
+	managedFile = flag.String(
+		"managed-file", "", "Override managed (enterprise policy) settings file path",
+	)
 	debugServer = flag.Bool("debug-server", false, "Start HTTP debug server alongside TUI")
 	debugPort   = flag.Int("debug-port", 8080, "Port for debug server")
+	debugOnly   = flag.String("debug-endpoints", "",
+		"Comma-separated debug server endpoints to serve, e.g. /snapshot,/logs (default: all)")
+	debugOff = flag.String("debug-disable-endpoints", "",
+		"Comma-separated debug server endpoints to refuse, e.g. /input,/reset")
+	debugPprof = flag.Bool("debug-pprof", false,
+		"Serve CPU, heap, and other pprof profiles under /debug/pprof/ on the debug server")
+	debugBind = flag.String("debug-bind", "127.0.0.1",
+		"Address the debug server listens on; 0.0.0.0 exposes it to other hosts")
+	debugToken = flag.String("debug-token", "",
+		"Token debug server requests must send in the X-Debug-Token header "+
+			"(default: $"+envDebugToken+", which other users cannot read from the process list)")
+	headless = flag.Bool("headless", false,
+		"Run without a terminal, driven through the debug server, which it starts")
+	headlessSize = flag.String("headless-size", "120x40",
+		"Terminal size WIDTHxHEIGHT the editor renders at with --headless")
+	logFile = flag.String("log-file", "",
+		"File the editor logs to, rotated at 5 MB, or \"off\" "+
+			"(default: $XDG_STATE_HOME/claude-permissions/editor.log)")
+	logLevel = flag.String("log-level", "info",
+		"Lowest level written to --log-file: debug, info, warn, or error")
+	scenarioFlag = flag.String("scenario", "",
+		"Start in a debug scenario: a name from "+debug.ScenarioDir+" or a JSON file")
+	recordFile = flag.String("record", "",
+		"Record keys, resizes, and timer ticks to FILE for replaying with --replay")
+	replayFile = flag.String("replay", "",
+		"Replay a session recorded with --record in place of keyboard input")
+
+	simulateFile = flag.String(
+		"simulate", "", "Evaluate tool invocations listed in FILE against the effective rules",
+	)
+	outputFormat = flag.String("format", formatText, "Output format for CLI modes (text, json)")
+	dryRun       = flag.Bool(
+		"dry-run", false, "Print planned changes without modifying files and exit",
+	)
+	projectFlag = flag.String(
+		"project", "", "Project directory whose repo and local settings are edited (default: CWD)",
+	)
+	noAutoClean = flag.Bool("no-auto-clean", false,
+		"Deprecated and has no effect; rules listed twice in one level are always reported as "+
+			"pending removals")
+	quietFlag = flag.Bool("quiet", false, "Do not print a session summary when the editor exits")
+	gitCommit = flag.Bool("git-commit", false,
+		"Commit the repo settings file to git after each save without asking")
+	priorityFlag = flag.String("priority", "",
+		"Levels in duplicate resolution order, most preferred first, e.g. repo,user,local "+
+			"(overrides duplicates.priority)")
+	versionFlag = flag.Bool("version", false, "Print version information and exit")
+	checkUpdate = flag.Bool("check-update", false,
+		"Check GitHub for a newer release in the background and show it in the footer")
 )
 
-// AppModel wraps types.Model and implements tea.Model interface
-type AppModel struct {
-	*types.Model
-}
+// envDebugToken names the environment variable --debug-token falls back to
+const envDebugToken = "CLAUDE_PERMISSIONS_DEBUG_TOKEN"
 
-// Init implements tea.Model interface
-func (a *AppModel) Init() tea.Cmd {
-	return ui.Init(a.Model)
-}
+// Output formats for non-interactive modes
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
 
-// Update implements tea.Model interface
-func (a *AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	newModel, cmd := ui.Update(a.Model, msg)
-	a.Model = newModel
-	return a, cmd
-}
+// setupLogger configures the global slog logger: records go to logs, the debug server's log
+// or the in-memory log crash reports include, and to the --log-file at --log-level. The
+// returned function closes the log file.
+func setupLogger(logs *debug.Logger) func() {
+	var handler slog.Handler = debug.NewDebugSlogHandler(logs)
+	file, fileHandler, err := openLogFile()
+	if err != nil {
+		fmt.Printf("Warning: File logging disabled: %v\n", err)
+	} else if fileHandler != nil {
+		handler = logging.NewFanoutHandler(handler, fileHandler)
+	}
+	slog.SetDefault(slog.New(logging.NewContextHandler(handler)))
 
-// View implements tea.Model interface
-func (a *AppModel) View() string {
-	return ui.View(a.Model)
+	return func() {
+		if file != nil {
+			_ = file.Close()
+		}
+	}
 }
 
-// GetView implements debug.ViewProvider interface
-func (a *AppModel) GetView() string {
-	return a.View()
+// openLogFile opens the --log-file with a JSON handler at --log-level; both are nil when
+// file logging is off
+func openLogFile() (*logging.RotatingFile, slog.Handler, error) {
+	if *logFile == "off" {
+		return nil, nil, nil
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(*logLevel)); err != nil {
+		return nil, nil, fmt.Errorf("invalid --log-level %q: want debug, info, warn, or error",
+			*logLevel)
+	}
+	path := *logFile
+	if path == "" {
+		var err error
+		if path, err = logging.DefaultFilePath(); err != nil {
+			return nil, nil, err
+		}
+	}
+	file, err := logging.OpenRotatingFile(path, logging.MaxFileSize, logging.MaxFileBackups)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, slog.NewJSONHandler(file, &slog.HandlerOptions{Level: level}), nil
 }
 
-// setupLogger configures the global slog logger based on debug server availability
-func setupLogger(debugSrv *debug.DebugServer) {
-	var handler slog.Handler
+func main() {
+	flag.Usage = printUsage
+	flag.Parse()
 
-	if debugSrv != nil {
-		// Debug server enabled - route logs to debug server
-		handler = debug.NewDebugSlogHandler(debugSrv.Logger())
-	} else {
-		// Debug server disabled - use no-op handler for zero overhead
-		handler = NoOpHandler{}
+	if *versionFlag {
+		fmt.Println(version.Get())
+		return
 	}
 
-	logger := slog.New(handler)
-	slog.SetDefault(logger)
-}
+	// CLI mode: subcommands run without starting the TUI
+	if flag.NArg() > 0 {
+		code, err := runSubcommand(flag.Arg(0), flag.Args()[1:])
+		if err != nil {
+			exitWithError(code, err)
+		}
+		os.Exit(code)
+	}
 
-func main() {
-	flag.Parse()
+	// CLI mode: print simulation results without starting the TUI
+	if *simulateFile != "" && *outputFormat == formatJSON {
+		if err := runSimulationCLI(*simulateFile); err != nil {
+			exitWithError(exitFailure, err)
+		}
+		return
+	}
 
-	dataModel, err := initialModel()
+	// CLI mode: report planned changes and exit
+	if *dryRun {
+		code, err := runDryRun(*outputFormat)
+		if err != nil {
+			exitWithError(code, err)
+		}
+		os.Exit(code)
+	}
+
+	dataModel, err := initialModel(*projectFlag)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Wrap the data model with AppModel to implement tea.Model
-	appModel := &AppModel{Model: dataModel}
+	dataModel.GitCommit = *gitCommit
+	dataModel.CheckUpdate = *checkUpdate
 
-	// Normal mode: interactive TUI
-	p := tea.NewProgram(appModel, tea.WithAltScreen())
+	if *scenarioFlag != "" {
+		if err := loadScenario(dataModel, *scenarioFlag); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Show simulation results as a table on startup when requested
+	if *simulateFile != "" {
+		decisions, err := simulateInvocations(*simulateFile, dataModel.ManagedLevel,
+			dataModel.UserLevel, dataModel.RepoLevel, dataModel.LocalLevel)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		dataModel.ActiveModal = ui.NewSimulationModal(decisions)
+	}
+
+	if err := runEditor(dataModel); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// loadScenario replaces the loaded rules and cursors with the named debug scenario
+func loadScenario(m *types.Model, name string) error {
+	scenario, err := debug.LoadScenario(name)
+	if err != nil {
+		return err
+	}
+	scenario.State.Apply(m)
+	m.DuplicatesTable = createDuplicatesTable(m.Duplicates)
+	m.DuplicatesTable.SetCursor(scenario.State.Cursor.DuplicatesCursor)
+	return nil
+}
+
+// debugEndpointFilter combines the debug endpoint flags with the [debug] config; a flag that
+// is set replaces the corresponding config list
+func debugEndpointFilter(cfg *config.Config) debug.EndpointFilter {
+	filter := debug.EndpointFilter{
+		Enabled:  normalizeEndpoints(cfg.Debug.Endpoints),
+		Disabled: normalizeEndpoints(cfg.Debug.DisabledEndpoints),
+	}
+	if *debugOnly != "" {
+		filter.Enabled = normalizeEndpoints(strings.Split(*debugOnly, ","))
+	}
+	if *debugOff != "" {
+		filter.Disabled = normalizeEndpoints(strings.Split(*debugOff, ","))
+	}
+	return filter
+}
+
+// normalizeEndpoints trims endpoint paths and adds missing leading slashes
+func normalizeEndpoints(list []string) []string {
+	var endpoints []string
+	for _, endpoint := range list {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, "/"+strings.TrimPrefix(endpoint, "/"))
+		}
+	}
+	return endpoints
+}
+
+// runEditor runs the interactive TUI on dataModel until the user quits
+func runEditor(dataModel *types.Model) error {
+	// The binary hosts the same component other programs embed
+	editor := ui.NewEditorFromModel(dataModel)
+
+	// Normal mode: interactive TUI, optionally recording or replaying a session
+	root, closeRecording, err := wrapSession(editor)
+	if err != nil {
+		return err
+	}
+	defer closeRecording()
+	options, width, height, err := programOptions()
+	if err != nil {
+		return err
+	}
+	guard := debug.GuardCrashes(root, dataModel)
+	p := tea.NewProgram(guard, options...)
+
+	// Redraw for notifications pushed outside the update loop; Send blocks while an update
+	// is running, so it must not be called synchronously from one
+	dataModel.Notifications.SetWakeup(func() {
+		go p.Send(ui.NotificationsChangedMsg{})
+	})
 
 	// Start debug server if requested
 	var debugSrv *debug.DebugServer
-	if *debugServer {
-		debugSrv = debug.NewDebugServer(*debugPort, p, dataModel, appModel)
+	if debugEnabled() {
+		filter := debugEndpointFilter(dataModel.Config)
+		if err := filter.Validate(); err != nil {
+			return err
+		}
+		debugSrv = newDebugServer(p, dataModel, editor, filter)
+		if *headless {
+			debugSrv.SetTerminalSize(width, height)
+		}
+		if *debugPprof {
+			debugSrv.EnableProfiling()
+		}
 		if err := debugSrv.Start(); err != nil {
 			fmt.Printf("Warning: Failed to start debug server: %v\n", err)
 		} else {
-			fmt.Printf("Debug server started on port %d\n", *debugPort)
+			fmt.Printf("Debug server started on %s\n", debugAddr())
 		}
 	}
 
-	// Setup logging system based on debug server availability
-	setupLogger(debugSrv)
-
-	// Run the TUI program
-	finalModel, err := p.Run()
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+	// Setup logging to the debug server, or else an in-memory log for crash reports, and
+	// the log file
+	logs := debug.NewLogger()
+	if debugSrv != nil {
+		logs = debugSrv.Logger()
+	}
+	guard.SetLogger(logs)
+	closeLog := setupLogger(logs)
+	defer closeLog()
+
+	// Run the TUI program; Bubble Tea restores the terminal after a panic
+	if _, err := p.Run(); err != nil {
+		if errors.Is(err, tea.ErrProgramPanic) {
+			return crashError(guard)
+		}
+		return err
 	}
 
 	// Stop debug server if it was started
@@ -114,47 +324,192 @@ func main() {
 
 	// Update debug server with final model if needed
 	if debugSrv != nil {
-		if finalAppModel, ok := finalModel.(*AppModel); ok {
-			debugSrv.UpdateModel(finalAppModel.Model)
+		debugSrv.UpdateModel(editor.Model())
+	}
+
+	if !*quietFlag {
+		printSessionSummary(editor.Model().Session, time.Now())
+	}
+
+	return nil
+}
+
+// crashError reports the crash report of a panic. A panic in a command does not pass through
+// the guard, so its report is written here, without the stack Bubble Tea printed.
+func crashError(guard *debug.CrashGuard) error {
+	path := guard.ReportPath()
+	if path == "" {
+		path = guard.WriteReport("panic in a command; see the stack trace above", "")
+	}
+	if path == "" {
+		return errors.New("the editor crashed and the crash report could not be written")
+	}
+	logging.For(logging.ComponentCrash).Error("editor_crashed", slog.String("report", path))
+	return fmt.Errorf("the editor crashed; please attach the crash report %s to a bug report",
+		path)
+}
+
+// debugAddr returns the host:port the debug server listens on
+func debugAddr() string {
+	return net.JoinHostPort(*debugBind, strconv.Itoa(*debugPort))
+}
+
+// newDebugServer creates the debug server at --debug-bind and --debug-port, requiring the
+// --debug-token if one is set
+func newDebugServer(
+	p *tea.Program, model *types.Model, editor *ui.Editor, filter debug.EndpointFilter,
+) *debug.DebugServer {
+	debugSrv := debug.NewDebugServer(debugAddr(), p, model, editor, filter)
+	token := *debugToken
+	if token == "" {
+		token = os.Getenv(envDebugToken)
+	}
+	if token != "" {
+		debugSrv.RequireToken(token)
+	} else if ip := net.ParseIP(*debugBind); ip == nil || !ip.IsLoopback() {
+		fmt.Printf("Warning: Debug server on %s accepts input from other hosts without a "+
+			"token; set %s\n", *debugBind, envDebugToken)
+	}
+	return debugSrv
+}
+
+// debugEnabled reports whether the debug server runs, as it always does with --headless
+func debugEnabled() bool {
+	return *debugServer || *headless
+}
+
+// programOptions returns the options of the editor's program and, with --headless, the size
+// it renders at. A headless program reads no input and discards its output, so it runs
+// without a terminal; its screen is served by the debug server.
+func programOptions() (options []tea.ProgramOption, width, height int, err error) {
+	if !*headless {
+		return []tea.ProgramOption{tea.WithAltScreen()}, 0, 0, nil
+	}
+	if _, err := fmt.Sscanf(*headlessSize, "%dx%d", &width, &height); err != nil ||
+		width < 1 || height < 1 {
+		return nil, 0, 0, fmt.Errorf("invalid --headless-size %q: want WIDTHxHEIGHT, e.g. 120x40",
+			*headlessSize)
+	}
+	return []tea.ProgramOption{
+		tea.WithInput(nil),
+		tea.WithOutput(io.Discard),
+		tea.WithWindowSize(width, height),
+	}, width, height, nil
+}
+
+// wrapSession wraps the editor in an observer streaming its changes to the debug server, a
+// session recorder for --record, and a replayer for --replay; the returned function finishes
+// the recording
+func wrapSession(editor *ui.Editor) (tea.Model, func(), error) {
+	var root tea.Model = editor
+	if debugEnabled() {
+		root = debug.Observe(root, editor.Model())
+	}
+	closeRecording := func() {}
+	if *replayFile != "" {
+		replayer, err := debug.NewReplayer(root, *replayFile)
+		if err != nil {
+			return nil, nil, err
 		}
+		root = replayer
 	}
+	if *recordFile != "" {
+		recorder, err := debug.NewRecorder(root, *recordFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		root = recorder
+		closeRecording = func() {
+			if err := recorder.Close(); err != nil {
+				fmt.Printf("Warning: %v\n", err)
+			}
+		}
+	}
+	return root, closeRecording, nil
 }
 
-// loadAllLevels loads settings from all three levels
-func loadAllLevels() (types.SettingsLevel, types.SettingsLevel, types.SettingsLevel, int, error) {
+// loadRawLevels loads settings from all three levels without any cleanup
+func loadRawLevels(
+	projectDir string,
+) (types.SettingsLevel, types.SettingsLevel, types.SettingsLevel, error) {
+	return loadLevels(projectDir, false)
+}
+
+// loadLevels loads settings from all three levels without any cleanup. When tolerant, a
+// level whose file cannot be read or parsed is returned empty with LoadError set, leaving it
+// read-only, instead of failing the load.
+func loadLevels(
+	projectDir string,
+	tolerant bool,
+) (types.SettingsLevel, types.SettingsLevel, types.SettingsLevel, error) {
 	userLevel, err := loadUserLevel()
-	if err != nil {
-		return types.SettingsLevel{}, types.SettingsLevel{}, types.SettingsLevel{}, 0, fmt.Errorf(
+	if userLevel, err = tolerateLoadError(userLevel, err, tolerant); err != nil {
+		return types.SettingsLevel{}, types.SettingsLevel{}, types.SettingsLevel{}, fmt.Errorf(
 			"failed to load user level: %w",
 			err,
 		)
 	}
 
-	repoLevel, err := loadRepoLevel()
-	if err != nil {
-		return types.SettingsLevel{}, types.SettingsLevel{}, types.SettingsLevel{}, 0, fmt.Errorf(
+	repoLevel, err := loadRepoLevel(projectDir)
+	if repoLevel, err = tolerateLoadError(repoLevel, err, tolerant); err != nil {
+		return types.SettingsLevel{}, types.SettingsLevel{}, types.SettingsLevel{}, fmt.Errorf(
 			"failed to load repo level: %w",
 			err,
 		)
 	}
 
-	localLevel, err := loadLocalLevel()
-	if err != nil {
-		return types.SettingsLevel{}, types.SettingsLevel{}, types.SettingsLevel{}, 0, fmt.Errorf(
+	localLevel, err := loadLocalLevel(projectDir)
+	if localLevel, err = tolerateLoadError(localLevel, err, tolerant); err != nil {
+		return types.SettingsLevel{}, types.SettingsLevel{}, types.SettingsLevel{}, fmt.Errorf(
 			"failed to load local level: %w",
 			err,
 		)
 	}
 
-	// Auto-resolve same-level duplicates and track statistics
-	userCleaned := autoResolveSameLevelDuplicates(&userLevel)
-	repoCleaned := autoResolveSameLevelDuplicates(&repoLevel)
-	localCleaned := autoResolveSameLevelDuplicates(&localLevel)
-	totalSameLevelCleaned := userCleaned + repoCleaned + localCleaned
+	return userLevel, repoLevel, localLevel, nil
+}
+
+// tolerateLoadError turns the error of a level whose settings file exists but could not be
+// loaded into an empty level carrying the error, when tolerant
+func tolerateLoadError(
+	level types.SettingsLevel,
+	err error,
+	tolerant bool,
+) (types.SettingsLevel, error) {
+	if err == nil || !tolerant || level.Path == "" {
+		return level, err
+	}
+	return types.SettingsLevel{
+		Name:        level.Name,
+		Path:        level.Path,
+		Permissions: []string{},
+		LoadError:   err.Error(),
+	}, nil
+}
+
+// loadAllLevels loads settings from all three levels
+func loadAllLevels(
+	projectDir string,
+) (types.SettingsLevel, types.SettingsLevel, types.SettingsLevel, int, error) {
+	userLevel, repoLevel, localLevel, err := loadRawLevels(projectDir)
+	if err != nil {
+		return types.SettingsLevel{}, types.SettingsLevel{}, types.SettingsLevel{}, 0, err
+	}
 
+	totalSameLevelCleaned := autoResolveAllSameLevelDuplicates(&userLevel, &repoLevel, &localLevel)
 	return userLevel, repoLevel, localLevel, totalSameLevelCleaned, nil
 }
 
+// autoResolveAllSameLevelDuplicates collapses same-level duplicates in every level and
+// returns how many were removed
+func autoResolveAllSameLevelDuplicates(levels ...*types.SettingsLevel) int {
+	total := 0
+	for _, level := range levels {
+		total += len(autoResolveSameLevelDuplicates(level))
+	}
+	return total
+}
+
 // createUIComponents creates the UI components
 func createUIComponents(duplicates []types.Duplicate) table.Model {
 	// Create table for duplicates panel
@@ -163,53 +518,203 @@ func createUIComponents(duplicates []types.Duplicate) table.Model {
 	return duplicatesTable
 }
 
-func initialModel() (*types.Model, error) {
-	userLevel, repoLevel, localLevel, totalSameLevelCleaned, err := loadAllLevels()
+func initialModel(projectDir string) (*types.Model, error) {
+	// Load read-only managed policy; it applies to every project
+	managedLevel, err := loadManagedLevel()
 	if err != nil {
+		return nil, fmt.Errorf("failed to load managed level: %w", err)
+	}
+
+	// Invalid config values fall back to defaults rather than failing startup;
+	// the errors are shown in a modal once the TUI starts
+	cfg, cfgErr := config.Load()
+	if err := applyPriorityFlag(cfg); err != nil {
 		return nil, err
 	}
+	keys, _ := keymap.New(cfg.Keys)
+
+	// Hints are disabled (rather than failing startup) when the state store is unreadable
+	stateStore, err := state.Load()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load state store: %v\n", err)
+		stateStore = nil
+	}
+
+	// Session restore is unavailable (rather than failing startup) when the file is unreadable
+	sessions, err := state.LoadSessions()
+	if err != nil {
+		fmt.Printf("Warning: Failed to load saved sessions: %v\n", err)
+		sessions = nil
+	}
+
+	// History is unavailable (rather than failing startup) when the audit log has no location
+	auditLog, err := audit.Open()
+	if err != nil {
+		fmt.Printf("Warning: Failed to locate audit log: %v\n", err)
+		auditLog = nil
+	}
+
+	model := &types.Model{
+		ManagedLevel:  managedLevel,
+		ActivePanel:   0,
+		Width:         0, // Will be set by terminal size message
+		Height:        0, // Will be set by terminal size message
+		ConfirmMode:   false,
+		Notifications: notify.NewQueue(),
+		Session:       types.SessionStats{StartedAt: time.Now()},
+		StateStore:    stateStore,
+		Sessions:      sessions,
+		AuditLog:      auditLog,
+		Config:        cfg,
+		Keys:          keys,
+		ProjectLoader: loadProject,
+	}
+
+	if cfgErr != nil {
+		model.ActiveModal = ui.NewSmallModal("Configuration Error",
+			cfgErr.Error()+"\n\nDefaults are used for the invalid settings.", "config_error")
+	}
+
+	if err := loadProject(model, projectDir); err != nil {
+		return nil, err
+	}
+
+	// A configuration error takes precedence, then broken settings files; the session is
+	// then offered on the next run, and expired soft-deleted rules once no other prompt is
+	// pending
+	if model.ActiveModal == nil {
+		ui.OfferLoadErrors(model)
+	}
+	if model.ActiveModal == nil {
+		ui.OfferSessionRestore(model)
+	}
+	if model.ActiveModal == nil {
+		ui.OfferExpiredPurge(model)
+	}
+
+	return model, nil
+}
+
+// loadProject loads all levels for the project containing dir into the model, replacing
+// the previously loaded project along with any pending changes
+func loadProject(m *types.Model, dir string) error {
+	projectDir, err := resolveProjectDir(dir)
+	if err != nil {
+		return err
+	}
+
+	// A broken settings file leaves its level empty and read-only rather than failing
+	userLevel, repoLevel, localLevel, err := loadLevels(projectDir, true)
+	if err != nil {
+		return err
+	}
+
+	// Rules listed twice in one level are collapsed in memory and reported as pending
+	// removals, so the files only change when a save is confirmed
+	var sameLevel map[string][]string
+	for _, level := range []*types.SettingsLevel{&localLevel, &repoLevel, &userLevel} {
+		if removed := autoResolveSameLevelDuplicates(level); len(removed) > 0 {
+			if sameLevel == nil {
+				sameLevel = make(map[string][]string)
+			}
+			sameLevel[level.Name] = removed
+		}
+	}
 
 	// Create consolidated permissions list
 	permissions := consolidatePermissions(userLevel, repoLevel, localLevel)
 
 	// Detect cross-level duplicates
-	duplicates := detectDuplicates(userLevel, repoLevel, localLevel)
+	duplicates := detectDuplicates(userLevel, repoLevel, localLevel, m.Config.DuplicatePriority())
+
+	// Detect rules that are both allowed and denied
+	contradictions := detectContradictions(userLevel, repoLevel, localLevel)
+
+	// Report rules that managed policy already covers or contradicts
+	managedFindings := detectManagedFindings(m.ManagedLevel, localLevel, repoLevel, userLevel)
+
+	// Load the team's lint rules; a broken policy file only warns
+	projectRoot := findProjectRoot(projectDir)
+	lintPolicy, err := policy.Load(policy.Path(projectRoot))
+	if err != nil {
+		logging.For(logging.ComponentProject).Warn("policy_load_failed",
+			logging.Project(projectRoot), logging.Err(err))
+		m.Notifications.Warn("Policy not loaded: %v", err)
+	}
 
 	duplicatesTable := createUIComponents(duplicates)
 
 	// Determine starting screen based on duplicates
 	startingScreen := types.ScreenOrganization
-	if len(duplicates) > 0 {
+	if len(duplicates) > 0 || len(contradictions) > 0 || len(sameLevel) > 0 {
 		startingScreen = types.ScreenDuplicates
 	}
 
-	model := &types.Model{
-		UserLevel:     userLevel,
-		RepoLevel:     repoLevel,
-		LocalLevel:    localLevel,
-		Permissions:   permissions,
-		Duplicates:    duplicates,
-		ActivePanel:   0,
-		CurrentScreen: startingScreen,
-		CleanupStats: struct {
-			DuplicatesResolved int
-			SameLevelCleaned   int
-		}{
-			DuplicatesResolved: 0,
-			SameLevelCleaned:   totalSameLevelCleaned,
-		},
-		FocusedColumn:    0, // Start with LOCAL column
-		SelectedItem:     0,
-		ColumnSelections: [3]int{0, 0, 0},
-		Width:            0, // Will be set by terminal size message
-		Height:           0, // Will be set by terminal size message
-		DuplicatesTable:  duplicatesTable,
-		ConfirmMode:      false,
-		StatusMessage:    "",
-		StatusTimer:      timer.New(3 * time.Second),
+	m.ProjectRoot = projectRoot
+	m.UserLevel = userLevel
+	m.RepoLevel = repoLevel
+	m.LocalLevel = localLevel
+	m.Permissions = permissions
+	m.Duplicates = duplicates
+	m.Contradictions = contradictions
+	m.ContradictionCursor = 0
+	m.ContradictionsFocused = false
+	m.Consolidations = nil
+	m.StaleRemovals = nil
+	m.RuleEdits = nil
+	m.RuleAdditions = nil
+	m.OriginalHooks = map[string][]types.Hook{
+		types.LevelLocal: slices.Clone(localLevel.Hooks),
+		types.LevelRepo:  slices.Clone(repoLevel.Hooks),
+		types.LevelUser:  slices.Clone(userLevel.Hooks),
+	}
+	m.HookCursor = 0
+	m.OriginalEnv = map[string]map[string]string{
+		types.LevelLocal: maps.Clone(localLevel.Env),
+		types.LevelRepo:  maps.Clone(repoLevel.Env),
+		types.LevelUser:  maps.Clone(userLevel.Env),
+	}
+	m.EnvKeepLevels = nil
+	m.EnvCursor = 0
+	m.OriginalDirectories = map[string][]string{
+		types.LevelLocal: slices.Clone(localLevel.Directories),
+		types.LevelRepo:  slices.Clone(repoLevel.Directories),
+		types.LevelUser:  slices.Clone(userLevel.Directories),
+	}
+	m.DirectoryCursor = 0
+	m.OriginalModes = map[string]types.PermissionModes{
+		types.LevelLocal: localLevel.CurrentModes(),
+		types.LevelRepo:  repoLevel.CurrentModes(),
+		types.LevelUser:  userLevel.CurrentModes(),
+	}
+	m.ManagedFindings = managedFindings
+	m.Policy = lintPolicy
+	m.PolicyOffset = 0
+	m.CurrentScreen = startingScreen
+	m.CleanupStats.DuplicatesResolved = 0
+	m.SameLevelDuplicates = sameLevel
+	m.FocusedColumn = 0 // Start with LOCAL column
+	m.SelectedItem = 0
+	m.ColumnSelections = [3]int{0, 0, 0}
+	m.ColumnOffsets = [3]int{0, 0, 0}
+	m.DuplicatesTable = duplicatesTable
+
+	for _, level := range []types.SettingsLevel{localLevel, repoLevel, userLevel} {
+		if level.Normalized {
+			m.Notifications.Warn("%s settings have comments or trailing commas; saving that "+
+				"file removes them", level.Name)
+		}
 	}
 
-	return model, nil
+	if m.StateStore != nil {
+		if err := m.StateStore.AddRecentProject(m.ProjectRoot); err != nil {
+			logging.For(logging.ComponentProject).Warn("recent_project_save_failed",
+				logging.Project(m.ProjectRoot), logging.Err(err))
+			m.Notifications.Warn("Recent projects not saved: %v", err)
+		}
+	}
+
+	return nil
 }
 
 func createDuplicatesTable(duplicates []types.Duplicate) table.Model {
@@ -233,7 +738,6 @@ func createDuplicatesTable(duplicates []types.Duplicate) table.Model {
 		table.WithColumns(columns),
 		table.WithRows(rows),
 		table.WithFocused(true),
-		table.WithHeight(7),
 	)
 
 	// Apply consistent table styling using centralized theme
@@ -0,0 +1,141 @@
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"claude-permissions/types"
+)
+
+// sidecarSuffix replaces the ".json" extension of a settings file to form its sidecar name
+const sidecarSuffix = ".meta.json"
+
+// Sidecar is the tool-managed metadata stored next to a settings file
+type Sidecar struct {
+	Rules    map[string]types.Provenance   `json:"rules"`
+	Disabled map[string]types.DisabledRule `json:"disabled,omitempty"`
+}
+
+// SidecarPath returns the sidecar location for a settings file,
+// e.g. ".claude/settings.local.json" → ".claude/settings.local.meta.json"
+func SidecarPath(settingsPath string) string {
+	return strings.TrimSuffix(settingsPath, ".json") + sidecarSuffix
+}
+
+// Load reads the sidecar of settingsPath. A missing sidecar yields empty maps.
+func Load(settingsPath string) (Sidecar, error) {
+	sidecar := Sidecar{
+		Rules:    make(map[string]types.Provenance),
+		Disabled: make(map[string]types.DisabledRule),
+	}
+	if settingsPath == "" {
+		return sidecar, nil
+	}
+
+	path := SidecarPath(settingsPath)
+	data, err := os.ReadFile(path) // #nosec G304 - sidecar of a user-controlled config file
+	if os.IsNotExist(err) {
+		return sidecar, nil
+	}
+	if err != nil {
+		return sidecar, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var parsed Sidecar
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return sidecar, fmt.Errorf("invalid JSON in %s: %w", path, err)
+	}
+	for rule, entry := range parsed.Rules {
+		sidecar.Rules[rule] = entry
+	}
+	for rule, entry := range parsed.Disabled {
+		sidecar.Disabled[rule] = entry
+	}
+
+	return sidecar, nil
+}
+
+// Record marks rule as added to level by source (e.g. "import:team.json" or "preset:go")
+func Record(level *types.SettingsLevel, rule, source string) {
+	if level.Provenance == nil {
+		level.Provenance = make(map[string]types.Provenance)
+	}
+	level.Provenance[rule] = types.Provenance{
+		Source:  source,
+		AddedAt: time.Now().UTC(),
+	}
+}
+
+// Disable records rule as removed from level for reason, keeping it until now+ttl
+func Disable(level *types.SettingsLevel, rule, reason string, now time.Time, ttl time.Duration) {
+	if level.Disabled == nil {
+		level.Disabled = make(map[string]types.DisabledRule)
+	}
+	level.Disabled[rule] = types.DisabledRule{
+		DisabledAt: now.UTC(),
+		ExpiresAt:  now.Add(ttl).UTC(),
+		Reason:     reason,
+	}
+}
+
+// Expired returns the level's disabled rules whose grace period has passed, sorted
+func Expired(level types.SettingsLevel, now time.Time) []string {
+	var rules []string
+	for rule, entry := range level.Disabled {
+		if entry.Expired(now) {
+			rules = append(rules, rule)
+		}
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+// Save writes the level's provenance and disabled rules to its sidecar. Provenance for rules
+// that no longer exist in the level is dropped, as are disabled entries for rules that were
+// added back. The sidecar is removed when nothing remains.
+func Save(level types.SettingsLevel) error {
+	if level.Path == "" {
+		return nil
+	}
+
+	present := make(map[string]bool, len(level.Permissions))
+	for _, perm := range level.Permissions {
+		present[perm] = true
+	}
+
+	sidecar := Sidecar{
+		Rules:    make(map[string]types.Provenance),
+		Disabled: make(map[string]types.DisabledRule),
+	}
+	for rule, entry := range level.Provenance {
+		if present[rule] {
+			sidecar.Rules[rule] = entry
+		}
+	}
+	for rule, entry := range level.Disabled {
+		if !present[rule] {
+			sidecar.Disabled[rule] = entry
+		}
+	}
+
+	path := SidecarPath(level.Path)
+	if len(sidecar.Rules) == 0 && len(sidecar.Disabled) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(sidecar, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package notify
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Severity ranks a notification, which controls its color and how long it stays visible
+type Severity string
+
+// Notification severities
+const (
+	SeverityInfo  Severity = "info"
+	SeverityWarn  Severity = "warn"
+	SeverityError Severity = "error"
+)
+
+// displayDurations is how long a notification stays visible once it is shown
+var displayDurations = map[Severity]time.Duration{
+	SeverityInfo:  3 * time.Second,
+	SeverityWarn:  5 * time.Second,
+	SeverityError: 8 * time.Second,
+}
+
+// Notification is a single queued status message
+type Notification struct {
+	Message  string
+	Severity Severity
+	shownAt  time.Time
+}
+
+// Queue holds pending notifications and shows them one at a time. It is safe for concurrent
+// use, and a nil *Queue ignores pushes and is always empty.
+type Queue struct {
+	mutex  sync.Mutex
+	items  []Notification
+	wakeup func()
+}
+
+// NewQueue creates an empty notification queue
+func NewQueue() *Queue {
+	return &Queue{}
+}
+
+// SetWakeup registers fn to be called after every push, so a UI can redraw for
+// notifications pushed from other goroutines. fn must not block.
+func (q *Queue) SetWakeup(fn func()) {
+	if q == nil {
+		return
+	}
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.wakeup = fn
+}
+
+// Push queues a notification with the given severity
+func (q *Queue) Push(severity Severity, message string) {
+	if q == nil {
+		return
+	}
+	q.mutex.Lock()
+	q.items = append(q.items, Notification{Message: message, Severity: severity})
+	wakeup := q.wakeup
+	q.mutex.Unlock()
+
+	if wakeup != nil {
+		wakeup()
+	}
+}
+
+// Info queues an informational notification
+func (q *Queue) Info(format string, args ...interface{}) {
+	q.Push(SeverityInfo, fmt.Sprintf(format, args...))
+}
+
+// Warn queues a warning notification
+func (q *Queue) Warn(format string, args ...interface{}) {
+	q.Push(SeverityWarn, fmt.Sprintf(format, args...))
+}
+
+// Error queues an error notification
+func (q *Queue) Error(format string, args ...interface{}) {
+	q.Push(SeverityError, fmt.Sprintf(format, args...))
+}
+
+// Current returns the notification to show at now, dropping those whose display time has
+// passed. The second result is the number of notifications waiting behind it.
+func (q *Queue) Current(now time.Time) (Notification, int, bool) {
+	if q == nil {
+		return Notification{}, 0, false
+	}
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for len(q.items) > 0 {
+		head := &q.items[0]
+		if head.shownAt.IsZero() {
+			head.shownAt = now
+		}
+		if now.Sub(head.shownAt) < displayDurations[head.Severity] {
+			return *head, len(q.items) - 1, true
+		}
+		q.items = q.items[1:]
+	}
+	return Notification{}, 0, false
+}
+
+// Remaining returns how long the current notification stays visible, or 0 when the queue
+// is empty or nothing has been shown yet
+func (q *Queue) Remaining(now time.Time) time.Duration {
+	if q == nil {
+		return 0
+	}
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.items) == 0 || q.items[0].shownAt.IsZero() {
+		return 0
+	}
+	head := q.items[0]
+	return max(displayDurations[head.Severity]-now.Sub(head.shownAt), 0)
+}
+
+// Dismiss drops the current notification
+func (q *Queue) Dismiss() {
+	if q == nil {
+		return
+	}
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.items) > 0 {
+		q.items = q.items[1:]
+	}
+}
+
+// Len returns the number of queued notifications, including the one being shown
+func (q *Queue) Len() int {
+	if q == nil {
+		return 0
+	}
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return len(q.items)
+}
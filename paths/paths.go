@@ -0,0 +1,149 @@
+package paths
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Environment variables consulted when resolving the user settings directory
+const (
+	EnvClaudeConfigDir = "CLAUDE_CONFIG_DIR"
+	EnvXDGConfigHome   = "XDG_CONFIG_HOME"
+	EnvUserProfile     = "USERPROFILE"
+)
+
+// Sources describing how a settings path was chosen
+const (
+	SourceFlag        = "flag"
+	SourceChezmoi     = "chezmoi"
+	SourceClaudeDir   = EnvClaudeConfigDir
+	SourceXDG         = EnvXDGConfigHome
+	SourceUserProfile = EnvUserProfile
+	SourceHome        = "home"
+	SourceProject     = "project"
+	SourcePlatform    = "platform"
+	SourceNone        = "none"
+)
+
+// SettingsFileName is the name of the shared settings file in a .claude directory
+const SettingsFileName = "settings.json"
+
+// LocalSettingsFileName is the name of the uncommitted project settings file
+const LocalSettingsFileName = "settings.local.json"
+
+// Resolved describes a settings file location and how it was chosen
+type Resolved struct {
+	Level  string `json:"level"`
+	Path   string `json:"path"`
+	Source string `json:"source"`
+	Exists bool   `json:"exists"`
+}
+
+// NewResolved creates a Resolved entry, checking whether the file exists
+func NewResolved(level, path, source string) Resolved {
+	exists := false
+	if path != "" {
+		if _, err := os.Stat(path); err == nil {
+			exists = true
+		}
+	}
+	return Resolved{Level: level, Path: path, Source: source, Exists: exists}
+}
+
+// HomeDir returns the user's home directory, preferring %USERPROFILE% on Windows
+func HomeDir() (string, string, error) {
+	if runtime.GOOS == "windows" {
+		if profile := os.Getenv(EnvUserProfile); profile != "" {
+			return profile, SourceUserProfile, nil
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to determine home directory: %w", err)
+	}
+	return home, SourceHome, nil
+}
+
+// UserSettingsDir returns the directory holding user-level Claude settings.
+// $CLAUDE_CONFIG_DIR wins, then an existing $XDG_CONFIG_HOME/claude, then ~/.claude.
+func UserSettingsDir() (string, string, error) {
+	if dir := os.Getenv(EnvClaudeConfigDir); dir != "" {
+		return dir, SourceClaudeDir, nil
+	}
+
+	if xdg := os.Getenv(EnvXDGConfigHome); xdg != "" {
+		dir := filepath.Join(xdg, "claude")
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir, SourceXDG, nil
+		}
+	}
+
+	home, source, err := HomeDir()
+	if err != nil {
+		return "", "", err
+	}
+	return filepath.Join(home, ".claude"), source, nil
+}
+
+// UserSettings resolves the user-level settings.json
+func UserSettings(level string) (Resolved, error) {
+	dir, source, err := UserSettingsDir()
+	if err != nil {
+		return Resolved{}, err
+	}
+	return NewResolved(level, filepath.Join(dir, SettingsFileName), source), nil
+}
+
+// RepoSettings returns the shared project settings path under root
+func RepoSettings(root string) string {
+	return filepath.Join(root, ".claude", SettingsFileName)
+}
+
+// LocalSettings returns the uncommitted project settings path under root
+func LocalSettings(root string) string {
+	return filepath.Join(root, ".claude", LocalSettingsFileName)
+}
+
+// ManagedSettings returns the platform location of managed-settings.json
+func ManagedSettings() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "/Library/Application Support/ClaudeCode/managed-settings.json"
+	case "windows":
+		return `C:\ProgramData\ClaudeCode\managed-settings.json`
+	default:
+		return "/etc/claude-code/managed-settings.json"
+	}
+}
+
+// EnsureFile creates path and its parent directories with an empty settings object
+// when the file does not exist yet
+func EnsureFile(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, []byte("{}\n"), 0o600); err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	return nil
+}
+
+// Abbreviate shortens path for display by replacing the home directory with "~"
+func Abbreviate(path string) string {
+	home, _, err := HomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if rest, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return filepath.Join("~", rest)
+	}
+	return path
+}
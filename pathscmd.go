@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"claude-permissions/paths"
+)
+
+// runPathsCommand prints where each settings level is read from and how it was resolved
+func runPathsCommand(_ []string) (int, error) {
+	projectDir, err := resolveProjectDir(*projectFlag)
+	if err != nil {
+		return 1, err
+	}
+
+	resolved, err := resolveSettingsPaths(projectDir)
+	if err != nil {
+		return 1, err
+	}
+
+	if *outputFormat == formatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(resolved); err != nil {
+			return 1, err
+		}
+		return 0, nil
+	}
+
+	printResolvedPaths(resolved)
+	return 0, nil
+}
+
+// printResolvedPaths prints resolved paths as an aligned table
+func printResolvedPaths(resolved []paths.Resolved) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "LEVEL\tSTATUS\tSOURCE\tPATH")
+	for _, r := range resolved {
+		status := "missing"
+		if r.Exists {
+			status = "exists"
+		}
+		path := r.Path
+		if path == "" {
+			path = "(not in a git repository)"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.Level, status, r.Source, path)
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,173 @@
+package permset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"claude-permissions/metadata"
+	"claude-permissions/types"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the format version written to exported files
+const Version = 1
+
+// Lists a rule can belong to
+const (
+	ListAllow = "allow"
+	ListDeny  = "deny"
+)
+
+// Set is a portable permission set: every rule of the exported levels, annotated with the
+// level and list it came from
+type Set struct {
+	Version int    `json:"version" yaml:"version"`
+	Rules   []Rule `json:"rules" yaml:"rules"`
+}
+
+// Rule is one exported rule
+type Rule struct {
+	Rule  string `json:"rule" yaml:"rule"`
+	Level string `json:"level" yaml:"level"`
+	List  string `json:"list" yaml:"list"` // ListAllow or ListDeny
+}
+
+// FromLevels collects the allow and deny rules of levels into a set
+func FromLevels(levels ...types.SettingsLevel) Set {
+	set := Set{Version: Version, Rules: []Rule{}}
+	for _, level := range levels {
+		for _, rule := range level.Permissions {
+			set.Rules = append(set.Rules, Rule{Rule: rule, Level: level.Name, List: ListAllow})
+		}
+		for _, rule := range level.Deny {
+			set.Rules = append(set.Rules, Rule{Rule: rule, Level: level.Name, List: ListDeny})
+		}
+	}
+	return set
+}
+
+// isYAML reports whether path should be read and written as YAML rather than JSON
+func isYAML(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// Write stores set at path, as YAML for .yaml/.yml files and JSON otherwise
+func Write(path string, set Set) error {
+	var buf bytes.Buffer
+	var err error
+	if isYAML(path) {
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		err = encoder.Encode(set)
+	} else {
+		encoder := json.NewEncoder(&buf)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(set)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode permission set: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Read loads the set stored at path, validating each rule's level and list
+func Read(path string) (Set, error) {
+	data, err := os.ReadFile(path) // #nosec G304 - path is provided by the user
+	if err != nil {
+		return Set{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var set Set
+	if isYAML(path) {
+		err = yaml.Unmarshal(data, &set)
+	} else {
+		err = json.Unmarshal(data, &set)
+	}
+	if err != nil {
+		return Set{}, fmt.Errorf("invalid permission set in %s: %w", path, err)
+	}
+	if set.Version > Version {
+		return Set{}, fmt.Errorf("%s has format version %d; this version reads up to %d",
+			path, set.Version, Version)
+	}
+
+	for i, rule := range set.Rules {
+		level, ok := canonicalLevel(rule.Level)
+		if !ok || (rule.List != ListAllow && rule.List != ListDeny) || rule.Rule == "" {
+			return Set{}, fmt.Errorf("invalid rule %d in %s: %+v", i+1, path, rule)
+		}
+		set.Rules[i].Level = level
+	}
+	return set, nil
+}
+
+// canonicalLevel normalizes the case of an editable level name
+func canonicalLevel(name string) (string, bool) {
+	for _, level := range []string{types.LevelUser, types.LevelRepo, types.LevelLocal} {
+		if strings.EqualFold(name, level) {
+			return level, true
+		}
+	}
+	return "", false
+}
+
+// Merge adds the set's rules for level that it does not already contain, recording source as
+// their provenance, and returns the added rules. Existing rules are never removed.
+func (s Set) Merge(level *types.SettingsLevel, source string) []Rule {
+	var added []Rule
+	for _, rule := range s.Rules {
+		if rule.Level != level.Name {
+			continue
+		}
+		list := &level.Permissions
+		if rule.List == ListDeny {
+			list = &level.Deny
+		}
+		if slices.Contains(*list, rule.Rule) {
+			continue
+		}
+		*list = append(*list, rule.Rule)
+		if rule.List == ListAllow {
+			metadata.Record(level, rule.Rule, source)
+		}
+		added = append(added, rule)
+	}
+	return added
+}
+
+// Source returns the provenance recorded for rules imported from path
+func Source(path string) string {
+	return "import:" + filepath.Base(path)
+}
+
+// ParseLevels parses a comma-separated list of editable level names such as "repo,local"
+func ParseLevels(list string) ([]string, error) {
+	var levels []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		level, ok := canonicalLevel(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown level %q (expected user, repo, or local)", name)
+		}
+		if !slices.Contains(levels, level) {
+			levels = append(levels, level)
+		}
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("no levels selected")
+	}
+	return levels, nil
+}
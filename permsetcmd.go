@@ -0,0 +1,123 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+
+	"claude-permissions/metadata"
+	"claude-permissions/permset"
+	"claude-permissions/settingsfile"
+	"claude-permissions/types"
+)
+
+// permsetLevels is registered by registerPermsetFlags when export or import runs
+var permsetLevels *string
+
+// registerPermsetFlags adds the export and import commands' flags to fs
+func registerPermsetFlags(fs *flag.FlagSet) {
+	permsetLevels = fs.String("levels", "user,repo,local",
+		"export/import: comma-separated levels to include")
+}
+
+// runExportCommand writes the rules of the selected levels to a portable permission set
+func runExportCommand(args []string) (int, error) {
+	if len(args) != 1 {
+		return 1, fmt.Errorf("usage: export [--levels user,repo,local] FILE.json|FILE.yaml")
+	}
+
+	levels, err := selectedPermsetLevels()
+	if err != nil {
+		return 1, err
+	}
+
+	set := permset.FromLevels(levels...)
+	if err := permset.Write(args[0], set); err != nil {
+		return 1, err
+	}
+	fmt.Printf("Exported %d rules from %d level(s) to %s\n",
+		len(set.Rules), len(levels), args[0])
+	return 0, nil
+}
+
+// runImportCommand adds the rules of a permission set to the selected levels; rules already
+// present are kept and nothing is removed
+func runImportCommand(args []string) (int, error) {
+	if len(args) != 1 {
+		return 1, fmt.Errorf("usage: import [--levels user,repo,local] [--dry-run] FILE")
+	}
+
+	set, err := permset.Read(args[0])
+	if err != nil {
+		return 1, err
+	}
+	levels, err := selectedPermsetLevels()
+	if err != nil {
+		return 1, err
+	}
+
+	if *dryRun {
+		fmt.Println("Dry run: no files will be modified")
+	}
+	source := permset.Source(args[0])
+	total := 0
+	for i := range levels {
+		level := &levels[i]
+		added := set.Merge(level, source)
+		if len(added) == 0 {
+			continue
+		}
+		if level.Path == "" {
+			return 1, fmt.Errorf("cannot import into %s level: project is not a git repository",
+				level.Name)
+		}
+
+		fmt.Printf("%s (%s):\n", level.Name, level.Path)
+		for _, rule := range added {
+			fmt.Printf("  + %s %s\n", rule.List, rule.Rule)
+		}
+		total += len(added)
+
+		if *dryRun {
+			continue
+		}
+		if err := settingsfile.Write(*level); err != nil {
+			return 1, err
+		}
+		if err := metadata.Save(*level); err != nil {
+			return exitWriteError, err
+		}
+	}
+
+	if total == 0 {
+		fmt.Println("Already up to date")
+		return 0, nil
+	}
+	fmt.Printf("\nSummary: %d added\n", total)
+	return 0, nil
+}
+
+// selectedPermsetLevels loads the levels named by --levels, in USER, REPO, LOCAL order
+func selectedPermsetLevels() ([]types.SettingsLevel, error) {
+	names, err := permset.ParseLevels(*permsetLevels)
+	if err != nil {
+		return nil, err
+	}
+
+	projectDir, err := resolveProjectDir(*projectFlag)
+	if err != nil {
+		return nil, err
+	}
+	userLevel, repoLevel, localLevel, err := loadRawLevels(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var levels []types.SettingsLevel
+	for _, level := range []types.SettingsLevel{userLevel, repoLevel, localLevel} {
+		if slices.Contains(names, level.Name) {
+			levels = append(levels, level)
+		}
+	}
+	return levels, nil
+}
@@ -0,0 +1,140 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"claude-permissions/rules"
+	"claude-permissions/types"
+
+	"github.com/BurntSushi/toml"
+)
+
+// FileName is the policy file checked into the repository's .claude directory
+const FileName = "permission-policy.toml"
+
+// Severities of a policy rule; only errors fail the lint command
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// Path returns the policy file location for the repository at root
+func Path(root string) string {
+	return filepath.Join(root, ".claude", FileName)
+}
+
+// Load reads the policy file at path; a missing file yields a nil policy and no error
+func Load(path string) (*types.Policy, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	p := &types.Policy{Path: path}
+	if _, err := toml.DecodeFile(path, p); err != nil {
+		return nil, fmt.Errorf("invalid policy in %s: %w", path, err)
+	}
+	if err := Validate(p); err != nil {
+		return nil, fmt.Errorf("invalid policy in %s:\n%w", path, err)
+	}
+	return p, nil
+}
+
+// Validate checks that every rule is named and checks something, normalizing the case of
+// level names and defaulting the severity to error
+func Validate(p *types.Policy) error {
+	var errs []error
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Name == "" {
+			errs = append(errs, fmt.Errorf("rule %d has no name", i+1))
+			continue
+		}
+
+		switch rule.Severity {
+		case SeverityError, SeverityWarning:
+		case "":
+			rule.Severity = SeverityError
+		default:
+			errs = append(errs, fmt.Errorf("rule %q: severity must be %q or %q, got %q",
+				rule.Name, SeverityError, SeverityWarning, rule.Severity))
+		}
+
+		for j, level := range rule.Levels {
+			canonical := canonicalLevel(level)
+			if canonical == "" {
+				errs = append(errs, fmt.Errorf("rule %q: unknown level %q", rule.Name, level))
+				continue
+			}
+			rule.Levels[j] = canonical
+		}
+
+		if (rule.Tool == "") != (rule.SpecifierPrefix == "") {
+			errs = append(errs, fmt.Errorf("rule %q: tool and specifier_prefix go together",
+				rule.Name))
+		}
+		if len(rule.Forbid) == 0 && rule.Tool == "" {
+			errs = append(errs, fmt.Errorf("rule %q checks nothing; set forbid or tool",
+				rule.Name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// canonicalLevel returns the editable level named level in any case, or ""
+func canonicalLevel(level string) string {
+	for _, name := range []string{types.LevelLocal, types.LevelRepo, types.LevelUser} {
+		if strings.EqualFold(level, name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// Check returns the violations of the allow rules of levels, in policy rule, level, and file
+// order
+func Check(p *types.Policy, levels ...types.SettingsLevel) []types.PolicyViolation {
+	var violations []types.PolicyViolation
+	for _, rule := range p.Rules {
+		for _, level := range levels {
+			if len(rule.Levels) > 0 && !slices.Contains(rule.Levels, level.Name) {
+				continue
+			}
+			for _, perm := range level.Permissions {
+				if violates(rule, perm) {
+					violations = append(violations, types.PolicyViolation{
+						Rule:        rule.Name,
+						Description: rule.Description,
+						Severity:    rule.Severity,
+						Level:       level.Name,
+						Permission:  perm,
+					})
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// violates reports whether the allow rule perm breaks rule
+func violates(rule types.PolicyRule, perm string) bool {
+	parsed := rules.Parse(perm)
+	for _, forbidden := range rule.Forbid {
+		if rules.Covers(parsed, rules.Parse(forbidden)) {
+			return true
+		}
+	}
+	return rule.Tool != "" && parsed.Tool == rule.Tool &&
+		!strings.HasPrefix(parsed.Specifier, rule.SpecifierPrefix)
+}
+
+// HasErrors reports whether any violation has error severity
+func HasErrors(violations []types.PolicyViolation) bool {
+	return slices.ContainsFunc(violations, func(v types.PolicyViolation) bool {
+		return v.Severity == SeverityError
+	})
+}
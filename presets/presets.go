@@ -0,0 +1,84 @@
+package presets
+
+import (
+	"claude-permissions/permset"
+)
+
+// Preset is a curated set of allow rules for one toolchain
+type Preset struct {
+	Name        string // Short identifier, recorded as "preset:<name>" provenance
+	Title       string
+	Description string
+	Rules       []string
+}
+
+// All lists the built-in presets in display order
+var All = []Preset{
+	{
+		Name:        "go",
+		Title:       "Go",
+		Description: "Build, test, vet, and format Go modules",
+		Rules: []string{
+			"Bash(go build:*)",
+			"Bash(go test:*)",
+			"Bash(go vet:*)",
+			"Bash(go fmt:*)",
+			"Bash(go mod tidy)",
+			"Bash(go list:*)",
+			"Bash(gofmt:*)",
+		},
+	},
+	{
+		Name:        "node",
+		Title:       "Node",
+		Description: "Install dependencies, test, lint, and build with npm",
+		Rules: []string{
+			"Bash(npm install)",
+			"Bash(npm ci)",
+			"Bash(npm test:*)",
+			"Bash(npm run build)",
+			"Bash(npm run lint)",
+			"Bash(npx tsc:*)",
+		},
+	},
+	{
+		Name:        "python",
+		Title:       "Python",
+		Description: "Run tests, linters, and type checks",
+		Rules: []string{
+			"Bash(pytest:*)",
+			"Bash(python -m pytest:*)",
+			"Bash(ruff check:*)",
+			"Bash(ruff format:*)",
+			"Bash(mypy:*)",
+		},
+	},
+	{
+		Name:        "rust",
+		Title:       "Rust",
+		Description: "Build, test, lint, and format Cargo projects",
+		Rules: []string{
+			"Bash(cargo build:*)",
+			"Bash(cargo test:*)",
+			"Bash(cargo check:*)",
+			"Bash(cargo clippy:*)",
+			"Bash(cargo fmt:*)",
+		},
+	},
+}
+
+// Source returns the provenance recorded for rules added by the preset
+func (p Preset) Source() string {
+	return "preset:" + p.Name
+}
+
+// Set returns the preset's rules as a permission set targeting level
+func (p Preset) Set(level string) permset.Set {
+	set := permset.Set{Version: permset.Version}
+	for _, rule := range p.Rules {
+		set.Rules = append(set.Rules, permset.Rule{
+			Rule: rule, Level: level, List: permset.ListAllow,
+		})
+	}
+	return set
+}
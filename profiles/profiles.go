@@ -0,0 +1,159 @@
+// Package profiles stores named snapshots of the allow rules of each level, such as "strict"
+// or "demo-mode", in the user config directory so they can be restored later
+package profiles
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"claude-permissions/permset"
+	"claude-permissions/state"
+	"claude-permissions/types"
+)
+
+// dirName is the directory under the editor's config directory holding the profiles
+const dirName = "profiles"
+
+// namePattern restricts profile names to characters that are safe in a file name
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ErrNotFound is returned when no profile of the given name was saved
+var ErrNotFound = errors.New("profile not found")
+
+// Profile describes a saved profile
+type Profile struct {
+	Name    string    `json:"name"`
+	Rules   int       `json:"rules"`
+	SavedAt time.Time `json:"saved_at"`
+	Path    string    `json:"path"`
+}
+
+// Dir returns the directory holding the profiles
+func Dir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, state.AppDirName, dirName), nil
+}
+
+// ValidateName reports whether name can be used as a profile name
+func ValidateName(name string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '.', '_', and '-'",
+			name)
+	}
+	return nil
+}
+
+// Path returns the file the named profile is stored in
+func Path(name string) (string, error) {
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
+
+// Snapshot collects the allow rules of levels into a set; deny rules are not part of a
+// profile
+func Snapshot(levels ...types.SettingsLevel) permset.Set {
+	return allowOnly(permset.FromLevels(levels...))
+}
+
+// allowOnly drops the deny rules of set
+func allowOnly(set permset.Set) permset.Set {
+	set.Rules = slices.DeleteFunc(set.Rules, func(rule permset.Rule) bool {
+		return rule.List != permset.ListAllow
+	})
+	return set
+}
+
+// Save stores set as the named profile, replacing any earlier profile of that name
+func Save(name string, set permset.Set) error {
+	path, err := Path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	return permset.Write(path, set)
+}
+
+// Load reads the named profile
+func Load(name string) (permset.Set, error) {
+	path, err := Path(name)
+	if err != nil {
+		return permset.Set{}, err
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return permset.Set{}, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	set, err := permset.Read(path)
+	if err != nil {
+		return permset.Set{}, err
+	}
+	return allowOnly(set), nil
+}
+
+// Delete removes the named profile
+func Delete(name string) error {
+	path, err := Path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	} else if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", path, err)
+	}
+	return nil
+}
+
+// List returns the saved profiles sorted by name; unreadable profiles are skipped
+func List() ([]Profile, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var list []Profile
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok || entry.IsDir() || ValidateName(name) != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		set, err := Load(name)
+		if err != nil {
+			continue
+		}
+		list = append(list, Profile{
+			Name:    name,
+			Rules:   len(set.Rules),
+			SavedAt: info.ModTime(),
+			Path:    filepath.Join(dir, entry.Name()),
+		})
+	}
+	return list, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"claude-permissions/profiles"
+)
+
+// runProfilesCommand lists, saves, or deletes named permission profiles. Restoring a profile
+// is done in the editor, where its changes are reviewed before they are saved.
+func runProfilesCommand(args []string) (int, error) {
+	if len(args) == 0 {
+		args = []string{"list"}
+	}
+	switch {
+	case args[0] == "list" && len(args) == 1:
+		return listProfiles()
+	case args[0] == "save" && len(args) == 2:
+		return saveProfile(args[1])
+	case args[0] == "delete" && len(args) == 2:
+		if err := profiles.Delete(args[1]); err != nil {
+			return 1, err
+		}
+		fmt.Printf("Deleted profile %s\n", args[1])
+		return 0, nil
+	}
+	return 1, fmt.Errorf("usage: profiles [list | save NAME | delete NAME]")
+}
+
+// listProfiles prints the saved profiles as a table, or as JSON with --format json
+func listProfiles() (int, error) {
+	saved, err := profiles.List()
+	if err != nil {
+		return 1, err
+	}
+
+	if *outputFormat == formatJSON {
+		if saved == nil {
+			saved = []profiles.Profile{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(saved); err != nil {
+			return 1, err
+		}
+		return 0, nil
+	}
+
+	if len(saved) == 0 {
+		fmt.Println("No saved profiles")
+		return 0, nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tRULES\tSAVED")
+	for _, profile := range saved {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\n", profile.Name, profile.Rules,
+			profile.SavedAt.Format("2006-01-02 15:04"))
+	}
+	_ = w.Flush()
+	return 0, nil
+}
+
+// saveProfile snapshots the allow rules of each level, as saved on disk, under name
+func saveProfile(name string) (int, error) {
+	projectDir, err := resolveProjectDir(*projectFlag)
+	if err != nil {
+		return 1, err
+	}
+	userLevel, repoLevel, localLevel, err := loadRawLevels(projectDir)
+	if err != nil {
+		return 1, err
+	}
+
+	set := profiles.Snapshot(userLevel, repoLevel, localLevel)
+	if err := profiles.Save(name, set); err != nil {
+		return 1, err
+	}
+	fmt.Printf("Saved profile %s (%d rules)\n", name, len(set.Rules))
+	return 0, nil
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html/template"
+	"os"
+	"strings"
+	"time"
+
+	"claude-permissions/docs"
+	"claude-permissions/rules"
+	"claude-permissions/types"
+)
+
+// reportHTMLFile is registered by registerReportFlags when the report command runs
+var reportHTMLFile *string
+
+// registerReportFlags adds the report command's flags to fs
+func registerReportFlags(fs *flag.FlagSet) {
+	reportHTMLFile = fs.String("html", "", "report: write a standalone HTML report to FILE")
+}
+
+// reportData is everything rendered into the HTML report
+type reportData struct {
+	Project          string
+	GeneratedAt      string
+	Levels           []reportLevel
+	Permissions      []types.Permission
+	Duplicates       []types.Duplicate
+	ManagedFindings  []types.ManagedFinding
+	Risks            []reportRisk
+	SameLevelCleaned int
+}
+
+// reportLevel summarizes one settings level
+type reportLevel struct {
+	Name   string
+	Path   string
+	Exists bool
+	Allow  int
+	Deny   int
+}
+
+// reportRisk is a risk finding together with the level holding the rule
+type reportRisk struct {
+	rules.Risk
+	Level     string
+	Semantics string // Summary of how the rule's tool is matched
+}
+
+// runReportCommand writes a read-only HTML report of the current permissions
+func runReportCommand(_ []string) (int, error) {
+	if *reportHTMLFile == "" {
+		return 1, fmt.Errorf("usage: report --html FILE")
+	}
+
+	data, err := buildReportData()
+	if err != nil {
+		return 1, err
+	}
+
+	file, err := os.Create(*reportHTMLFile)
+	if err != nil {
+		return 1, fmt.Errorf("failed to create %s: %w", *reportHTMLFile, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := reportTemplate.Execute(file, data); err != nil {
+		return 1, fmt.Errorf("failed to render report: %w", err)
+	}
+
+	fmt.Printf("Report written to %s\n", *reportHTMLFile)
+	return 0, nil
+}
+
+// buildReportData runs the analyzers used by the editor over the current project
+func buildReportData() (*reportData, error) {
+	projectDir, err := resolveProjectDir(*projectFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	userLevel, repoLevel, localLevel, sameLevelCleaned, err := loadAllLevels(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	managedLevel, err := loadManagedLevel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load managed level: %w", err)
+	}
+
+	priority, err := loadDuplicatePriority()
+	if err != nil {
+		return nil, err
+	}
+	data := &reportData{
+		Project:          findProjectRoot(projectDir),
+		GeneratedAt:      time.Now().Format("2006-01-02 15:04"),
+		Permissions:      consolidatePermissions(userLevel, repoLevel, localLevel),
+		Duplicates:       detectDuplicates(userLevel, repoLevel, localLevel, priority),
+		ManagedFindings:  detectManagedFindings(managedLevel, localLevel, repoLevel, userLevel),
+		SameLevelCleaned: sameLevelCleaned,
+	}
+
+	levels := []types.SettingsLevel{localLevel, repoLevel, userLevel}
+	if managedLevel.Exists {
+		levels = append(levels, managedLevel)
+	}
+	for _, level := range levels {
+		data.Levels = append(data.Levels, reportLevel{
+			Name:   level.Name,
+			Path:   level.Path,
+			Exists: level.Exists,
+			Allow:  len(level.Permissions),
+			Deny:   len(level.Deny),
+		})
+		if level.Name == types.LevelManaged {
+			continue
+		}
+		for _, perm := range level.Permissions {
+			if risk, ok := rules.AssessRisk(perm); ok {
+				data.Risks = append(data.Risks, reportRisk{
+					Risk: risk, Level: level.Name, Semantics: docs.For(perm).Summary,
+				})
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// reportTemplate renders a standalone page; colors follow the TUI theme
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"lower": strings.ToLower,
+	"join":  strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Claude Code Permissions — {{.Project}}</title>
+<style>
+  body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 1000px; color: #1f2937; }
+  h1 { margin-bottom: 0; }
+  .meta { color: #6b7280; margin-top: .25rem; }
+  table { border-collapse: collapse; width: 100%; margin-bottom: 2rem; }
+  th, td { text-align: left; padding: .4rem .6rem; border-bottom: 1px solid #e5e7eb; }
+  th { background: #f3f4f6; }
+  code { font-family: ui-monospace, monospace; }
+  .stats { display: flex; gap: 1rem; margin: 1.5rem 0; }
+  .stat { border: 1px solid #e5e7eb; border-radius: 8px; padding: .75rem 1rem; flex: 1; }
+  .stat b { display: block; font-size: 1.5rem; }
+  .level { font-weight: bold; }
+  .level.local { color: #b45309; }
+  .level.repo { color: #0284c7; }
+  .level.user { color: #059669; }
+  .level.managed { color: #6b7280; }
+  .high { color: #dc2626; font-weight: bold; }
+  .medium { color: #d97706; font-weight: bold; }
+  .missing { color: #dc2626; }
+  .empty { color: #6b7280; font-style: italic; }
+</style>
+</head>
+<body>
+<h1>Claude Code Permissions</h1>
+<p class="meta">{{.Project}} · generated {{.GeneratedAt}}</p>
+
+<div class="stats">
+  <div class="stat"><b>{{len .Permissions}}</b>rules</div>
+  <div class="stat"><b>{{len .Duplicates}}</b>cross-level duplicates</div>
+  <div class="stat"><b>{{.SameLevelCleaned}}</b>same-level duplicates</div>
+  <div class="stat"><b>{{len .ManagedFindings}}</b>managed policy findings</div>
+  <div class="stat"><b>{{len .Risks}}</b>risks</div>
+</div>
+
+<h2>Levels</h2>
+<table>
+  <tr><th>Level</th><th>File</th><th>Allow</th><th>Deny</th></tr>
+  {{range .Levels}}
+  <tr>
+    <td class="level {{lower .Name}}">{{.Name}}</td>
+    <td>
+      <code>{{if .Path}}{{.Path}}{{else}}—{{end}}</code>
+      {{if not .Exists}}<span class="missing">(missing)</span>{{end}}
+    </td>
+    <td>{{.Allow}}</td>
+    <td>{{.Deny}}</td>
+  </tr>
+  {{end}}
+</table>
+
+<h2>Risks</h2>
+{{if .Risks}}
+<table>
+  <tr><th>Rule</th><th>Level</th><th>Severity</th><th>Reason</th><th>Matching</th></tr>
+  {{range .Risks}}
+  <tr>
+    <td><code>{{.Rule}}</code></td>
+    <td class="level {{lower .Level}}">{{.Level}}</td>
+    <td class="{{.Severity}}">{{.Severity}}</td>
+    <td>{{.Reason}}</td>
+    <td>{{.Semantics}}</td>
+  </tr>
+  {{end}}
+</table>
+{{else}}<p class="empty">No risky rules found.</p>{{end}}
+
+<h2>Duplicates</h2>
+{{if .Duplicates}}
+<table>
+  <tr><th>Rule</th><th>Found in</th><th>Suggested level</th></tr>
+  {{range .Duplicates}}
+  <tr>
+    <td><code>{{.Name}}</code></td>
+    <td>{{join .Levels ", "}}</td>
+    <td class="level {{lower .KeepLevel}}">{{.KeepLevel}}</td>
+  </tr>
+  {{end}}
+</table>
+{{else}}<p class="empty">No rules are duplicated across levels.</p>{{end}}
+
+<h2>Managed Policy</h2>
+{{if .ManagedFindings}}
+<table>
+  <tr><th>Rule</th><th>Level</th><th>Finding</th><th>Managed rule</th></tr>
+  {{range .ManagedFindings}}
+  <tr>
+    <td><code>{{.Name}}</code></td>
+    <td class="level {{lower .Level}}">{{.Level}}</td>
+    <td>{{.Kind}}</td>
+    <td><code>{{.ManagedRule}}</code></td>
+  </tr>
+  {{end}}
+</table>
+{{else}}<p class="empty">No rules overlap managed policy.</p>{{end}}
+
+<h2>All Rules</h2>
+<table>
+  <tr><th>Rule</th><th>Level</th><th>Source</th></tr>
+  {{range .Permissions}}
+  <tr>
+    <td><code>{{.Name}}</code></td>
+    <td class="level {{lower .CurrentLevel}}">{{.CurrentLevel}}</td>
+    <td>
+      {{with .Provenance}}{{.Source}} ({{.AddedAt.Format "2006-01-02"}}){{else}}hand-written{{end}}
+    </td>
+  </tr>
+  {{end}}
+</table>
+</body>
+</html>
+`))
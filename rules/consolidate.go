@@ -0,0 +1,70 @@
+package rules
+
+import (
+	"sort"
+	"strings"
+
+	"claude-permissions/types"
+)
+
+// MinConsolidation is how many narrow rules a broader rule must replace to be suggested
+const MinConsolidation = 3
+
+// SuggestConsolidations proposes broader allow rules that each replace several narrow rules
+// of level, e.g. "Bash(git:*)" for "Bash(git add:*)", "Bash(git commit:*)", and
+// "Bash(git push:*)". Replacements that AssessRisk flags are never suggested.
+func SuggestConsolidations(level types.SettingsLevel) []types.Consolidation {
+	groups := make(map[string][]string)
+	for _, raw := range level.Permissions {
+		if replacement := broaderRule(Parse(raw)); replacement != "" {
+			groups[replacement] = append(groups[replacement], raw)
+		}
+	}
+
+	var suggestions []types.Consolidation
+	for replacement, members := range groups {
+		var covered []string
+		for _, raw := range members {
+			if raw != replacement && Covers(Parse(replacement), Parse(raw)) {
+				covered = append(covered, raw)
+			}
+		}
+		if len(covered) < MinConsolidation {
+			continue
+		}
+		if _, risky := AssessRisk(replacement); risky {
+			continue
+		}
+		sort.Strings(covered)
+		suggestions = append(suggestions, types.Consolidation{
+			Level:       level.Name,
+			Replacement: replacement,
+			Rules:       covered,
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		return suggestions[i].Replacement < suggestions[j].Replacement
+	})
+	return suggestions
+}
+
+// broaderRule returns the rule a narrow rule would be grouped under: the command prefix for
+// shell rules and the server for MCP tools, or "" when there is none
+func broaderRule(rule Rule) string {
+	switch {
+	case rule.Tool == ToolBash:
+		command := strings.Fields(strings.TrimSuffix(rule.Specifier, ":*"))
+		if len(command) == 0 {
+			return ""
+		}
+		return ToolBash + "(" + command[0] + ":*)"
+	case strings.HasPrefix(rule.Tool, "mcp__") && rule.Specifier == "":
+		parts := strings.SplitN(rule.Tool, "__", 3)
+		if len(parts) < 3 || parts[1] == "" {
+			return ""
+		}
+		return "mcp__" + parts[1]
+	}
+	return ""
+}
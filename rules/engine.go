@@ -0,0 +1,75 @@
+package rules
+
+import (
+	"claude-permissions/types"
+)
+
+// Outcome is the result Claude Code would produce for a tool invocation
+type Outcome string
+
+// Possible outcomes of evaluating an invocation
+const (
+	OutcomeAllow  Outcome = "allow"
+	OutcomeDeny   Outcome = "deny"
+	OutcomePrompt Outcome = "prompt"
+)
+
+// Decision describes how an invocation is handled and which rule decided it
+type Decision struct {
+	Invocation string  `json:"invocation"`
+	Outcome    Outcome `json:"outcome"`
+	Rule       string  `json:"rule,omitempty"`
+	Level      string  `json:"level,omitempty"`
+}
+
+// Engine evaluates invocations against the effective rules of all settings levels
+type Engine struct {
+	levels []types.SettingsLevel
+}
+
+// NewEngine creates an engine over levels listed in precedence order (most specific first)
+func NewEngine(levels ...types.SettingsLevel) *Engine {
+	return &Engine{levels: levels}
+}
+
+// Evaluate decides whether an invocation is allowed, denied, or prompts the user.
+// Deny rules at any level take precedence over allow rules.
+func (e *Engine) Evaluate(raw string) Decision {
+	inv := ParseInvocation(raw)
+
+	denyRules := func(l types.SettingsLevel) []string { return l.Deny }
+	if rule, level, ok := e.firstMatch(inv, denyRules); ok {
+		return Decision{Invocation: inv.Raw, Outcome: OutcomeDeny, Rule: rule, Level: level}
+	}
+
+	allowRules := func(l types.SettingsLevel) []string { return l.Permissions }
+	if rule, level, ok := e.firstMatch(inv, allowRules); ok {
+		return Decision{Invocation: inv.Raw, Outcome: OutcomeAllow, Rule: rule, Level: level}
+	}
+
+	return Decision{Invocation: inv.Raw, Outcome: OutcomePrompt}
+}
+
+// EvaluateAll evaluates each invocation in order
+func (e *Engine) EvaluateAll(invocations []string) []Decision {
+	decisions := make([]Decision, 0, len(invocations))
+	for _, inv := range invocations {
+		decisions = append(decisions, e.Evaluate(inv))
+	}
+	return decisions
+}
+
+// firstMatch returns the first rule (and its level) selected by pick that covers inv
+func (e *Engine) firstMatch(
+	inv Invocation,
+	pick func(types.SettingsLevel) []string,
+) (rule, level string, ok bool) {
+	for _, lvl := range e.levels {
+		for _, raw := range pick(lvl) {
+			if Parse(raw).Matches(inv) {
+				return raw, lvl.Name, true
+			}
+		}
+	}
+	return "", "", false
+}
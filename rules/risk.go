@@ -0,0 +1,78 @@
+package rules
+
+import "strings"
+
+// Risk severities
+const (
+	SeverityHigh   = "high"
+	SeverityMedium = "medium"
+)
+
+// unrestrictedTools are tools whose bare rule grants unrestricted access
+var unrestrictedTools = map[string]bool{
+	ToolBash:       true,
+	ToolWebFetch:   true,
+	"Write":        true,
+	"Edit":         true,
+	"MultiEdit":    true,
+	"NotebookEdit": true,
+}
+
+// riskyCommands are shell commands whose prefix rules allow arbitrary or destructive actions
+var riskyCommands = map[string]bool{
+	"bash":  true,
+	"chmod": true,
+	"chown": true,
+	"curl":  true,
+	"dd":    true,
+	"eval":  true,
+	"rm":    true,
+	"sh":    true,
+	"sudo":  true,
+	"wget":  true,
+	"zsh":   true,
+}
+
+// Risk describes why an allow rule deserves a second look
+type Risk struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Reason   string `json:"reason"`
+}
+
+// AssessRisk reports whether an allow rule is unusually broad or dangerous
+func AssessRisk(raw string) (Risk, bool) {
+	rule := Parse(raw)
+	unrestricted := rule.Specifier == "" || rule.Specifier == "*" || rule.Specifier == ":*"
+
+	switch {
+	case unrestrictedTools[rule.Tool] && unrestricted:
+		return Risk{
+			Rule: raw, Severity: SeverityHigh,
+			Reason: "allows every " + rule.Tool + " call without prompting",
+		}, true
+	case rule.Tool == ToolBash && strings.HasSuffix(rule.Specifier, ":*"):
+		command := strings.Fields(strings.TrimSuffix(rule.Specifier, ":*"))
+		if len(command) > 0 && riskyCommands[command[0]] {
+			return Risk{
+				Rule: raw, Severity: SeverityHigh,
+				Reason: "allows any arguments to " + command[0],
+			}, true
+		}
+	case pathTools[rule.Tool] && isRootWildcard(rule.Specifier):
+		return Risk{
+			Rule: raw, Severity: SeverityMedium,
+			Reason: "matches every path below its root",
+		}, true
+	}
+	return Risk{}, false
+}
+
+// isRootWildcard reports whether a path pattern matches everything below the root or home
+func isRootWildcard(pattern string) bool {
+	switch pattern {
+	case "**", "/**", "//**", "~/**":
+		return true
+	}
+	return false
+}
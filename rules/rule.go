@@ -0,0 +1,241 @@
+package rules
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Tool names with specifier semantics that differ from exact matching
+const (
+	ToolBash     = "Bash"
+	ToolWebFetch = "WebFetch"
+)
+
+// pathTools lists tools whose specifiers are gitignore-style path patterns
+var pathTools = map[string]bool{
+	"Read":         true,
+	"Edit":         true,
+	"Write":        true,
+	"MultiEdit":    true,
+	"NotebookEdit": true,
+	"Glob":         true,
+	"Grep":         true,
+	"LS":           true,
+}
+
+// IsPathTool reports whether the tool's specifiers are path patterns
+func IsPathTool(tool string) bool {
+	return pathTools[tool]
+}
+
+// Rule represents a parsed permission rule such as "Bash(git add:*)"
+type Rule struct {
+	Raw       string
+	Tool      string
+	Specifier string
+}
+
+// Invocation represents a concrete tool call such as "Bash(git add README.md)"
+type Invocation struct {
+	Raw      string
+	Tool     string
+	Argument string
+}
+
+// Parse splits a permission rule into its tool name and optional specifier
+func Parse(raw string) Rule {
+	tool, spec := splitToolCall(raw)
+	return Rule{Raw: strings.TrimSpace(raw), Tool: tool, Specifier: spec}
+}
+
+// ParseInvocation splits a tool invocation into its tool name and argument
+func ParseInvocation(raw string) Invocation {
+	tool, arg := splitToolCall(raw)
+	return Invocation{Raw: strings.TrimSpace(raw), Tool: tool, Argument: arg}
+}
+
+// splitToolCall splits "Tool(argument)" into its two parts
+func splitToolCall(raw string) (tool, argument string) {
+	raw = strings.TrimSpace(raw)
+	open := strings.Index(raw, "(")
+	if open <= 0 || !strings.HasSuffix(raw, ")") {
+		return raw, ""
+	}
+	return raw[:open], raw[open+1 : len(raw)-1]
+}
+
+// Matches reports whether the rule covers the given invocation
+func (r Rule) Matches(inv Invocation) bool {
+	if !toolMatches(r.Tool, inv.Tool) {
+		return false
+	}
+
+	// A bare tool name (or wildcard specifier) covers every use of the tool
+	if r.Specifier == "" || r.Specifier == "*" {
+		return true
+	}
+	if inv.Argument == "" {
+		return false
+	}
+
+	switch {
+	case r.Tool == ToolBash:
+		return matchBash(r.Specifier, inv.Argument)
+	case r.Tool == ToolWebFetch:
+		return matchWebFetch(r.Specifier, inv.Argument)
+	case pathTools[r.Tool]:
+		return matchPath(r.Specifier, inv.Argument)
+	default:
+		return r.Specifier == inv.Argument
+	}
+}
+
+// toolMatches compares tool names, treating "mcp__server" as covering all of its tools
+func toolMatches(ruleTool, invTool string) bool {
+	if ruleTool == invTool {
+		return true
+	}
+	if strings.HasPrefix(ruleTool, "mcp__") {
+		server := strings.TrimSuffix(ruleTool, "__*")
+		return strings.HasPrefix(invTool, server+"__")
+	}
+	return false
+}
+
+// matchBash implements exact and ":*" prefix matching for shell commands
+func matchBash(spec, command string) bool {
+	if prefix, ok := strings.CutSuffix(spec, ":*"); ok {
+		return strings.HasPrefix(strings.TrimSpace(command), prefix)
+	}
+	return spec == strings.TrimSpace(command)
+}
+
+// matchWebFetch implements "domain:host" matching against a URL or host
+func matchWebFetch(spec, target string) bool {
+	domain, ok := strings.CutPrefix(spec, "domain:")
+	if !ok {
+		return spec == target
+	}
+
+	host := strings.TrimPrefix(target, "domain:")
+	if parsed, err := url.Parse(target); err == nil && parsed.Host != "" {
+		host = parsed.Hostname()
+	}
+	return strings.EqualFold(host, domain)
+}
+
+// matchPath implements gitignore-style matching with support for "**"
+func matchPath(pattern, target string) bool {
+	pattern = strings.TrimPrefix(path.Clean(pattern), "./")
+	target = strings.TrimPrefix(path.Clean(target), "./")
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(target, "/"))
+}
+
+// matchSegments matches path segments where "**" spans zero or more segments
+func matchSegments(pattern, target []string) bool {
+	if len(pattern) == 0 {
+		return len(target) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(target); i++ {
+			if matchSegments(pattern[1:], target[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(target) == 0 {
+		return false
+	}
+	if ok, err := path.Match(pattern[0], target[0]); err != nil || !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], target[1:])
+}
+
+// Covers reports whether broad permits everything narrow permits,
+// e.g. "Bash" covers "Bash(git add:*)" and "Bash(git:*)" covers "Bash(git add:*)"
+func Covers(broad, narrow Rule) bool {
+	if broad.Raw == narrow.Raw {
+		return true
+	}
+	if narrow.Specifier == "" || narrow.Specifier == "*" {
+		return toolMatches(broad.Tool, narrow.Tool) &&
+			(broad.Specifier == "" || broad.Specifier == "*")
+	}
+
+	if !toolMatches(broad.Tool, narrow.Tool) {
+		return false
+	}
+	if broad.Specifier == "" || broad.Specifier == "*" {
+		return true
+	}
+
+	switch {
+	case narrow.Tool == ToolBash:
+		return coversBash(broad.Specifier, narrow.Specifier)
+	case pathTools[narrow.Tool]:
+		return coversPath(broad.Specifier, narrow.Specifier)
+	default:
+		return broad.Matches(Invocation{
+			Raw: narrow.Raw, Tool: narrow.Tool, Argument: narrow.Specifier,
+		})
+	}
+}
+
+// coversBash reports whether the command spec broad permits every command narrow does. An
+// exact command never covers a ":*" prefix, and prefix "P:*" covers "Q:*" when Q starts with P.
+func coversBash(broad, narrow string) bool {
+	narrowPrefix, narrowIsPrefix := strings.CutSuffix(narrow, ":*")
+	if !narrowIsPrefix {
+		return matchBash(broad, narrow)
+	}
+	broadPrefix, broadIsPrefix := strings.CutSuffix(broad, ":*")
+	return broadIsPrefix && strings.HasPrefix(strings.TrimSpace(narrowPrefix), broadPrefix)
+}
+
+// coversPath reports whether the path pattern broad matches every path narrow does
+func coversPath(broad, narrow string) bool {
+	broad = strings.TrimPrefix(path.Clean(broad), "./")
+	narrow = strings.TrimPrefix(path.Clean(narrow), "./")
+	return coversSegments(strings.Split(broad, "/"), strings.Split(narrow, "/"))
+}
+
+// coversSegments reports whether the broad segments match every path the narrow segments do.
+// Only "**" spans several segments, so "src/*" does not cover "src/**". Within a segment,
+// patterns other than "*" are only known to cover an identical pattern or a literal name.
+func coversSegments(broad, narrow []string) bool {
+	if len(broad) == 0 {
+		return len(narrow) == 0
+	}
+	if broad[0] == "**" {
+		for i := 0; i <= len(narrow); i++ {
+			if coversSegments(broad[1:], narrow[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(narrow) == 0 || narrow[0] == "**" {
+		return false
+	}
+	if !coversSegment(broad[0], narrow[0]) {
+		return false
+	}
+	return coversSegments(broad[1:], narrow[1:])
+}
+
+// coversSegment reports whether the broad segment pattern matches every name narrow does
+func coversSegment(broad, narrow string) bool {
+	if broad == narrow || broad == "*" {
+		return true
+	}
+	if strings.ContainsAny(narrow, `*?[\`) {
+		return false
+	}
+	ok, err := path.Match(broad, narrow)
+	return err == nil && ok
+}
@@ -0,0 +1,73 @@
+package rules
+
+import "testing"
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		rule       string
+		invocation string
+		want       bool
+	}{
+		{"Bash", "Bash(rm -rf /)", true},
+		{"Bash(*)", "Bash(ls)", true},
+		{"Bash(git add:*)", "Bash(git add README.md)", true},
+		{"Bash(git add:*)", "Bash(git commit)", false},
+		{"Bash(git status)", "Bash(git status)", true},
+		{"Bash(git status)", "Bash(git status --short)", false},
+		{"Bash(ls)", "Read(ls)", false},
+		{"Read(src/*)", "Read(src/main.go)", true},
+		{"Read(src/*)", "Read(src/pkg/main.go)", false},
+		{"Read(src/**)", "Read(src/pkg/main.go)", true},
+		{"Read(**/*.go)", "Read(main.go)", true},
+		{"Read(./src/*.go)", "Read(src/main.go)", true},
+		{"WebFetch(domain:example.com)", "WebFetch(https://example.com/page)", true},
+		{"WebFetch(domain:example.com)", "WebFetch(https://other.com/)", false},
+		{"mcp__github", "mcp__github__create_issue", true},
+		{"mcp__github", "mcp__gitlab__create_issue", false},
+	}
+	for _, tt := range tests {
+		got := Parse(tt.rule).Matches(ParseInvocation(tt.invocation))
+		if got != tt.want {
+			t.Errorf("Parse(%q).Matches(%q) = %v, want %v", tt.rule, tt.invocation, got, tt.want)
+		}
+	}
+}
+
+func TestCovers(t *testing.T) {
+	tests := []struct {
+		broad  string
+		narrow string
+		want   bool
+	}{
+		{"Bash", "Bash(git add:*)", true},
+		{"Bash(*)", "Bash(git add)", true},
+		{"Bash(git add:*)", "Bash", false},
+		{"Bash(git:*)", "Bash(git add:*)", true},
+		{"Bash(git add:*)", "Bash(git add:*)", true},
+		{"Bash(git add:*)", "Bash(git add README.md)", true},
+		{"Bash(git add:*)", "Bash(git:*)", false},
+		{"Bash(git add)", "Bash(git add:*)", false},
+		{"Bash(git add)", "Bash(git add)", true},
+		{"Read", "Read(src/*)", true},
+		{"Read(src/**)", "Read(src/*)", true},
+		{"Read(src/**)", "Read(src/pkg/**)", true},
+		{"Read(src/*)", "Read(src/**)", false},
+		{"Read(src/*)", "Read(src/main.go)", true},
+		{"Read(src/*)", "Read(src/*.go)", true},
+		{"Read(src/*.go)", "Read(src/*)", false},
+		{"Read(src/*.go)", "Read(src/main.go)", true},
+		{"Read(**)", "Read(src/**/*.go)", true},
+		{"Read(src/main.go)", "Read(src/*.go)", false},
+		{"Read(src/**)", "Edit(src/main.go)", false},
+		{"WebFetch(domain:example.com)", "WebFetch(domain:example.com)", true},
+		{"WebFetch(domain:example.com)", "WebFetch(domain:other.com)", false},
+		{"mcp__github", "mcp__github__create_issue", true},
+		{"mcp__github__create_issue", "mcp__github", false},
+	}
+	for _, tt := range tests {
+		got := Covers(Parse(tt.broad), Parse(tt.narrow))
+		if got != tt.want {
+			t.Errorf("Covers(%q, %q) = %v, want %v", tt.broad, tt.narrow, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"claude-permissions/types"
+)
+
+// Target returns the file or directory a rule refers to, or "" when it names none: the
+// script of a shell rule whose command is a path, e.g. "./scripts/build.sh" for
+// "Bash(./scripts/build.sh:*)", or the literal directories of a path rule before its first
+// wildcard, e.g. "src" for "Read(src/**)"
+func Target(rule Rule) string {
+	switch {
+	case rule.Tool == ToolBash:
+		command := strings.Fields(strings.TrimSuffix(rule.Specifier, ":*"))
+		if len(command) == 0 || !strings.Contains(command[0], "/") ||
+			strings.ContainsAny(command[0], "*?[") {
+			return ""
+		}
+		return command[0]
+	case pathTools[rule.Tool]:
+		var literal []string
+		for _, segment := range strings.Split(rule.Specifier, "/") {
+			if strings.ContainsAny(segment, "*?[") {
+				break
+			}
+			literal = append(literal, segment)
+		}
+		target := strings.Join(literal, "/")
+		if target == "" || target == "~" || strings.Trim(target, "/.") == "" {
+			return ""
+		}
+		return target
+	}
+	return ""
+}
+
+// FindStale returns the allow rules of level whose Target no longer exists. Targets under
+// "~/" are looked up in home and other relative targets in root.
+func FindStale(level types.SettingsLevel, root, home string) []types.StaleRule {
+	var stale []types.StaleRule
+	for _, raw := range level.Permissions {
+		target := Target(Parse(raw))
+		if target == "" {
+			continue
+		}
+		resolved := target
+		if rest, ok := strings.CutPrefix(target, "~/"); ok {
+			if home == "" {
+				continue
+			}
+			resolved = filepath.Join(home, rest)
+		} else if !filepath.IsAbs(target) {
+			resolved = filepath.Join(root, target)
+		}
+		if _, err := os.Stat(resolved); os.IsNotExist(err) {
+			stale = append(stale, types.StaleRule{Level: level.Name, Rule: raw, Target: target})
+		}
+	}
+	return stale
+}
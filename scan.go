@@ -0,0 +1,273 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"claude-permissions/docs"
+	"claude-permissions/paths"
+	"claude-permissions/rules"
+	"claude-permissions/types"
+	"claude-permissions/ui"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// Flags registered by registerScanFlags when the scan command runs
+var (
+	scanRoot *string
+	scanPick *bool
+)
+
+// scanSkipDirs are directories never descended into while looking for repositories
+var scanSkipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	".cache":       true,
+}
+
+// registerScanFlags adds the scan command's flags to fs
+func registerScanFlags(fs *flag.FlagSet) {
+	scanRoot = fs.String("root", ".", "scan: directory tree to search for repositories")
+	scanPick = fs.Bool("pick", false, "scan: choose a repository to open in the editor afterwards")
+}
+
+// scanReport aggregates findings across every repository with Claude settings
+type scanReport struct {
+	Root             string            `json:"root"`
+	ReposScanned     int               `json:"repos_scanned"`
+	Repos            []repoFindings    `json:"repos"`
+	CommonDuplicates []commonDuplicate `json:"common_duplicates"`
+}
+
+// repoFindings lists the audit results for one repository
+type repoFindings struct {
+	Root             string            `json:"root"`
+	Rules            int               `json:"rules"`
+	Duplicates       []types.Duplicate `json:"duplicates"`
+	SameLevelRemoved int               `json:"same_level_removed"`
+	Risks            []rules.Risk      `json:"risks"`
+	Error            string            `json:"error,omitempty"`
+	userDuplicates   map[string]bool   // Rules this repo shares with the user level
+}
+
+// commonDuplicate is a user-level rule that many repositories repeat
+type commonDuplicate struct {
+	Rule  string `json:"rule"`
+	Repos int    `json:"repos"`
+}
+
+// runScanCommand audits every repository below --root and prints an aggregate report
+func runScanCommand(_ []string) (int, error) {
+	root, err := resolveProjectDir(*scanRoot)
+	if err != nil {
+		return 1, err
+	}
+
+	report, err := buildScanReport(root)
+	if err != nil {
+		return 1, err
+	}
+
+	if *outputFormat == formatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return 1, err
+		}
+	} else {
+		printScanReport(report)
+	}
+
+	if *scanPick && len(report.Repos) > 0 {
+		return 0, pickAndOpenRepo(report)
+	}
+	return 0, nil
+}
+
+// buildScanReport finds repositories below root and audits their settings against the user level
+func buildScanReport(root string) (*scanReport, error) {
+	userLevel, err := loadUserLevel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user level: %w", err)
+	}
+	autoResolveSameLevelDuplicates(&userLevel)
+
+	repos, err := findRepositories(root)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &scanReport{Root: root, ReposScanned: len(repos)}
+	priority, err := loadDuplicatePriority()
+	if err != nil {
+		return nil, err
+	}
+	commonCounts := make(map[string]int)
+	for _, repo := range repos {
+		findings, ok := auditRepository(repo, userLevel, priority)
+		if !ok {
+			continue
+		}
+		for rule := range findings.userDuplicates {
+			commonCounts[rule]++
+		}
+		report.Repos = append(report.Repos, findings)
+	}
+
+	for rule, count := range commonCounts {
+		report.CommonDuplicates = append(report.CommonDuplicates,
+			commonDuplicate{Rule: rule, Repos: count})
+	}
+	sort.Slice(report.CommonDuplicates, func(i, j int) bool {
+		a, b := report.CommonDuplicates[i], report.CommonDuplicates[j]
+		if a.Repos != b.Repos {
+			return a.Repos > b.Repos
+		}
+		return a.Rule < b.Rule
+	})
+
+	return report, nil
+}
+
+// findRepositories returns the root of every git repository below root, without descending
+// into repositories once found
+func findRepositories(root string) ([]string, error) {
+	var repos []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable directories are skipped rather than aborting the scan
+			if entry != nil && entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if scanSkipDirs[entry.Name()] || (entry.Name() == ".git" && path != root) {
+			return filepath.SkipDir
+		}
+		if _, err := os.Stat(filepath.Join(path, ".git", "config")); err == nil {
+			repos = append(repos, path)
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+	return repos, nil
+}
+
+// auditRepository loads a repository's levels and reports its findings; ok is false when the
+// repository has no Claude settings
+func auditRepository(
+	root string,
+	userLevel types.SettingsLevel,
+	priority []string,
+) (repoFindings, bool) {
+	findings := repoFindings{Root: root, userDuplicates: make(map[string]bool)}
+
+	repoLevel, repoErr := loadSettingsLevel(types.LevelRepo, paths.RepoSettings(root))
+	localLevel, localErr := loadSettingsLevel(types.LevelLocal, paths.LocalSettings(root))
+	if !repoLevel.Exists && !localLevel.Exists {
+		return findings, false
+	}
+	for _, err := range []error{repoErr, localErr} {
+		if err != nil {
+			findings.Error = err.Error()
+			return findings, true
+		}
+	}
+
+	findings.SameLevelRemoved = len(autoResolveSameLevelDuplicates(&repoLevel)) +
+		len(autoResolveSameLevelDuplicates(&localLevel))
+	findings.Rules = len(consolidatePermissions(userLevel, repoLevel, localLevel))
+
+	findings.Duplicates = detectDuplicates(userLevel, repoLevel, localLevel, priority)
+	for _, dup := range findings.Duplicates {
+		for _, level := range dup.Levels {
+			if level == types.LevelUser {
+				findings.userDuplicates[dup.Name] = true
+			}
+		}
+	}
+
+	for _, level := range []types.SettingsLevel{repoLevel, localLevel} {
+		for _, perm := range level.Permissions {
+			if risk, ok := rules.AssessRisk(perm); ok {
+				findings.Risks = append(findings.Risks, risk)
+			}
+		}
+	}
+
+	return findings, true
+}
+
+// printScanReport prints per-repository findings followed by the aggregate
+func printScanReport(report *scanReport) {
+	fmt.Printf("Scanned %d repositories under %s, %d with Claude settings\n",
+		report.ReposScanned, report.Root, len(report.Repos))
+
+	for _, repo := range report.Repos {
+		fmt.Printf("\n%s\n", repo.Root)
+		if repo.Error != "" {
+			fmt.Printf("  error: %s\n", repo.Error)
+			continue
+		}
+		fmt.Printf("  %s\n", repoSummary(repo))
+		for _, dup := range repo.Duplicates {
+			fmt.Printf("  • duplicate %s in %v\n", dup.Name, dup.Levels)
+		}
+		for _, risk := range repo.Risks {
+			fmt.Printf("  • %s risk %s: %s\n", risk.Severity, risk.Rule, risk.Reason)
+			fmt.Printf("      %s\n", docs.For(risk.Rule).Summary)
+		}
+	}
+
+	if len(report.CommonDuplicates) > 0 {
+		fmt.Println("\nRules most often repeated from the user level:")
+		for _, common := range report.CommonDuplicates {
+			fmt.Printf("  %3d  %s\n", common.Repos, common.Rule)
+		}
+	}
+}
+
+// repoSummary returns a one-line count of a repository's findings
+func repoSummary(repo repoFindings) string {
+	if repo.Error != "" {
+		return "error: " + repo.Error
+	}
+	return fmt.Sprintf("%d rules, %d duplicates, %d same-level, %d risks",
+		repo.Rules, len(repo.Duplicates), repo.SameLevelRemoved, len(repo.Risks))
+}
+
+// pickAndOpenRepo lets the user choose a scanned repository and opens it in the editor
+func pickAndOpenRepo(report *scanReport) error {
+	roots := make([]string, 0, len(report.Repos))
+	summaries := make([]string, 0, len(report.Repos))
+	for _, repo := range report.Repos {
+		roots = append(roots, repo.Root)
+		summaries = append(summaries, repoSummary(repo))
+	}
+
+	picker := ui.NewRepoPicker(roots, summaries)
+	if _, err := tea.NewProgram(picker, tea.WithAltScreen()).Run(); err != nil {
+		return err
+	}
+	if picker.Selected == "" {
+		return nil
+	}
+
+	dataModel, err := initialModel(picker.Selected)
+	if err != nil {
+		return err
+	}
+	return runEditor(dataModel)
+}
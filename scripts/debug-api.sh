@@ -14,11 +14,22 @@ DEFAULT_HOST="localhost"
 COMMAND=""
 PORT="$DEFAULT_PORT"
 HOST="$DEFAULT_HOST"
-KEY=""
+TOKEN="${CLAUDE_PERMISSIONS_DEBUG_TOKEN:-}"
+KEYS=()
 COLOR=false
 USER_FILE=""
 REPO_FILE=""
 LOCAL_FILE=""
+MESSAGE=""
+SEVERITY="info"
+LOG_FILTERS=""
+STATE_FILE=""
+SCENARIO=""
+GOLDEN=""
+UPDATE=false
+IGNORE=()
+WIDTH=""
+HEIGHT=""
 
 usage() {
     cat << EOF
@@ -26,45 +37,71 @@ Usage: $0 <command> [options]
 
 Commands:
   health                    - Check debug server health
-  state                     - Get application state (UI, data, files)
-  layout                    - Get layout diagnostics
+  state                     - Get application state (UI, data, files, full model)
+  changes                   - List the pending changes as the confirm screen would
+  set-state --file <path>   - Replace the model with the JSON state in a file
   snapshot                  - Capture screen content
-  logs                      - Get debug event logs
-  input <key>               - Send key input to application
+  compare <golden>          - Diff the screen against testdata/golden/<golden>.txt
+  logs                      - Get debug event logs, leaving them for other readers
+  clear-logs                - Clear the debug event logs
+  input <key>...            - Send keys to the application, each once the previous one settled
   reset                     - Reset application state
-  launch-confirm-changes    - Launch confirmation screen with mock changes
+  launch-confirm-changes    - Launch confirmation screen in a scenario (default: confirm-changes)
+  scenario [name]           - Load a scenario from testdata/scenarios, or list them without a name
   load-settings             - Load settings from specified file paths
+  notify <message>          - Queue a status bar notification
+  resize <width> <height>   - Send a synthetic terminal resize and capture the screen at that size
 
 Options:
   --port <port>     - Debug server port (default: $DEFAULT_PORT)
   --host <host>     - Debug server host (default: $DEFAULT_HOST)
+  --token <token>   - Token for a server started with --debug-token
+                      (default: \$CLAUDE_PERMISSIONS_DEBUG_TOKEN)
   --color           - For snapshot: include ANSI color codes (default: stripped)
   --user-file <path>   - For load-settings: path to user settings file
   --repo-file <path>   - For load-settings: path to repo settings file
   --local-file <path>  - For load-settings: path to local settings file
+  --update             - For compare: write the screen as the golden file
+  --ignore <regex>     - For compare: also mask matches of this expression; repeatable
+  --scenario <name>    - For launch-confirm-changes: scenario to launch the screen in
+  --file <path>        - For set-state: JSON model state, in the shape of state's "model"
+  --severity <level>   - For notify: info (default), warn, or error
+  --filter <key=value> - For logs: only entries with this attribute (screen, component,
+                         level_name, permission, change_id, project), level, or event;
+                         since_id=N, limit=N, and wait=SECONDS page and long-poll; repeatable
 
 Key Input Examples:
   tab, enter, escape, up, down, left, right, space
   a, u, r, l, e, c, q, /, 1, 2, 3
+  Chords join ctrl, alt, or shift to a key: ctrl+c, shift+tab
 
 Examples:
   $0 health
   $0 state
-  $0 layout
+  $0 changes
+  $0 set-state --file scenario.json
   $0 snapshot --color
+  $0 compare organization --ignore 'Files: .*'
   $0 logs
+  $0 logs --filter component=save --filter level_name=Local
+  $0 logs --filter since_id=42 --filter wait=10
+  $0 clear-logs
   $0 input tab
   $0 input enter
+  $0 input down down space
   $0 reset
   $0 launch-confirm-changes
+  $0 scenario 50-duplicates
   $0 load-settings --user-file testdata/user-no-duplicates.json --repo-file testdata/repo-no-duplicates.json
+  $0 notify "Settings changed on disk" --severity warn
+  $0 resize 80 24
 EOF
 }
 
 # Parse arguments
 while [[ $# -gt 0 ]]; do
     case $1 in
-        health|state|layout|snapshot|logs|input|reset|launch-confirm-changes|load-settings)
+        health|state|changes|set-state|snapshot|compare|logs|clear-logs|input|reset|launch-confirm-changes|scenario|load-settings|notify|resize)
             COMMAND="$1"
             shift
             ;;
@@ -76,6 +113,10 @@ while [[ $# -gt 0 ]]; do
             HOST="$2"
             shift 2
             ;;
+        --token)
+            TOKEN="$2"
+            shift 2
+            ;;
         --color)
             COLOR=true
             shift
@@ -92,13 +133,52 @@ while [[ $# -gt 0 ]]; do
             LOCAL_FILE="$2"
             shift 2
             ;;
+        --update)
+            UPDATE=true
+            shift
+            ;;
+        --ignore)
+            IGNORE+=("$2")
+            shift 2
+            ;;
+        --scenario)
+            SCENARIO="$2"
+            shift 2
+            ;;
+        --file)
+            STATE_FILE="$2"
+            shift 2
+            ;;
+        --severity)
+            SEVERITY="$2"
+            shift 2
+            ;;
+        --filter)
+            LOG_FILTERS="${LOG_FILTERS:+$LOG_FILTERS&}$2"
+            shift 2
+            ;;
         --help|-h)
             usage
             exit 0
             ;;
         *)
-            if [[ "$COMMAND" == "input" && -z "$KEY" ]]; then
-                KEY="$1"
+            if [[ "$COMMAND" == "input" ]]; then
+                KEYS+=("$1")
+                shift
+            elif [[ "$COMMAND" == "compare" && -z "$GOLDEN" ]]; then
+                GOLDEN="$1"
+                shift
+            elif [[ "$COMMAND" == "scenario" && -z "$SCENARIO" ]]; then
+                SCENARIO="$1"
+                shift
+            elif [[ "$COMMAND" == "notify" && -z "$MESSAGE" ]]; then
+                MESSAGE="$1"
+                shift
+            elif [[ "$COMMAND" == "resize" && -z "$WIDTH" ]]; then
+                WIDTH="$1"
+                shift
+            elif [[ "$COMMAND" == "resize" && -z "$HEIGHT" ]]; then
+                HEIGHT="$1"
                 shift
             else
                 echo "Unknown option: $1" >&2
@@ -117,15 +197,35 @@ if [[ -z "$COMMAND" ]]; then
 fi
 
 # Validate key for input command
-if [[ "$COMMAND" == "input" && -z "$KEY" ]]; then
+if [[ "$COMMAND" == "input" && ${#KEYS[@]} -eq 0 ]]; then
     echo "Error: Key required for input command" >&2
     usage >&2
     exit 1
 fi
 
+# Validate message for notify command
+if [[ "$COMMAND" == "notify" && -z "$MESSAGE" ]]; then
+    echo "Error: Message required for notify command" >&2
+    usage >&2
+    exit 1
+fi
+
+# Validate size for resize command
+if [[ "$COMMAND" == "resize" && ( ! "$WIDTH" =~ ^[0-9]+$ || ! "$HEIGHT" =~ ^[0-9]+$ ) ]]; then
+    echo "Error: Width and height required for resize command" >&2
+    usage >&2
+    exit 1
+fi
+
 # Base URL
 BASE_URL="http://$HOST:$PORT"
 
+# Header carrying the token, if the server requires one
+AUTH=()
+if [[ -n "$TOKEN" ]]; then
+    AUTH=(-H "X-Debug-Token: $TOKEN")
+fi
+
 # Helper function to make GET requests
 make_get_request() {
     local endpoint="$1"
@@ -136,7 +236,7 @@ make_get_request() {
         url="$url?$query_params"
     fi
 
-    if ! curl -s -f "$url"; then
+    if ! curl -s -f ${AUTH[@]+"${AUTH[@]}"} "$url"; then
         echo "Error: Failed to connect to debug server at $BASE_URL" >&2
         echo "Make sure the application is running with --debug-server flag" >&2
         exit 1
@@ -150,7 +250,7 @@ make_post_request() {
 
     local url="$BASE_URL$endpoint"
 
-    if ! curl -s -f -X POST -H "Content-Type: application/json" -d "$data" "$url"; then
+    if ! curl -s -f ${AUTH[@]+"${AUTH[@]}"} -X POST -H "Content-Type: application/json" -d "$data" "$url"; then
         echo "Error: Failed to send POST request to debug server at $BASE_URL" >&2
         echo "Make sure the application is running with --debug-server flag" >&2
         exit 1
@@ -167,8 +267,12 @@ case "$COMMAND" in
         make_get_request "/state"
         ;;
 
-    layout)
-        make_get_request "/layout"
+    set-state)
+        if [[ -z "$STATE_FILE" ]]; then
+            echo "Error: set-state requires --file <path>" >&2
+            exit 1
+        fi
+        make_post_request "/state" "$(cat "$STATE_FILE")"
         ;;
 
     snapshot)
@@ -179,12 +283,40 @@ case "$COMMAND" in
         fi
         ;;
 
+    compare)
+        if [[ -z "$GOLDEN" ]]; then
+            echo "Error: compare requires a golden file name" >&2
+            exit 1
+        fi
+        ignore_json=""
+        for pattern in "${IGNORE[@]+"${IGNORE[@]}"}"; do
+            escaped="${pattern//\\/\\\\}"
+            escaped="${escaped//\"/\\\"}"
+            ignore_json="${ignore_json:+$ignore_json,}\"$escaped\""
+        done
+        make_post_request "/snapshot/compare" \
+            "{\"golden\":\"$GOLDEN\",\"update\":$UPDATE,\"ignore\":[$ignore_json]}"
+        ;;
+
     logs)
-        make_get_request "/logs"
+        make_get_request "/logs" "${LOG_FILTERS:-}"
+        ;;
+
+    clear-logs)
+        if ! curl -s -f ${AUTH[@]+"${AUTH[@]}"} -X DELETE "$BASE_URL/logs"; then
+            echo "Error: Failed to send DELETE request to debug server at $BASE_URL" >&2
+            echo "Make sure the application is running with --debug-server flag" >&2
+            exit 1
+        fi
         ;;
 
     input)
-        make_post_request "/input" "{\"key\":\"$KEY\"}"
+        keys_json=$(printf '"%s",' "${KEYS[@]}")
+        make_post_request "/input" "{\"keys\":[${keys_json%,}]}"
+        ;;
+
+    changes)
+        make_get_request "/changes"
         ;;
 
     reset)
@@ -192,30 +324,15 @@ case "$COMMAND" in
         ;;
 
     launch-confirm-changes)
-        # Create mock data with permissions that exist in test data
-        mock_data='{
-            "mock_changes": {
-                "permission_moves": [
-                    {
-                        "name": "Read",
-                        "from": "User",
-                        "to": "Local"
-                    },
-                    {
-                        "name": "Bash",
-                        "from": "User",
-                        "to": "Repo"
-                    },
-                    {
-                        "name": "Glob",
-                        "from": "Local",
-                        "to": "User"
-                    }
-                ],
-                "duplicate_resolutions": []
-            }
-        }'
-        make_post_request "/launch-confirm-changes" "$mock_data"
+        make_post_request "/launch-confirm-changes" "{\"scenario\":\"${SCENARIO:-confirm-changes}\"}"
+        ;;
+
+    scenario)
+        if [[ -n "$SCENARIO" ]]; then
+            make_post_request "/scenario" "{\"name\":\"$SCENARIO\"}"
+        else
+            make_get_request "/scenario"
+        fi
         ;;
 
     load-settings)
@@ -246,6 +363,16 @@ case "$COMMAND" in
         make_post_request "/load-settings" "$json_data"
         ;;
 
+    notify)
+        escaped_message="${MESSAGE//\\/\\\\}"
+        escaped_message="${escaped_message//\"/\\\"}"
+        make_post_request "/notify" "{\"message\":\"$escaped_message\",\"severity\":\"$SEVERITY\"}"
+        ;;
+
+    resize)
+        make_post_request "/resize" "{\"width\":$WIDTH,\"height\":$HEIGHT}"
+        ;;
+
     *)
         echo "Error: Unknown command: $COMMAND" >&2
         usage >&2
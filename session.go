@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"claude-permissions/paths"
+	"claude-permissions/types"
+)
+
+// printSessionSummary prints what the editor session changed so the shell scrollback
+// documents it
+func printSessionSummary(session types.SessionStats, now time.Time) {
+	elapsed := now.Sub(session.StartedAt).Round(time.Second)
+	if len(session.FilesWritten) == 0 {
+		fmt.Printf("Session (%s): no changes saved\n", elapsed)
+		return
+	}
+
+	fmt.Printf("Session (%s):\n", elapsed)
+	fmt.Printf("  Rules moved:         %d\n", session.RulesMoved)
+	fmt.Printf("  Duplicates resolved: %d\n", session.DuplicatesResolved)
+	fmt.Printf("  Files written:       %d\n", len(session.FilesWritten))
+	for _, path := range session.FilesWritten {
+		fmt.Printf("    %s\n", paths.Abbreviate(path))
+	}
+	for _, dir := range session.BackupDirs {
+		fmt.Printf("  Backup:              %s\n", paths.Abbreviate(dir))
+	}
+}
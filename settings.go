@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -9,29 +8,36 @@ import (
 	"sort"
 	"strings"
 
+	"claude-permissions/config"
+	"claude-permissions/metadata"
+	"claude-permissions/paths"
+	"claude-permissions/rules"
+	"claude-permissions/settingsfile"
 	"claude-permissions/types"
 )
 
 // loadUserLevel loads user-level settings with chezmoi integration
 func loadUserLevel() (types.SettingsLevel, error) {
-	// Use command line override if provided
+	resolved, err := resolveUserSettingsPath()
+	if err != nil {
+		return types.SettingsLevel{}, err
+	}
+	return loadSettingsLevel("User", resolved.Path)
+}
+
+// resolveUserSettingsPath resolves the user settings file: command line override, then
+// chezmoi source, then the platform location from the paths package
+func resolveUserSettingsPath() (paths.Resolved, error) {
 	if *userFile != "" {
-		return loadSettingsLevel("User", *userFile)
+		return paths.NewResolved(types.LevelUser, *userFile, paths.SourceFlag), nil
 	}
 
 	// Check for chezmoi integration
 	if path := getChezmoidUserPath(); path != "" {
-		return loadSettingsLevel("User", path)
-	}
-
-	// Fallback to standard path
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return types.SettingsLevel{}, err
+		return paths.NewResolved(types.LevelUser, path, paths.SourceChezmoi), nil
 	}
 
-	path := filepath.Join(home, ".claude", "settings.json")
-	return loadSettingsLevel("User", path)
+	return paths.UserSettings(types.LevelUser)
 }
 
 // getChezmoidUserPath returns the chezmoi source path for user settings
@@ -61,15 +67,10 @@ func getChezmoidUserPath() string {
 	return path
 }
 
-// loadRepoLevel loads repository-level settings
-func loadRepoLevel() (types.SettingsLevel, error) {
-	// Use command line override if provided
-	if *repoFile != "" {
-		return loadSettingsLevel("Repo", *repoFile)
-	}
-
-	repoRoot, err := findGitRoot()
-	if err != nil {
+// loadRepoLevel loads repository-level settings for the project containing projectDir
+func loadRepoLevel(projectDir string) (types.SettingsLevel, error) {
+	resolved := resolveRepoSettingsPath(projectDir)
+	if resolved.Path == "" {
 		return types.SettingsLevel{
 			Name:        types.LevelRepo,
 			Path:        "",
@@ -77,20 +78,13 @@ func loadRepoLevel() (types.SettingsLevel, error) {
 			Exists:      false,
 		}, nil
 	}
-
-	path := filepath.Join(repoRoot, ".claude", "settings.json")
-	return loadSettingsLevel("Repo", path)
+	return loadSettingsLevel("Repo", resolved.Path)
 }
 
-// loadLocalLevel loads local-level settings
-func loadLocalLevel() (types.SettingsLevel, error) {
-	// Use command line override if provided
-	if *localFile != "" {
-		return loadSettingsLevel("Local", *localFile)
-	}
-
-	repoRoot, err := findGitRoot()
-	if err != nil {
+// loadLocalLevel loads local-level settings for the project containing projectDir
+func loadLocalLevel(projectDir string) (types.SettingsLevel, error) {
+	resolved := resolveLocalSettingsPath(projectDir)
+	if resolved.Path == "" {
 		return types.SettingsLevel{
 			Name:        types.LevelLocal,
 			Path:        "",
@@ -98,19 +92,104 @@ func loadLocalLevel() (types.SettingsLevel, error) {
 			Exists:      false,
 		}, nil
 	}
+	return loadSettingsLevel("Local", resolved.Path)
+}
 
-	path := filepath.Join(repoRoot, ".claude", "settings.local.json")
-	return loadSettingsLevel("Local", path)
+// loadManagedLevel loads the read-only enterprise managed policy level
+func loadManagedLevel() (types.SettingsLevel, error) {
+	return loadSettingsLevel(types.LevelManaged, resolveManagedSettingsPath().Path)
 }
 
-// findGitRoot finds the root of the git repository
-func findGitRoot() (string, error) {
-	cwd, err := os.Getwd()
+// resolveRepoSettingsPath resolves the repo settings file; Path is empty outside a git repository
+func resolveRepoSettingsPath(projectDir string) paths.Resolved {
+	if *repoFile != "" {
+		return paths.NewResolved(types.LevelRepo, *repoFile, paths.SourceFlag)
+	}
+
+	repoRoot, err := findGitRoot(projectDir)
+	if err != nil {
+		return paths.NewResolved(types.LevelRepo, "", paths.SourceNone)
+	}
+	return paths.NewResolved(types.LevelRepo, paths.RepoSettings(repoRoot), paths.SourceProject)
+}
+
+// resolveLocalSettingsPath resolves the local settings file; Path is empty outside a git repository
+func resolveLocalSettingsPath(projectDir string) paths.Resolved {
+	if *localFile != "" {
+		return paths.NewResolved(types.LevelLocal, *localFile, paths.SourceFlag)
+	}
+
+	repoRoot, err := findGitRoot(projectDir)
+	if err != nil {
+		return paths.NewResolved(types.LevelLocal, "", paths.SourceNone)
+	}
+	return paths.NewResolved(types.LevelLocal, paths.LocalSettings(repoRoot), paths.SourceProject)
+}
+
+// resolveManagedSettingsPath resolves the managed policy file
+func resolveManagedSettingsPath() paths.Resolved {
+	if *managedFile != "" {
+		return paths.NewResolved(types.LevelManaged, *managedFile, paths.SourceFlag)
+	}
+	return paths.NewResolved(types.LevelManaged, paths.ManagedSettings(), paths.SourcePlatform)
+}
+
+// resolveSettingsPaths resolves every settings file for projectDir in User, Repo, Local,
+// Managed order
+func resolveSettingsPaths(projectDir string) ([]paths.Resolved, error) {
+	user, err := resolveUserSettingsPath()
+	if err != nil {
+		return nil, err
+	}
+	return []paths.Resolved{
+		user,
+		resolveRepoSettingsPath(projectDir),
+		resolveLocalSettingsPath(projectDir),
+		resolveManagedSettingsPath(),
+	}, nil
+}
+
+// resolveProjectDir returns the absolute project directory, defaulting to the working directory
+func resolveProjectDir(dir string) (string, error) {
+	if dir == "" {
+		return os.Getwd()
+	}
+
+	// Paths typed into the project switcher are not expanded by a shell
+	if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, rest)
+	}
+
+	abs, err := filepath.Abs(dir)
 	if err != nil {
 		return "", err
 	}
 
-	dir := cwd
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", fmt.Errorf("project directory %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("project directory %s is not a directory", dir)
+	}
+	return abs, nil
+}
+
+// findProjectRoot returns the git root containing dir, or dir itself outside a repository
+func findProjectRoot(dir string) string {
+	if root, err := findGitRoot(dir); err == nil {
+		return root
+	}
+	return dir
+}
+
+// findGitRoot finds the root of the git repository containing startDir
+func findGitRoot(startDir string) (string, error) {
+	dir := startDir
 	for {
 		gitPath := filepath.Join(dir, ".git", "config")
 		if _, err := os.Stat(gitPath); err == nil {
@@ -149,20 +228,17 @@ func loadSettingsLevel(name, path string) (types.SettingsLevel, error) {
 		return level, fmt.Errorf("failed to read %s: %w", path, err)
 	}
 
-	// Parse JSON
-	var settings types.Settings
-	if err := json.Unmarshal(data, &settings); err != nil {
-		return level, fmt.Errorf("invalid JSON in %s: %w", path, err)
+	if err := settingsfile.Parse(&level, data); err != nil {
+		return level, err
 	}
 
-	level.Exists = true
-	level.Permissions = settings.Allow
-	if level.Permissions == nil {
-		level.Permissions = []string{}
+	// Load provenance for imported or preset rules, and rules soft-deleted by the editor
+	sidecar, err := metadata.Load(path)
+	if err != nil {
+		return level, err
 	}
-
-	// Sort permissions alphabetically
-	sort.Strings(level.Permissions)
+	level.Provenance = sidecar.Rules
+	level.Disabled = sidecar.Disabled
 
 	return level, nil
 }
@@ -174,35 +250,21 @@ func loadSettingsLevel(name, path string) (types.SettingsLevel, error) {
 func consolidatePermissions(user, repo, local types.SettingsLevel) []types.Permission {
 	permMap := make(map[string]types.Permission)
 
-	// Add all permissions from all levels
-	for _, perm := range user.Permissions {
-		permMap[perm] = types.Permission{
-			Name:          perm,
-			CurrentLevel:  types.LevelUser,
-			OriginalLevel: types.LevelUser,
-			Selected:      false,
-		}
-	}
-
-	for _, perm := range repo.Permissions {
-		if _, exists := permMap[perm]; !exists {
-			permMap[perm] = types.Permission{
-				Name:          perm,
-				CurrentLevel:  types.LevelRepo,
-				OriginalLevel: types.LevelRepo,
-				Selected:      false,
+	// Add all permissions from all levels; the first level listed wins
+	for _, level := range []types.SettingsLevel{user, repo, local} {
+		for _, perm := range level.Permissions {
+			if _, exists := permMap[perm]; exists {
+				continue
 			}
-		}
-	}
-
-	for _, perm := range local.Permissions {
-		if _, exists := permMap[perm]; !exists {
-			permMap[perm] = types.Permission{
+			permission := types.Permission{
 				Name:          perm,
-				CurrentLevel:  types.LevelLocal,
-				OriginalLevel: types.LevelLocal,
-				Selected:      false,
+				CurrentLevel:  level.Name,
+				OriginalLevel: level.Name,
+			}
+			if entry, ok := level.Provenance[perm]; ok {
+				permission.Provenance = &entry
 			}
+			permMap[perm] = permission
 		}
 	}
 
@@ -219,27 +281,29 @@ func consolidatePermissions(user, repo, local types.SettingsLevel) []types.Permi
 	return permissions
 }
 
-// autoResolveSameLevelDuplicates removes duplicate permissions within the same level
-func autoResolveSameLevelDuplicates(level *types.SettingsLevel) int {
+// autoResolveSameLevelDuplicates removes duplicate permissions within the same level and
+// returns the removed entries (one per extra occurrence)
+func autoResolveSameLevelDuplicates(level *types.SettingsLevel) []string {
 	seen := make(map[string]bool)
 	cleaned := []string{}
-	duplicatesRemoved := 0
+	removed := []string{}
 
 	for _, perm := range level.Permissions {
 		if !seen[perm] {
 			seen[perm] = true
 			cleaned = append(cleaned, perm)
 		} else {
-			duplicatesRemoved++
+			removed = append(removed, perm)
 		}
 	}
 
 	level.Permissions = cleaned
-	return duplicatesRemoved
+	return removed
 }
 
-// detectDuplicates finds permissions that exist in multiple levels
-func detectDuplicates(user, repo, local types.SettingsLevel) []types.Duplicate {
+// detectDuplicates finds permissions that exist in multiple levels, auto-assigning each the
+// first level in priority (most preferred first) that contains it
+func detectDuplicates(user, repo, local types.SettingsLevel, priority []string) []types.Duplicate {
 	permCount := make(map[string][]string)
 
 	// Count occurrences across levels
@@ -257,22 +321,13 @@ func detectDuplicates(user, repo, local types.SettingsLevel) []types.Duplicate {
 	var duplicates []types.Duplicate
 	for perm, levels := range permCount {
 		if len(levels) > 1 {
-			// Default to keeping highest priority level (User > Repo > Local)
-			keepLevel := types.LevelLocal
-			for _, level := range levels {
-				if level == types.LevelUser {
-					keepLevel = types.LevelUser
-					break
-				} else if level == types.LevelRepo && keepLevel != types.LevelUser {
-					keepLevel = types.LevelRepo
-				}
-			}
+			keepLevel := highestPriorityLevel(levels, priority)
 
 			duplicates = append(duplicates, types.Duplicate{
-				Name:      perm,
-				Levels:    levels,
-				KeepLevel: keepLevel,
-				Selected:  false,
+				Name:             perm,
+				Levels:           levels,
+				KeepLevel:        keepLevel,
+				DefaultKeepLevel: keepLevel,
 			})
 		}
 	}
@@ -284,3 +339,121 @@ func detectDuplicates(user, repo, local types.SettingsLevel) []types.Duplicate {
 
 	return duplicates
 }
+
+// detectContradictions finds rules that are allowed at one level and denied at another
+// (or the same) level
+func detectContradictions(user, repo, local types.SettingsLevel) []types.Contradiction {
+	allowed := make(map[string][]string)
+	denied := make(map[string][]string)
+	for _, level := range []types.SettingsLevel{user, repo, local} {
+		for _, perm := range level.Permissions {
+			allowed[perm] = append(allowed[perm], level.Name)
+		}
+		for _, perm := range level.Deny {
+			denied[perm] = append(denied[perm], level.Name)
+		}
+	}
+
+	var contradictions []types.Contradiction
+	for perm, allowLevels := range allowed {
+		if denyLevels, ok := denied[perm]; ok {
+			contradictions = append(contradictions, types.Contradiction{
+				Name:        perm,
+				AllowLevels: allowLevels,
+				DenyLevels:  denyLevels,
+			})
+		}
+	}
+
+	sort.Slice(contradictions, func(i, j int) bool {
+		return strings.ToLower(contradictions[i].Name) < strings.ToLower(contradictions[j].Name)
+	})
+
+	return contradictions
+}
+
+// highestPriorityLevel returns the first level in priority that appears in levels
+func highestPriorityLevel(levels, priority []string) string {
+	for _, candidate := range priority {
+		for _, level := range levels {
+			if level == candidate {
+				return level
+			}
+		}
+	}
+	return levels[0]
+}
+
+// loadDuplicatePriority returns the duplicate priority for non-interactive modes: --priority,
+// then the config file
+func loadDuplicatePriority() ([]string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if err := applyPriorityFlag(cfg); err != nil {
+		return nil, err
+	}
+	return cfg.DuplicatePriority(), nil
+}
+
+// applyPriorityFlag overrides the configured duplicate priority with --priority when given
+func applyPriorityFlag(cfg *config.Config) error {
+	if *priorityFlag == "" {
+		return nil
+	}
+	if err := cfg.OverridePriority(strings.Split(*priorityFlag, ",")); err != nil {
+		return fmt.Errorf("--priority %w", err)
+	}
+	return nil
+}
+
+// detectManagedFindings reports editable rules already covered by a managed allow rule
+// or contradicted by a managed deny rule
+func detectManagedFindings(
+	managed types.SettingsLevel,
+	levels ...types.SettingsLevel,
+) []types.ManagedFinding {
+	var findings []types.ManagedFinding
+	if !managed.Exists {
+		return findings
+	}
+
+	for _, level := range levels {
+		for _, perm := range level.Permissions {
+			if finding, ok := checkManagedRule(managed, level.Name, perm); ok {
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	return findings
+}
+
+// checkManagedRule compares one editable rule against the managed policy.
+// Contradictions are reported in preference to coverage.
+func checkManagedRule(
+	managed types.SettingsLevel,
+	levelName, perm string,
+) (types.ManagedFinding, bool) {
+	rule := rules.Parse(perm)
+
+	for _, deny := range managed.Deny {
+		denyRule := rules.Parse(deny)
+		if rules.Covers(denyRule, rule) || rules.Covers(rule, denyRule) {
+			return types.ManagedFinding{
+				Name: perm, Level: levelName, Kind: types.ManagedContradicted, ManagedRule: deny,
+			}, true
+		}
+	}
+
+	for _, allow := range managed.Permissions {
+		if rules.Covers(rules.Parse(allow), rule) {
+			return types.ManagedFinding{
+				Name: perm, Level: levelName, Kind: types.ManagedCovered, ManagedRule: allow,
+			}, true
+		}
+	}
+
+	return types.ManagedFinding{}, false
+}
@@ -0,0 +1,64 @@
+package settingsfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"claude-permissions/state"
+	"claude-permissions/types"
+)
+
+// BackupRoot returns the directory holding pre-save backups under the user config directory
+func BackupRoot() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, state.AppDirName, "backups"), nil
+}
+
+// Backup copies the existing settings files of levels into a new timestamped directory
+// under BackupRoot and returns it. Levels without a file are skipped; when none has one,
+// no directory is created and "" is returned.
+func Backup(levels []types.SettingsLevel, now time.Time) (string, error) {
+	var existing []types.SettingsLevel
+	for _, level := range levels {
+		if level.Path == "" {
+			continue
+		}
+		if _, err := os.Stat(level.Path); err == nil {
+			existing = append(existing, level)
+		}
+	}
+	if len(existing) == 0 {
+		return "", nil
+	}
+
+	root, err := BackupRoot()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate backup directory: %w", err)
+	}
+	dir := filepath.Join(root, now.Format("20060102-150405.000"))
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	for _, level := range existing {
+		data, err := os.ReadFile(level.Path) // #nosec G304 - path is a user-controlled config file
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s for backup: %w", level.Path, err)
+		}
+		if err := os.WriteFile(backupPath(dir, level), data, 0o600); err != nil {
+			return "", fmt.Errorf("failed to back up %s: %w", level.Path, err)
+		}
+	}
+	return dir, nil
+}
+
+// backupPath returns where Backup stores the level's file within dir
+func backupPath(dir string, level types.SettingsLevel) string {
+	return filepath.Join(dir, strings.ToLower(level.Name)+"-"+filepath.Base(level.Path))
+}
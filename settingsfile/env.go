@@ -0,0 +1,56 @@
+package settingsfile
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// keyEnv is the settings key holding environment variables
+const keyEnv = "env"
+
+// ParseEnv returns the variables of a settings env object, never nil so that a loaded level
+// rewrites its env on save. Values that are not strings are kept as their JSON text.
+func ParseEnv(env map[string]json.RawMessage) (map[string]string, error) {
+	parsed := make(map[string]string, len(env))
+	for name, raw := range env {
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			if !json.Valid(raw) {
+				return nil, fmt.Errorf("invalid env value for %s: %w", name, err)
+			}
+			value = string(raw)
+		}
+		parsed[name] = value
+	}
+	return parsed, nil
+}
+
+// setEnv stores env under the env key, which is only added when there is something in it.
+// Variables keep their order in the file, and values ParseEnv kept as JSON text are left
+// as written.
+func setEnv(settings map[string]json.RawMessage, env map[string]string) error {
+	if _, ok := settings[keyEnv]; !ok && len(env) == 0 {
+		return nil
+	}
+	var existing map[string]json.RawMessage
+	_ = json.Unmarshal(settings[keyEnv], &existing)
+
+	values := make(map[string]json.RawMessage, len(env))
+	for name, value := range env {
+		if raw, ok := existing[name]; ok && string(raw) == value {
+			values[name] = raw
+			continue
+		}
+		encoded, err := marshalValue(value)
+		if err != nil {
+			return err
+		}
+		values[name] = encoded
+	}
+	encoded, err := encodeObject(values, objectKeys(settings[keyEnv]))
+	if err != nil {
+		return err
+	}
+	settings[keyEnv] = encoded
+	return nil
+}
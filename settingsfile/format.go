@@ -0,0 +1,139 @@
+package settingsfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// defaultIndent indents files that are created or have no indented lines
+const defaultIndent = "  "
+
+// format is the layout of an existing settings file, reproduced when it is rewritten so that
+// a save only changes the keys the editor owns
+type format struct {
+	indent  string   // One level of indentation; "" keeps the file on one line
+	keys    []string // Top-level keys in file order
+	newline bool     // Whether the file ends with a newline
+}
+
+// detectFormat reads the indentation, top-level key order, and final newline of a settings
+// file; an empty file gets two-space indentation and a final newline
+func detectFormat(data []byte) format {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return format{indent: defaultIndent, newline: true}
+	}
+
+	f := format{keys: objectKeys(trimmed), newline: bytes.HasSuffix(data, []byte("\n"))}
+	if !bytes.Contains(trimmed, []byte("\n")) {
+		return f
+	}
+	f.indent = defaultIndent
+	for _, line := range strings.Split(string(trimmed), "\n")[1:] {
+		content := strings.TrimLeft(line, " \t")
+		if content != "" && len(content) < len(line) {
+			f.indent = strings.TrimRight(line[:len(line)-len(content)], "\r")
+			break
+		}
+	}
+	return f
+}
+
+// render encodes settings in the detected format, new keys following existing ones
+// in sorted order
+func (f format) render(settings map[string]json.RawMessage) ([]byte, error) {
+	out, err := encodeObject(settings, f.keys)
+	if err != nil {
+		return nil, err
+	}
+	if f.indent != "" {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, out, "", f.indent); err != nil {
+			return nil, err
+		}
+		out = indented.Bytes()
+	}
+	if f.newline {
+		out = append(out, '\n')
+	}
+	return out, nil
+}
+
+// objectKeys returns the keys of a JSON object in the order they are written, or nil when
+// data is not an object
+func objectKeys(data []byte) []string {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil
+	}
+	var keys []string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return keys
+		}
+		key, ok := token.(string)
+		if !ok {
+			return keys
+		}
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return keys
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// encodeObject encodes values as a compact JSON object, writing the keys of order first and
+// the rest in sorted order
+func encodeObject(values map[string]json.RawMessage, order []string) ([]byte, error) {
+	var keys []string
+	for _, key := range order {
+		if _, ok := values[key]; ok && !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	var added []string
+	for key := range values {
+		if !slices.Contains(keys, key) {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	keys = append(keys, added...)
+
+	var out bytes.Buffer
+	out.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			out.WriteByte(',')
+		}
+		encodedKey, err := marshalValue(key)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(encodedKey)
+		out.WriteByte(':')
+		if err := json.Compact(&out, values[key]); err != nil {
+			return nil, err
+		}
+	}
+	out.WriteByte('}')
+	return out.Bytes(), nil
+}
+
+// marshalValue encodes v like json.Marshal but leaves "<", ">", and "&" unescaped, as
+// people write them in rules and hook commands
+func marshalValue(v interface{}) ([]byte, error) {
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n")), nil
+}
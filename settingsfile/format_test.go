@@ -0,0 +1,110 @@
+package settingsfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"claude-permissions/types"
+)
+
+func TestWriteRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"two spaces", "{\n  \"allow\": [\n    \"Bash(ls)\",\n    \"Read\"\n  ]\n}\n"},
+		{"four spaces", "{\n    \"allow\": [\n        \"Read\"\n    ]\n}\n"},
+		{"tabs", "{\n\t\"allow\": [\n\t\t\"Read\"\n\t]\n}\n"},
+		{"single line", `{"allow":["Read"]}`},
+		{"no final newline", "{\n  \"allow\": [\n    \"Read\"\n  ]\n}"},
+		{
+			"key order",
+			"{\n  \"model\": \"opus\",\n  \"deny\": [\n    \"Bash(rm:*)\"\n  ],\n" +
+				"  \"allow\": [\n    \"Read\"\n  ],\n  \"cleanupPeriodDays\": 30\n}\n",
+		},
+		{
+			"other keys untouched",
+			"{\n  \"allow\": [],\n  \"statusLine\": {\n    \"type\": \"command\",\n" +
+				"    \"command\": \"echo a < b && c\"\n  }\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "settings.json")
+		if err := os.WriteFile(path, []byte(tt.input), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		level := types.SettingsLevel{Name: types.LevelRepo, Path: path}
+		if err := Parse(&level, []byte(tt.input)); err != nil {
+			t.Fatalf("%s: Parse: %v", tt.name, err)
+		}
+		if err := Write(level); err != nil {
+			t.Fatalf("%s: Write: %v", tt.name, err)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.input {
+			t.Errorf("%s: rewrote\n%s\nas\n%s", tt.name, tt.input, got)
+		}
+	}
+}
+
+func TestWriteKeepsFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		allow []string
+		deny  []string
+		want  string
+	}{
+		{
+			"added rule indented with tabs",
+			"{\n\t\"model\": \"opus\",\n\t\"allow\": [\n\t\t\"Read\"\n\t]\n}\n",
+			[]string{"Read", "Edit"},
+			nil,
+			"{\n\t\"model\": \"opus\",\n\t\"allow\": [\n\t\t\"Read\",\n\t\t\"Edit\"\n\t]\n}\n",
+		},
+		{
+			"new deny key after existing keys",
+			"{\"model\":\"opus\",\"allow\":[]}",
+			nil,
+			[]string{"Bash(rm:*)"},
+			`{"model":"opus","allow":[],"deny":["Bash(rm:*)"]}`,
+		},
+		{
+			"comments dropped, layout kept",
+			"{\n  // Team rules\n  \"allow\": [\"Read\",],\n}\n",
+			[]string{"Read"},
+			nil,
+			"{\n  \"allow\": [\n    \"Read\"\n  ]\n}\n",
+		},
+		{
+			"empty file",
+			"",
+			[]string{"Read"},
+			nil,
+			"{\n  \"allow\": [\n    \"Read\"\n  ]\n}\n",
+		},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "settings.json")
+		if err := os.WriteFile(path, []byte(tt.input), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		level := types.SettingsLevel{
+			Name: types.LevelRepo, Path: path, Permissions: tt.allow, Deny: tt.deny,
+		}
+		if err := Write(level); err != nil {
+			t.Fatalf("%s: Write: %v", tt.name, err)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s: wrote\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+	}
+}
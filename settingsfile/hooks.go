@@ -0,0 +1,91 @@
+package settingsfile
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"claude-permissions/types"
+)
+
+// keyHooks is the settings key holding hook lists by event
+const keyHooks = "hooks"
+
+// HookEvents are the events whose hooks the editor manages, in display order; hooks of
+// other events are preserved as-is
+var HookEvents = []string{types.HookPreToolUse, types.HookPostToolUse}
+
+// hookEntry is the part of a hook list entry the editor displays
+type hookEntry struct {
+	Matcher string `json:"matcher"`
+	Hooks   []struct {
+		Command string `json:"command"`
+	} `json:"hooks"`
+}
+
+// ParseHooks returns the PreToolUse and PostToolUse entries of a settings hooks object,
+// never nil so that a loaded level rewrites its hooks on save
+func ParseHooks(hooks map[string]json.RawMessage) ([]types.Hook, error) {
+	parsed := []types.Hook{}
+	for _, event := range HookEvents {
+		raw, ok := hooks[event]
+		if !ok {
+			continue
+		}
+		var entries []json.RawMessage
+		if err := json.Unmarshal(raw, &entries); err != nil {
+			return nil, fmt.Errorf("invalid %s hooks: %w", event, err)
+		}
+		for _, entry := range entries {
+			var decoded hookEntry
+			if err := json.Unmarshal(entry, &decoded); err != nil {
+				return nil, fmt.Errorf("invalid %s hook: %w", event, err)
+			}
+			hook := types.Hook{Event: event, Matcher: decoded.Matcher, Raw: entry}
+			for _, h := range decoded.Hooks {
+				hook.Commands = append(hook.Commands, h.Command)
+			}
+			parsed = append(parsed, hook)
+		}
+	}
+	return parsed, nil
+}
+
+// setHooks replaces the managed event lists of the settings hooks object with hooks,
+// dropping events that no longer have any
+func setHooks(settings map[string]json.RawMessage, hooks []types.Hook) error {
+	existing := make(map[string]json.RawMessage)
+	if raw, ok := settings[keyHooks]; ok {
+		if err := json.Unmarshal(raw, &existing); err != nil {
+			return fmt.Errorf("invalid hooks: %w", err)
+		}
+	}
+
+	for _, event := range HookEvents {
+		var entries []json.RawMessage
+		for _, hook := range hooks {
+			if hook.Event == event {
+				entries = append(entries, hook.Raw)
+			}
+		}
+		if len(entries) == 0 {
+			delete(existing, event)
+			continue
+		}
+		encoded, err := marshalValue(entries)
+		if err != nil {
+			return err
+		}
+		existing[event] = encoded
+	}
+
+	// Like deny, only keep a hooks key that was already there or has something in it
+	if _, ok := settings[keyHooks]; !ok && len(existing) == 0 {
+		return nil
+	}
+	encoded, err := encodeObject(existing, objectKeys(settings[keyHooks]))
+	if err != nil {
+		return err
+	}
+	settings[keyHooks] = encoded
+	return nil
+}
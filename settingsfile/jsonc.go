@@ -0,0 +1,96 @@
+package settingsfile
+
+import "bytes"
+
+// Standardize turns hand-edited JSON with comments ("//" and "/* */") and trailing commas
+// into plain JSON and reports whether anything was removed. Comments become spaces so that
+// parse errors keep pointing at the right line; strings are left alone.
+func Standardize(data []byte) ([]byte, bool) {
+	out := make([]byte, 0, len(data))
+	changed := false
+	inString, escaped := false, false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			end := bytes.IndexByte(data[i:], '\n')
+			if end < 0 {
+				end = len(data) - i
+			}
+			out = append(out, bytes.Repeat([]byte(" "), end)...)
+			i += end - 1
+			changed = true
+			continue
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				end = len(data) - i - 2
+			} else {
+				end += 2
+			}
+			out = append(out, blankComment(data[i:i+2+end])...)
+			i += 1 + end
+			changed = true
+			continue
+		}
+		out = append(out, c)
+	}
+
+	cleaned, removedCommas := removeTrailingCommas(out)
+	return cleaned, changed || removedCommas
+}
+
+// blankComment replaces a block comment with spaces, keeping its line breaks
+func blankComment(comment []byte) []byte {
+	blank := make([]byte, len(comment))
+	for i, c := range comment {
+		blank[i] = ' '
+		if c == '\n' || c == '\r' {
+			blank[i] = c
+		}
+	}
+	return blank
+}
+
+// removeTrailingCommas drops commas followed only by whitespace before "}" or "]", and
+// reports whether there were any
+func removeTrailingCommas(data []byte) ([]byte, bool) {
+	out := make([]byte, 0, len(data))
+	removed := false
+	inString, escaped := false, false
+	for i, c := range data {
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == ',':
+			rest := bytes.TrimLeft(data[i+1:], " \t\r\n")
+			if len(rest) > 0 && (rest[0] == '}' || rest[0] == ']') {
+				out = append(out, ' ')
+				removed = true
+				continue
+			}
+		}
+		out = append(out, c)
+	}
+	return out, removed
+}
@@ -0,0 +1,82 @@
+package settingsfile
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestStandardize(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		changed bool
+	}{
+		{"plain JSON", `{"allow": ["Read"]}`, `{"allow": ["Read"]}`, false},
+		{
+			"line comment",
+			"{\n  // Team rules\n  \"allow\": [\"Read\"]\n}",
+			`{"allow": ["Read"]}`,
+			true,
+		},
+		{"block comment", `{"allow": [/* ok */ "Read"]}`, `{"allow": ["Read"]}`, true},
+		{"trailing commas", `{"allow": ["Read", "Edit",],}`, `{"allow": ["Read", "Edit"]}`, true},
+		{
+			"comment after trailing comma",
+			"{\"allow\": [\"Read\", // last\n]}",
+			`{"allow": ["Read"]}`,
+			true,
+		},
+		{
+			"line comment marker in string",
+			`{"allow": ["WebFetch(domain:https://example.com)"]}`,
+			`{"allow": ["WebFetch(domain:https://example.com)"]}`,
+			false,
+		},
+		{
+			"block comment marker in string",
+			`{"allow": ["Read(/* literal */)"]}`,
+			`{"allow": ["Read(/* literal */)"]}`,
+			false,
+		},
+		{
+			"escaped quote before comment marker",
+			`{"env": {"GREETING": "say \"//hi\","}, // note` + "\n}",
+			`{"env": {"GREETING": "say \"//hi\","}}`,
+			true,
+		},
+		{"comma in string", `{"allow": ["Bash(echo a,]"]}`, `{"allow": ["Bash(echo a,]"]}`, false},
+	}
+	for _, tt := range tests {
+		got, changed := Standardize([]byte(tt.input))
+		if changed != tt.changed {
+			t.Errorf("%s: changed = %v, want %v", tt.name, changed, tt.changed)
+		}
+		var gotValue, wantValue interface{}
+		if err := json.Unmarshal(got, &gotValue); err != nil {
+			t.Errorf("%s: result %q is not JSON: %v", tt.name, got, err)
+			continue
+		}
+		if err := json.Unmarshal([]byte(tt.want), &wantValue); err != nil {
+			t.Fatalf("%s: want %q is not JSON: %v", tt.name, tt.want, err)
+		}
+		if !reflect.DeepEqual(gotValue, wantValue) {
+			t.Errorf("%s: Standardize(%q) = %q, want %q", tt.name, tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestStandardizeKeepsLines(t *testing.T) {
+	input := "{\n  /* two\n     lines */\n  \"allow\": [],\n}\n"
+	got, _ := Standardize([]byte(input))
+	if len(got) != len(input) {
+		t.Errorf("Standardize(%q) = %q, want the same length", input, got)
+	}
+	for i := range input {
+		if (input[i] == '\n') != (got[i] == '\n') {
+			t.Errorf("Standardize(%q) = %q, want line breaks kept in place", input, got)
+			break
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package settingsfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"claude-permissions/types"
+)
+
+// Settings keys holding the permission modes
+const (
+	keyDefaultMode   = "defaultMode"
+	keyDisableBypass = "disableBypassPermissionsMode"
+)
+
+// DefaultModes are the values defaultMode accepts
+var DefaultModes = []string{"default", "acceptEdits", "plan", "bypassPermissions"}
+
+// DisableBypass is the only value disableBypassPermissionsMode accepts
+const DisableBypass = "disable"
+
+// ParseModes returns the permission modes of a settings file, never nil so that a loaded
+// level rewrites them on save. Values that are not strings are kept as their JSON text and
+// reported by ValidateModes.
+func ParseModes(defaultMode, disableBypass json.RawMessage) *types.PermissionModes {
+	return &types.PermissionModes{
+		DefaultMode:   modeValue(defaultMode),
+		DisableBypass: modeValue(disableBypass),
+	}
+}
+
+// modeValue decodes a string setting, or returns the JSON text of any other value
+func modeValue(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return string(raw)
+	}
+	return value
+}
+
+// ValidateModes reports the first mode set to a value Claude Code does not accept, or nil
+func ValidateModes(modes types.PermissionModes) error {
+	if modes.DefaultMode != "" && !slices.Contains(DefaultModes, modes.DefaultMode) {
+		return fmt.Errorf("%s %q is not one of %v", keyDefaultMode, modes.DefaultMode, DefaultModes)
+	}
+	if modes.DisableBypass != "" && modes.DisableBypass != DisableBypass {
+		return fmt.Errorf("%s %q must be %q", keyDisableBypass, modes.DisableBypass,
+			DisableBypass)
+	}
+	return nil
+}
+
+// setModes stores the permission modes, removing those that are unset. A value kept as
+// JSON text by ParseModes is left as written.
+func setModes(settings map[string]json.RawMessage, modes types.PermissionModes) error {
+	for key, value := range map[string]string{
+		keyDefaultMode:   modes.DefaultMode,
+		keyDisableBypass: modes.DisableBypass,
+	} {
+		if value == "" {
+			delete(settings, key)
+			continue
+		}
+		if raw, ok := settings[key]; ok && string(raw) == value {
+			continue
+		}
+		encoded, err := marshalValue(value)
+		if err != nil {
+			return err
+		}
+		settings[key] = encoded
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package settingsfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"claude-permissions/types"
+)
+
+// ParseError reports a settings file whose contents could not be parsed
+type ParseError struct {
+	Path string
+	Err  error
+}
+
+// Error describes the file and what is wrong with it
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying decoding error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parse fills level's rules, hooks, env, directories, and modes from data, the contents of
+// its settings file, tolerating the comments and trailing commas of hand-edited files.
+// Rules are sorted alphabetically. Contents that cannot be parsed yield a *ParseError.
+func Parse(level *types.SettingsLevel, data []byte) error {
+	data, level.Normalized = Standardize(data)
+	var settings types.Settings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return &ParseError{Path: level.Path, Err: fmt.Errorf("invalid JSON: %w", err)}
+	}
+
+	level.Exists = true
+	level.Permissions = settings.Allow
+	if level.Permissions == nil {
+		level.Permissions = []string{}
+	}
+	level.Deny = settings.Deny
+	if level.Deny == nil {
+		level.Deny = []string{}
+	}
+	var err error
+	level.Hooks, err = ParseHooks(settings.Hooks)
+	if err != nil {
+		return &ParseError{Path: level.Path, Err: err}
+	}
+	level.Env, err = ParseEnv(settings.Env)
+	if err != nil {
+		return &ParseError{Path: level.Path, Err: err}
+	}
+	level.Directories = settings.AdditionalDirectories
+	if level.Directories == nil {
+		level.Directories = []string{}
+	}
+	level.Modes = ParseModes(settings.DefaultMode, settings.DisableBypassPermissionsMode)
+
+	sort.Strings(level.Permissions)
+	sort.Strings(level.Deny)
+	return nil
+}
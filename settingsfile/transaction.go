@@ -0,0 +1,190 @@
+package settingsfile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"claude-permissions/types"
+)
+
+// stagedFile is a settings file whose new contents wait in a temporary file next to it
+type stagedFile struct {
+	level   types.SettingsLevel
+	temp    string
+	existed bool // Whether the file existed before the save, so rollback restores or removes it
+}
+
+// CommitError reports a WriteAll that failed, listing what happened to each file
+type CommitError struct {
+	Err        error
+	Restored   []string // Files replaced and then restored from the backup
+	Unrestored []string // Files replaced that could not be restored and hold the new contents
+	NotUpdated []string // Files left untouched
+}
+
+// Error describes the failure followed by the state of every file
+func (e *CommitError) Error() string {
+	lines := []string{e.Err.Error()}
+	for _, group := range []struct {
+		label string
+		files []string
+	}{
+		{"Restored from backup", e.Restored},
+		{"Could not restore (new contents kept)", e.Unrestored},
+		{"Not updated", e.NotUpdated},
+	} {
+		if len(group.files) > 0 {
+			lines = append(lines, fmt.Sprintf("%s: %s", group.label,
+				strings.Join(group.files, ", ")))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the error that stopped the save
+func (e *CommitError) Unwrap() error {
+	return e.Err
+}
+
+// FileState is how far WriteAll has got with one settings file
+type FileState int
+
+// File states in the order a file goes through them
+const (
+	FilePending FileState = iota // Not started
+	FileWriting                  // Being written to its temporary file or renamed into place
+	FileDone                     // Replaced with the new contents
+	FileFailed                   // Left with, or restored to, its old contents
+)
+
+// Progress is told each time a file written by WriteAll changes state. It is called from the
+// goroutine running WriteAll.
+type Progress func(path string, state FileState)
+
+// WriteAll writes the settings files of levels as one transaction: every file is first
+// written to a temporary file beside it and synced, then the temporary files are renamed
+// over the originals in order. When a step fails, files already replaced are restored from
+// backupDir, the directory Backup returned for the same levels, or removed if they were
+// created by this save, and the returned *CommitError lists the state of every file.
+// progress, which may be nil, follows each file through the transaction.
+func WriteAll(levels []types.SettingsLevel, backupDir string, progress Progress) error {
+	report := func(paths []string, state FileState) {
+		if progress == nil {
+			return
+		}
+		for _, path := range paths {
+			progress(path, state)
+		}
+	}
+
+	var staged []stagedFile
+	discard := func() {
+		for _, file := range staged {
+			_ = os.Remove(file.temp)
+		}
+	}
+
+	for _, level := range levels {
+		report([]string{level.Path}, FileWriting)
+		file, err := stage(level)
+		if err != nil {
+			discard()
+			report(levelPaths(levels), FileFailed)
+			return &CommitError{Err: err, NotUpdated: levelPaths(levels)}
+		}
+		staged = append(staged, file)
+	}
+
+	for i, file := range staged {
+		if err := os.Rename(file.temp, file.level.Path); err != nil {
+			discard()
+			commitErr := &CommitError{
+				Err:        fmt.Errorf("failed to replace %s: %w", file.level.Path, err),
+				NotUpdated: levelPaths(levels[i:]),
+			}
+			for _, done := range staged[:i] {
+				if err := rollback(done, backupDir); err != nil {
+					commitErr.Unrestored = append(commitErr.Unrestored, done.level.Path)
+				} else {
+					commitErr.Restored = append(commitErr.Restored, done.level.Path)
+				}
+			}
+			report(commitErr.Restored, FileFailed)
+			report(commitErr.NotUpdated, FileFailed)
+			return commitErr
+		}
+		report([]string{file.level.Path}, FileDone)
+	}
+	return nil
+}
+
+// stage writes the new contents of a level's settings file to a synced temporary file in
+// the same directory, so the final rename cannot cross file systems
+func stage(level types.SettingsLevel) (stagedFile, error) {
+	if level.Path == "" {
+		return stagedFile{}, fmt.Errorf("%s level has no settings file", level.Name)
+	}
+	_, err := os.Stat(level.Path)
+	file := stagedFile{level: level, existed: err == nil}
+
+	out, perm, err := encode(level)
+	if err != nil {
+		return stagedFile{}, err
+	}
+	dir := filepath.Dir(level.Path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return stagedFile{}, fmt.Errorf("failed to create directory for %s: %w", level.Path, err)
+	}
+	temp, err := os.CreateTemp(dir, "."+filepath.Base(level.Path)+".tmp-*")
+	if err != nil {
+		return stagedFile{}, fmt.Errorf("failed to stage %s: %w", level.Path, err)
+	}
+	file.temp = temp.Name()
+
+	_, err = temp.Write(out)
+	if err == nil {
+		err = temp.Chmod(perm)
+	}
+	if err == nil {
+		err = temp.Sync()
+	}
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.temp)
+		return stagedFile{}, fmt.Errorf("failed to stage %s: %w", level.Path, err)
+	}
+	return file, nil
+}
+
+// rollback puts back the contents a replaced file had before the save
+func rollback(file stagedFile, backupDir string) error {
+	if !file.existed {
+		return os.Remove(file.level.Path)
+	}
+	if backupDir == "" {
+		return errors.New("no backup")
+	}
+	data, err := os.ReadFile(backupPath(backupDir, file.level))
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(file.level.Path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file.level.Path, data, info.Mode().Perm())
+}
+
+// levelPaths returns the settings file of each level
+func levelPaths(levels []types.SettingsLevel) []string {
+	files := make([]string, 0, len(levels))
+	for _, level := range levels {
+		files = append(files, level.Path)
+	}
+	return files
+}
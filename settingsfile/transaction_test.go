@@ -0,0 +1,144 @@
+package settingsfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"claude-permissions/types"
+)
+
+func TestWriteAllRollsBack(t *testing.T) {
+	tests := []struct {
+		name       string
+		backup     bool
+		restored   []string // Names of the levels restored from the backup or removed
+		unrestored []string
+	}{
+		{"restored from backup", true, []string{"user", "repo"}, nil},
+		{"no backup", false, []string{"repo"}, []string{"user"}},
+	}
+	for _, tt := range tests {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		dir := t.TempDir()
+		user := types.SettingsLevel{
+			Name: types.LevelUser, Path: filepath.Join(dir, "user.json"),
+			Permissions: []string{"Read", "Edit"},
+		}
+		repo := types.SettingsLevel{
+			Name: types.LevelRepo, Path: filepath.Join(dir, "repo.json"),
+			Permissions: []string{"Read"},
+		}
+		local := types.SettingsLevel{
+			Name: types.LevelLocal, Path: filepath.Join(dir, "local.json"),
+			Permissions: []string{"Read"},
+		}
+		const original = `{"allow":["Read"]}`
+		for _, path := range []string{user.Path, local.Path} {
+			if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		levels := []types.SettingsLevel{user, repo, local}
+
+		backupDir := ""
+		if tt.backup {
+			var err error
+			if backupDir, err = Backup(levels, time.Now()); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// Once the first file is replaced, turn the last one into a directory so that
+		// renaming over it fails
+		progress := func(path string, state FileState) {
+			if path == user.Path && state == FileDone {
+				if err := os.Remove(local.Path); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.MkdirAll(filepath.Join(local.Path, "blocker"), 0o750); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		err := WriteAll(levels, backupDir, progress)
+
+		var commitErr *CommitError
+		if !errors.As(err, &commitErr) {
+			t.Fatalf("%s: WriteAll error = %v, want a *CommitError", tt.name, err)
+		}
+		paths := map[string]string{"user": user.Path, "repo": repo.Path}
+		var restored, unrestored []string
+		for _, name := range tt.restored {
+			restored = append(restored, paths[name])
+		}
+		for _, name := range tt.unrestored {
+			unrestored = append(unrestored, paths[name])
+		}
+		if !slices.Equal(commitErr.Restored, restored) {
+			t.Errorf("%s: Restored = %v, want %v", tt.name, commitErr.Restored, restored)
+		}
+		if !slices.Equal(commitErr.Unrestored, unrestored) {
+			t.Errorf("%s: Unrestored = %v, want %v", tt.name, commitErr.Unrestored, unrestored)
+		}
+		if !slices.Equal(commitErr.NotUpdated, []string{local.Path}) {
+			t.Errorf("%s: NotUpdated = %v, want %v", tt.name, commitErr.NotUpdated,
+				[]string{local.Path})
+		}
+
+		// A restored file has its old contents; one the save created is gone
+		data, err := os.ReadFile(user.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.backup && string(data) != original {
+			t.Errorf("%s: user file = %s, want %s", tt.name, data, original)
+		}
+		if !tt.backup && string(data) == original {
+			t.Errorf("%s: user file was restored without a backup", tt.name)
+		}
+		if _, err := os.Stat(repo.Path); !os.IsNotExist(err) {
+			t.Errorf("%s: created repo file was not removed: %v", tt.name, err)
+		}
+
+		// No temporary files are left beside the settings files
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range entries {
+			if name := entry.Name(); name[0] == '.' {
+				t.Errorf("%s: temporary file %s left behind", tt.name, name)
+			}
+		}
+	}
+}
+
+func TestWriteAllStageFailure(t *testing.T) {
+	dir := t.TempDir()
+	good := types.SettingsLevel{
+		Name: types.LevelUser, Path: filepath.Join(dir, "user.json"), Permissions: []string{"Read"},
+	}
+	broken := types.SettingsLevel{Name: types.LevelRepo, Path: filepath.Join(dir, "repo.json")}
+	if err := os.WriteFile(broken.Path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var commitErr *CommitError
+	if err := WriteAll([]types.SettingsLevel{good, broken}, "", nil); !errors.As(err, &commitErr) {
+		t.Fatalf("WriteAll error = %v, want a *CommitError", err)
+	}
+	if want := []string{good.Path, broken.Path}; !slices.Equal(commitErr.NotUpdated, want) {
+		t.Errorf("NotUpdated = %v, want %v", commitErr.NotUpdated, want)
+	}
+	if len(commitErr.Restored) > 0 || len(commitErr.Unrestored) > 0 {
+		t.Errorf("Restored = %v, Unrestored = %v, want none", commitErr.Restored,
+			commitErr.Unrestored)
+	}
+	if _, err := os.Stat(good.Path); !os.IsNotExist(err) {
+		t.Errorf("user file was written although staging failed: %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package settingsfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"claude-permissions/paths"
+	"claude-permissions/types"
+)
+
+// Keys owned by the editor; every other key in a settings file is preserved as-is
+const (
+	keyAllow       = "allow"
+	keyDeny        = "deny"
+	keyDirectories = "additionalDirectories"
+)
+
+// Write replaces the allow and deny arrays, and the PreToolUse and PostToolUse hooks, env
+// block, additionalDirectories, and permission modes of levels that loaded them, in the
+// level's settings file, preserving all other keys along with the file's indentation and key
+// order.
+// The file and its parent directories are created when missing.
+func Write(level types.SettingsLevel) error {
+	if level.Path == "" {
+		return fmt.Errorf("%s level has no settings file", level.Name)
+	}
+	if err := paths.EnsureFile(level.Path); err != nil {
+		return &WriteError{Path: level.Path, Err: err}
+	}
+
+	out, perm, err := encode(level)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(level.Path, out, perm); err != nil {
+		return &WriteError{Path: level.Path, Err: err}
+	}
+	return nil
+}
+
+// WriteError reports a settings file that could not be written
+type WriteError struct {
+	Path string
+	Err  error
+}
+
+// Error describes the file that could not be written and why
+func (e *WriteError) Error() string {
+	return fmt.Sprintf("failed to write %s: %v", e.Path, e.Err)
+}
+
+// Unwrap returns the underlying file system error
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+// encode returns the new contents of the level's settings file and the permissions to write
+// it with; a missing file is encoded as if it were empty
+func encode(level types.SettingsLevel) ([]byte, os.FileMode, error) {
+	perm := os.FileMode(0o600)
+	data, err := os.ReadFile(level.Path) // #nosec G304 - path is a user-controlled config file
+	switch {
+	case err == nil:
+		info, err := os.Stat(level.Path)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to stat %s: %w", level.Path, err)
+		}
+		perm = info.Mode().Perm()
+	case !os.IsNotExist(err):
+		return nil, 0, fmt.Errorf("failed to read %s: %w", level.Path, err)
+	}
+
+	data, _ = Standardize(data)
+	settings := make(map[string]json.RawMessage)
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return nil, 0, &ParseError{Path: level.Path, Err: fmt.Errorf("invalid JSON: %w", err)}
+		}
+	}
+
+	if err := setArray(settings, keyAllow, level.Permissions); err != nil {
+		return nil, 0, err
+	}
+
+	// Only add a deny key when there is something to deny
+	if _, ok := settings[keyDeny]; ok || len(level.Deny) > 0 {
+		if err := setArray(settings, keyDeny, level.Deny); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if level.Hooks != nil {
+		if err := setHooks(settings, level.Hooks); err != nil {
+			return nil, 0, fmt.Errorf("failed to update hooks in %s: %w", level.Path, err)
+		}
+	}
+
+	if level.Env != nil {
+		if err := setEnv(settings, level.Env); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if level.Modes != nil {
+		if err := setModes(settings, *level.Modes); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// Like deny, only add the key when there is something in it
+	_, hasDirectories := settings[keyDirectories]
+	if level.Directories != nil && (hasDirectories || len(level.Directories) > 0) {
+		if err := setArray(settings, keyDirectories, level.Directories); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	out, err := detectFormat(data).render(settings)
+	if err != nil {
+		return nil, 0, err
+	}
+	return out, perm, nil
+}
+
+// setArray stores values under key, writing an empty array rather than null
+func setArray(settings map[string]json.RawMessage, key string, values []string) error {
+	if values == nil {
+		values = []string{}
+	}
+	encoded, err := marshalValue(values)
+	if err != nil {
+		return err
+	}
+	settings[key] = encoded
+	return nil
+}
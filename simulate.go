@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"claude-permissions/rules"
+	"claude-permissions/types"
+)
+
+// readInvocations reads one tool invocation per line, skipping blank lines and # comments
+func readInvocations(path string) ([]string, error) {
+	file, err := os.Open(path) // #nosec G304 - path is a user-supplied input file
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var invocations []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		invocations = append(invocations, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return invocations, nil
+}
+
+// simulateInvocations evaluates every invocation in path against the effective rules.
+// Levels are consulted in Claude Code's precedence order: Managed, Local, Repo, then User.
+func simulateInvocations(
+	path string,
+	managed, user, repo, local types.SettingsLevel,
+) ([]rules.Decision, error) {
+	invocations, err := readInvocations(path)
+	if err != nil {
+		return nil, err
+	}
+
+	engine := rules.NewEngine(managed, local, repo, user)
+	return engine.EvaluateAll(invocations), nil
+}
+
+// runSimulationCLI prints simulation results for path as JSON to stdout
+func runSimulationCLI(path string) error {
+	projectDir, err := resolveProjectDir(*projectFlag)
+	if err != nil {
+		return err
+	}
+
+	userLevel, repoLevel, localLevel, _, err := loadAllLevels(projectDir)
+	if err != nil {
+		return err
+	}
+
+	managedLevel, err := loadManagedLevel()
+	if err != nil {
+		return err
+	}
+
+	decisions, err := simulateInvocations(path, managedLevel, userLevel, repoLevel, localLevel)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(decisions)
+}
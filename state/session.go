@@ -0,0 +1,158 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// PendingMove is a rule moved to another level but not saved yet
+type PendingMove struct {
+	Rule string `json:"rule"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// PendingResolution is a duplicate whose keep level was changed but not saved yet
+type PendingResolution struct {
+	Rule      string `json:"rule"`
+	KeepLevel string `json:"keep_level"` // Empty when the default resolution was cleared
+}
+
+// PendingContradiction is an allow/deny contradiction whose winner was picked but not saved yet
+type PendingContradiction struct {
+	Rule   string `json:"rule"`
+	Winner string `json:"winner"`
+}
+
+// PendingConsolidation is an accepted consolidation suggestion that was not saved yet
+type PendingConsolidation struct {
+	Level       string   `json:"level"`
+	Replacement string   `json:"replacement"`
+	Rules       []string `json:"rules"`
+}
+
+// PendingStaleRemoval is a stale rule marked for removal that was not saved yet
+type PendingStaleRemoval struct {
+	Level  string `json:"level"`
+	Rule   string `json:"rule"`
+	Target string `json:"target"`
+}
+
+// Session is the unsaved work in one project, along with where the cursor was
+type Session struct {
+	SavedAt          time.Time              `json:"saved_at"`
+	Moves            []PendingMove          `json:"moves,omitempty"`
+	Resolutions      []PendingResolution    `json:"resolutions,omitempty"`
+	Contradictions   []PendingContradiction `json:"contradictions,omitempty"`
+	Consolidations   []PendingConsolidation `json:"consolidations,omitempty"`
+	StaleRemovals    []PendingStaleRemoval  `json:"stale_removals,omitempty"`
+	Screen           int                    `json:"screen"`
+	FocusedColumn    int                    `json:"focused_column"`
+	ColumnSelections [3]int                 `json:"column_selections"`
+}
+
+// SessionStore persists unsaved sessions per project root so they survive restarts
+type SessionStore struct {
+	path     string
+	mutex    sync.RWMutex
+	sessions map[string]Session
+}
+
+// DefaultSessionPath returns the session file location under the user config directory
+func DefaultSessionPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, AppDirName, "session.json"), nil
+}
+
+// LoadSessions opens the session store at the default location
+func LoadSessions() (*SessionStore, error) {
+	path, err := DefaultSessionPath()
+	if err != nil {
+		return nil, err
+	}
+	return LoadSessionsFrom(path)
+}
+
+// LoadSessionsFrom opens the session store at path; a missing file yields an empty store
+func LoadSessionsFrom(path string) (*SessionStore, error) {
+	store := &SessionStore{path: path, sessions: make(map[string]Session)}
+
+	data, err := os.ReadFile(path) // #nosec G304 - path is the editor's own session file
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &store.sessions); err != nil {
+		return nil, fmt.Errorf("invalid JSON in %s: %w", path, err)
+	}
+	if store.sessions == nil {
+		store.sessions = make(map[string]Session)
+	}
+
+	return store, nil
+}
+
+// Get returns the unsaved session stored for project
+func (s *SessionStore) Get(project string) (Session, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	session, ok := s.sessions[project]
+	return session, ok
+}
+
+// Put stores session for project, stamping SavedAt. The file is only rewritten when the
+// session differs from the stored one.
+func (s *SessionStore) Put(project string, session Session) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if existing, ok := s.sessions[project]; ok {
+		session.SavedAt = existing.SavedAt
+		if reflect.DeepEqual(existing, session) {
+			return nil
+		}
+	}
+	session.SavedAt = time.Now()
+	s.sessions[project] = session
+	return s.save()
+}
+
+// Delete removes the session stored for project, if any
+func (s *SessionStore) Delete(project string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.sessions[project]; !ok {
+		return nil
+	}
+	delete(s.sessions, project)
+	return s.save()
+}
+
+// save writes the store to disk; callers must hold the write lock
+func (s *SessionStore) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s.sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.path, err)
+	}
+	return nil
+}
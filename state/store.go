@@ -0,0 +1,125 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// AppDirName is the directory under the user config dir holding editor state
+const AppDirName = "claude-permissions"
+
+// maxRecentProjects caps how many project directories are remembered
+const maxRecentProjects = 10
+
+// Data is the persisted content of the state store
+type Data struct {
+	SeenHints      map[string]bool `json:"seen_hints"`
+	RecentProjects []string        `json:"recent_projects,omitempty"`
+}
+
+// Store persists small pieces of UI state (such as dismissed hints) across runs
+type Store struct {
+	path  string
+	mutex sync.RWMutex
+	data  Data
+}
+
+// DefaultPath returns the state file location under the user config directory
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, AppDirName, "state.json"), nil
+}
+
+// Load opens the state store at the default location
+func Load() (*Store, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFrom(path)
+}
+
+// LoadFrom opens the state store at path; a missing file yields an empty store
+func LoadFrom(path string) (*Store, error) {
+	store := &Store{
+		path: path,
+		data: Data{SeenHints: make(map[string]bool)},
+	}
+
+	data, err := os.ReadFile(path) // #nosec G304 - path is the editor's own state file
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, &store.data); err != nil {
+		return nil, fmt.Errorf("invalid JSON in %s: %w", path, err)
+	}
+	if store.data.SeenHints == nil {
+		store.data.SeenHints = make(map[string]bool)
+	}
+
+	return store, nil
+}
+
+// HintSeen reports whether the hint with the given id was dismissed before
+func (s *Store) HintSeen(id string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.data.SeenHints[id]
+}
+
+// MarkHintSeen records that the hint was dismissed and persists the store
+func (s *Store) MarkHintSeen(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.data.SeenHints[id] = true
+	return s.save()
+}
+
+// RecentProjects returns recently opened project directories, most recent first
+func (s *Store) RecentProjects() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return append([]string(nil), s.data.RecentProjects...)
+}
+
+// AddRecentProject moves dir to the front of the recent projects list and persists the store
+func (s *Store) AddRecentProject(dir string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	recent := []string{dir}
+	for _, existing := range s.data.RecentProjects {
+		if existing != dir && len(recent) < maxRecentProjects {
+			recent = append(recent, existing)
+		}
+	}
+	s.data.RecentProjects = recent
+	return s.save()
+}
+
+// save writes the store to disk; callers must hold the write lock
+func (s *Store) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(s.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.path, err)
+	}
+	return nil
+}
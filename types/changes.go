@@ -0,0 +1,45 @@
+package types
+
+// Kinds of pending changes, one per section of the confirm screen
+const (
+	ChangeMove          = "move"          // A rule moved between levels
+	ChangeDuplicate     = "duplicate"     // A duplicate removed from all but one level
+	ChangeContradiction = "contradiction" // The losing side of an allow/deny contradiction removed
+	ChangeConsolidation = "consolidation" // Narrow rules replaced by a broader one
+	ChangeStale         = "stale"         // A rule whose target no longer exists removed
+	ChangeEdit          = "edit"          // A rule rewritten in the inspector
+	ChangeDelete        = "delete"        // A rule deleted in the inspector
+	ChangePaste         = "paste"         // A rule pasted from the clipboard
+	ChangeAddition      = "addition"      // A rule added by a preset or import
+	ChangeSameLevel     = "same_level"    // Extra occurrences of a rule within a level removed
+	ChangeHook          = "hook"          // A hook moved or reordered
+	ChangeEnv           = "env"           // An environment variable set or removed
+	ChangeDirectory     = "directory"     // An additional directory added, moved, or removed
+	ChangeMode          = "mode"          // A permission mode changed
+	ChangeFormatting    = "formatting"    // Comments and trailing commas dropped from a file
+)
+
+// PendingChange is one change the next save makes, as the confirm screen lists it
+type PendingChange struct {
+	Kind   string   `json:"kind"`
+	Rule   string   `json:"rule,omitempty"`   // The rule, hook, variable, directory, or mode
+	Level  string   `json:"level,omitempty"`  // The level a change within one file is made in
+	From   []string `json:"from,omitempty"`   // Levels the entry is moved or removed from
+	To     string   `json:"to,omitempty"`     // Level the entry is moved to, added to, or kept in
+	Before string   `json:"before,omitempty"` // A changed mode's value as loaded
+	After  string   `json:"after,omitempty"`  // An edit's, consolidation's, or mode's new value
+	Rules  []string `json:"rules,omitempty"`  // The rules a consolidation replaces
+	Count  int      `json:"count,omitempty"`  // How often a same-level duplicate is listed
+	Detail string   `json:"detail,omitempty"` // Winner, missing target, hook action, file, source
+
+	// The line the confirm screen shows for the change, styled
+	Line string `json:"line"`
+}
+
+// ChangeSection is a titled group of pending changes of one kind. Moves are grouped by
+// destination level, so there is a move section per level.
+type ChangeSection struct {
+	Kind    string          `json:"kind"`
+	Title   string          `json:"title"` // Styled like Line
+	Changes []PendingChange `json:"changes"`
+}
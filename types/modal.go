@@ -2,7 +2,9 @@ package types
 
 // Modal represents a renderable modal dialog that can be overlaid on any screen
 type Modal interface {
-	// RenderModal renders the modal content for the given terminal dimensions
+	// RenderModal renders the modal to fit the current terminal dimensions. It runs for every
+	// frame, including after each tea.WindowSizeMsg, so modals lay out from these bounds
+	// rather than from a size fixed when they opened; bodies too tall for them scroll
 	RenderModal(width, height int) string
 
 	// HandleInput processes keyboard input for this modal
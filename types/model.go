@@ -1,10 +1,17 @@
 package types
 
 import (
+	"encoding/json"
 	"sync"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/config"
+	"claude-permissions/keymap"
+	"claude-permissions/notify"
+	"claude-permissions/state"
 
 	"github.com/charmbracelet/bubbles/v2/table"
-	"github.com/charmbracelet/bubbles/v2/timer"
 )
 
 // Constants for settings levels
@@ -12,17 +19,82 @@ const (
 	LevelUser  = "User"
 	LevelRepo  = "Repo"
 	LevelLocal = "Local"
+
+	// LevelManaged is the read-only enterprise policy level (managed-settings.json)
+	LevelManaged = "Managed"
 )
 
 // Constants for screen states
 const (
 	ScreenDuplicates = iota
 	ScreenOrganization
+	ScreenHistory
+	ScreenHooks
+	ScreenEnv
+	ScreenDirectories
+	ScreenPolicy
+)
+
+// Hook events listed on the Hooks screen
+const (
+	HookPreToolUse  = "PreToolUse"
+	HookPostToolUse = "PostToolUse"
 )
 
 // Settings represents the structure of Claude settings.json
 type Settings struct {
-	Allow []string `json:"allow"`
+	Allow []string                   `json:"allow"`
+	Deny  []string                   `json:"deny"`
+	Hooks map[string]json.RawMessage `json:"hooks"` // Hook lists keyed by event
+	Env   map[string]json.RawMessage `json:"env"`   // Environment variables for tool calls
+
+	// Directories outside the project that tools may access
+	AdditionalDirectories []string `json:"additionalDirectories"`
+
+	// Permission mode settings, kept raw so an invalid value is reported rather than
+	// failing the load
+	DefaultMode                  json.RawMessage `json:"defaultMode"`
+	DisableBypassPermissionsMode json.RawMessage `json:"disableBypassPermissionsMode"`
+}
+
+// PermissionModes are the permission mode settings of a level; "" leaves a mode unset
+type PermissionModes struct {
+	DefaultMode   string // Mode new sessions start in, e.g. "plan"
+	DisableBypass string // "disable" prevents the bypassPermissions mode
+}
+
+// Hook is one matcher entry of a level's PreToolUse or PostToolUse hooks
+type Hook struct {
+	Event    string
+	Matcher  string
+	Commands []string        // Commands the entry runs, for display
+	Raw      json.RawMessage // The entry as written, so unknown fields survive a move
+}
+
+// SessionStats records what saves during one editor session changed
+type SessionStats struct {
+	StartedAt          time.Time
+	RulesMoved         int
+	DuplicatesResolved int
+	FilesWritten       []string // Unique paths, in the order first written
+	BackupDirs         []string // One directory per save that backed up existing files
+}
+
+// RecordWrite adds path to FilesWritten unless it is already listed
+func (s *SessionStats) RecordWrite(path string) {
+	for _, written := range s.FilesWritten {
+		if written == path {
+			return
+		}
+	}
+	s.FilesWritten = append(s.FilesWritten, path)
+}
+
+// RepoCommit is a git commit of the repo settings file offered after a save
+type RepoCommit struct {
+	Root    string // Repository root
+	Path    string // Settings file to commit
+	Message string // Generated from the rule changes the save made
 }
 
 // SettingsLevel represents a level of settings (User, Repo, Local)
@@ -30,25 +102,162 @@ type SettingsLevel struct {
 	Name        string
 	Path        string
 	Permissions []string
+	Deny        []string
 	Exists      bool
+	Provenance  map[string]Provenance   // Sidecar provenance for bulk-added rules, keyed by rule
+	Disabled    map[string]DisabledRule // Soft-deleted rules awaiting purge, keyed by rule
+
+	// PreToolUse hooks followed by PostToolUse hooks, each in file order. Nil leaves the
+	// file's hooks untouched on write.
+	Hooks []Hook
+
+	// Environment variables of the env block; nil leaves the file's env untouched on write
+	Env map[string]string
+
+	// additionalDirectories entries in file order; nil leaves them untouched on write
+	Directories []string
+
+	// defaultMode and disableBypassPermissionsMode; nil leaves them untouched on write
+	Modes *PermissionModes
+
+	// The file has comments or trailing commas, which were ignored on load and are dropped
+	// when it is saved
+	Normalized bool
+
+	// Why the file could not be loaded; such a level is shown empty and is never written
+	LoadError string
+}
+
+// CurrentModes returns the level's permission modes, all unset when it has none
+func (l SettingsLevel) CurrentModes() PermissionModes {
+	if l.Modes == nil {
+		return PermissionModes{}
+	}
+	return *l.Modes
+}
+
+// DisabledRule records a rule that was removed from a level but kept in its sidecar for a
+// grace period
+type DisabledRule struct {
+	DisabledAt time.Time `json:"disabled_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Reason     string    `json:"reason"`
+}
+
+// Expired reports whether the grace period of the rule has passed at now
+func (d DisabledRule) Expired(now time.Time) bool {
+	return !now.Before(d.ExpiresAt)
+}
+
+// Provenance records where a rule came from when it was added by import, preset, or suggestion
+type Provenance struct {
+	Source  string    `json:"source"`
+	AddedAt time.Time `json:"added_at"`
 }
 
 // Permission represents a permission with its current level and pending operations
 type Permission struct {
 	Name          string
 	CurrentLevel  string
-	OriginalLevel string // Track the original level for moved permissions
-	Selected      bool
-	Edited        bool
-	NewName       string
+	OriginalLevel string      // Track the original level for moved permissions
+	Provenance    *Provenance // Non-nil for rules that were not written by hand
+	Added         bool        // Pasted, or added by a preset or import, and not saved yet
 }
 
 // Duplicate represents a duplicate permission across levels
 type Duplicate struct {
-	Name      string
-	Levels    []string
-	KeepLevel string
-	Selected  bool
+	Name             string
+	Levels           []string
+	KeepLevel        string
+	DefaultKeepLevel string // KeepLevel auto-assigned from the priority order at load
+}
+
+// Contradiction winners
+const (
+	WinnerAllow = "allow"
+	WinnerDeny  = "deny"
+)
+
+// Contradiction is a rule that is allowed at some levels and denied at others
+type Contradiction struct {
+	Name        string
+	AllowLevels []string
+	DenyLevels  []string
+	Winner      string // WinnerAllow, WinnerDeny, or "" while unresolved
+}
+
+// Consolidation is a broader allow rule suggested to replace several narrow rules of a level
+type Consolidation struct {
+	Level       string
+	Replacement string
+	Rules       []string // The narrow rules Replacement covers, removed when it is applied
+}
+
+// StaleRule is an allow rule whose script or path no longer exists in the working tree
+type StaleRule struct {
+	Level  string
+	Rule   string
+	Target string // The missing file or directory, as written in the rule
+}
+
+// RuleEdit is an allow rule changed in the rule inspector
+type RuleEdit struct {
+	Level       string
+	Rule        string
+	Replacement string // "" deletes the rule
+}
+
+// RuleAddition is a rule added by a preset or import, written by the next save. Allow rules
+// are also listed in Model.Permissions as added rules.
+type RuleAddition struct {
+	Level  string
+	Rule   string
+	Deny   bool
+	Source string // Provenance, e.g. "preset:go" or "import:team.json"
+	Action string // audit.ActionPreset or audit.ActionImport
+}
+
+// ManagedFinding kinds reported when a rule overlaps managed policy
+const (
+	ManagedCovered      = "covered"
+	ManagedContradicted = "contradicted"
+)
+
+// ManagedFinding reports a rule that is already covered or contradicted by managed policy
+type ManagedFinding struct {
+	Name        string
+	Level       string
+	Kind        string // ManagedCovered or ManagedContradicted
+	ManagedRule string
+}
+
+// PolicyRule is one lint rule of a team's policy file. Forbid flags allow rules that permit
+// everything one of its rules does, e.g. forbid = ["Bash(*)"] flags "Bash" and "Bash(*)";
+// Tool with SpecifierPrefix flags allow rules for Tool whose specifier does not start with
+// the prefix, e.g. tool = "WebFetch" with specifier_prefix = "domain:".
+type PolicyRule struct {
+	Name            string   `toml:"name"`
+	Description     string   `toml:"description"`
+	Severity        string   `toml:"severity"` // "error" (the default) or "warning"
+	Levels          []string `toml:"levels"`   // Levels checked; empty checks every level
+	Forbid          []string `toml:"forbid"`
+	Tool            string   `toml:"tool"`
+	SpecifierPrefix string   `toml:"specifier_prefix"`
+}
+
+// Policy is the lint rules loaded from a repository's policy file
+type Policy struct {
+	Path  string       `toml:"-"`
+	Rules []PolicyRule `toml:"rule"`
+}
+
+// PolicyViolation is an allow rule that breaks a policy rule
+type PolicyViolation struct {
+	Rule        string `json:"rule"`
+	Description string `json:"description,omitempty"`
+	Severity    string `json:"severity"`
+	Level       string `json:"level"`
+	Permission  string `json:"permission"`
 }
 
 // Model represents the application state
@@ -61,6 +270,29 @@ type Model struct {
 	RepoLevel  SettingsLevel // Changed from: repoLevel
 	LocalLevel SettingsLevel // Changed from: localLevel
 
+	// Project whose repo and local levels are loaded
+	ProjectRoot   string
+	ProjectLoader func(m *Model, dir string) error // Reloads all levels for another project
+
+	// GitCommit commits the repo settings file after every save without asking; otherwise
+	// PendingCommit holds the commit a save offered until it is taken or declined
+	GitCommit     bool
+	PendingCommit *RepoCommit
+
+	// CheckUpdate asks GitHub for a newer release at startup (--check-update);
+	// UpdateAvailable is its tag once one is found
+	CheckUpdate     bool
+	UpdateAvailable string
+
+	// Read-only enterprise policy level and the rules it overlaps
+	ManagedLevel    SettingsLevel
+	ManagedFindings []ManagedFinding
+
+	// Lint rules of the project's policy file, nil without one; the Policy screen checks
+	// the levels against them as they change
+	Policy       *Policy
+	PolicyOffset int // First visible Policy screen row
+
 	// UI state
 	Permissions []Permission // Changed from: permissions
 	Duplicates  []Duplicate  // Changed from: duplicates
@@ -70,9 +302,16 @@ type Model struct {
 	CurrentScreen int
 	CleanupStats  struct {
 		DuplicatesResolved int
-		SameLevelCleaned   int
 	}
 
+	// Extra occurrences of rules listed more than once within a level, keyed by level; they
+	// are collapsed in memory and removed from the files by the next save
+	SameLevelDuplicates map[string][]string
+
+	// Session tallies printed when the editor exits; unlike CleanupStats they survive
+	// project reloads
+	Session SessionStats
+
 	// Terminal dimensions (for pure lipgloss layout)
 	Width  int
 	Height int
@@ -81,9 +320,54 @@ type Model struct {
 	FocusedColumn    int    // 0=LOCAL, 1=REPO, 2=USER
 	SelectedItem     int    // Index within focused column
 	ColumnSelections [3]int // Selection index for each column
+	ColumnOffsets    [3]int // First visible row of each column
+	JumpPending      bool   // The next letter jumps to a rule in the focused column
+	PastePending     bool   // A clipboard read was requested to paste a rule
 
 	// UI components
-	DuplicatesTable table.Model // Changed from: duplicatesTable
+	DuplicatesTable  table.Model // Changed from: duplicatesTable
+	DuplicatesOffset int         // First visible duplicates table row
+
+	// Allow/deny contradictions listed below the duplicates table; the section takes the
+	// cursor when ContradictionsFocused is set or there are no duplicates
+	Contradictions        []Contradiction
+	ContradictionCursor   int
+	ContradictionsFocused bool
+
+	// Accepted consolidation suggestions, applied on save
+	Consolidations []Consolidation
+
+	// Stale rules marked for removal in the stale rule review, removed on save
+	StaleRemovals []StaleRule
+
+	// Allow rules edited or deleted in the rule inspector, applied on save
+	RuleEdits []RuleEdit
+
+	// Rules added by a preset or import, written by the next save
+	RuleAdditions []RuleAddition
+
+	// Hooks screen state; OriginalHooks holds each level's hooks as loaded, so reordered
+	// and moved hooks are pending changes until saved or reset
+	OriginalHooks map[string][]Hook
+	HookCursor    int
+
+	// Env screen state; OriginalEnv holds each level's variables as loaded and EnvKeepLevels
+	// the level chosen to keep each variable set at several levels, applied on save
+	OriginalEnv   map[string]map[string]string
+	EnvKeepLevels map[string]string
+	EnvCursor     int
+
+	// Directories screen state; OriginalDirectories holds each level's additionalDirectories
+	// as loaded, so added, removed, and moved entries are pending changes
+	OriginalDirectories map[string][]string
+	DirectoryCursor     int
+
+	// Permission modes of each level as loaded, so modes changed in the modes modal are
+	// pending changes
+	OriginalModes map[string]PermissionModes
+
+	// Levels ticked in the confirm modal for the next save; nil saves every changed level
+	SaveSelection []string
 
 	// Confirmation state
 	ConfirmMode bool   // Changed from: confirmMode
@@ -92,9 +376,29 @@ type Model struct {
 	// Modal state
 	ActiveModal Modal // Unified modal system
 
-	// Status message state
-	StatusMessage string      // Changed from: statusMessage
-	StatusTimer   timer.Model // Changed from: statusTimer
+	// User configuration (config.toml) and the key bindings derived from it
+	Config *config.Config
+	Keys   keymap.KeyMap
+
+	// Persistent UI state and one-time hints
+	StateStore *state.Store
+	ActiveHint string // Identifier of the hint currently displayed, if any
+
+	// Unsaved work persisted across restarts; SessionDirty is set once this run stored some
+	Sessions     *state.SessionStore
+	SessionDirty bool
+
+	// Queued status bar notifications
+	Notifications *notify.Queue
+
+	// Feedback for rejected input; BellPending is consumed after each key
+	BellPending bool
+	FlashUntil  time.Time // The status bar flashes until this time
+
+	// Applied changes; the History screen is only offered when AuditLog is set
+	AuditLog      *audit.Log
+	History       []audit.Entry // Newest first, loaded when the History screen is shown
+	HistoryOffset int           // First visible History row
 }
 
 // Note: tea.Model interface methods are now implemented by AppModel wrapper in main package
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/metadata"
+	"claude-permissions/types"
+)
+
+// stageRuleAdditions adds the rules of a preset or import to their levels as pending
+// additions. Allow rules are listed in their column like pasted rules.
+func stageRuleAdditions(m *types.Model, additions []types.RuleAddition) {
+	for _, addition := range additions {
+		level := getLevelByName(m, addition.Level)
+		if addition.Deny {
+			level.Deny = append(level.Deny, addition.Rule)
+		} else {
+			addPastedRule(m, addition.Level, addition.Rule)
+			metadata.Record(level, addition.Rule, addition.Source)
+			provenance := level.Provenance[addition.Rule]
+			m.Permissions[len(m.Permissions)-1].Provenance = &provenance
+		}
+		m.RuleAdditions = append(m.RuleAdditions, addition)
+	}
+}
+
+// ruleAdditionOf returns the preset or import that added the allow rule perm, if any
+func ruleAdditionOf(m *types.Model, perm types.Permission) (types.RuleAddition, bool) {
+	i := slices.IndexFunc(m.RuleAdditions, func(addition types.RuleAddition) bool {
+		return !addition.Deny && addition.Rule == perm.Name &&
+			addition.Level == perm.CurrentLevel
+	})
+	if i < 0 {
+		return types.RuleAddition{}, false
+	}
+	return m.RuleAdditions[i], true
+}
+
+// moveRuleAddition follows an added allow rule moved to another level before it was saved
+func moveRuleAddition(m *types.Model, rule, fromLevel, toLevel string) {
+	for i, addition := range m.RuleAdditions {
+		if !addition.Deny && addition.Rule == rule && addition.Level == fromLevel {
+			m.RuleAdditions[i].Level = toLevel
+			return
+		}
+	}
+}
+
+// resetRuleAdditions removes every rule added by a preset or import from its level. The
+// allow rules are removed with the pasted rules.
+func resetRuleAdditions(m *types.Model) {
+	for _, addition := range m.RuleAdditions {
+		level := getLevelByName(m, addition.Level)
+		if addition.Deny {
+			level.Deny = removePermission(level.Deny, addition.Rule)
+		} else {
+			delete(level.Provenance, addition.Rule)
+		}
+	}
+	m.RuleAdditions = nil
+}
+
+// ruleAdditionLevels returns the names of the levels with pending additions
+func ruleAdditionLevels(m *types.Model) []string {
+	var levels []string
+	for _, addition := range m.RuleAdditions {
+		if !slices.Contains(levels, addition.Level) {
+			levels = append(levels, addition.Level)
+		}
+	}
+	return levels
+}
+
+// ruleAdditionAuditEntries returns an audit entry for each rule added by a preset or import
+func ruleAdditionAuditEntries(m *types.Model, now time.Time) []audit.Entry {
+	var entries []audit.Entry
+	for _, addition := range m.RuleAdditions {
+		entries = append(entries, audit.Entry{
+			Timestamp: now,
+			Rule:      addition.Rule,
+			From:      addition.Source,
+			To:        addition.Level,
+			Action:    addition.Action,
+		})
+	}
+	return entries
+}
+
+// ruleAdditionChanges lists the rules added by a preset or import
+func ruleAdditionChanges(m *types.Model) []types.PendingChange {
+	var changes []types.PendingChange
+	for _, addition := range m.RuleAdditions {
+		list := ""
+		if addition.Deny {
+			list = " deny list"
+		}
+		changes = append(changes, types.PendingChange{
+			Kind:   types.ChangeAddition,
+			Rule:   addition.Rule,
+			To:     addition.Level,
+			Detail: addition.Source,
+			Line: fmt.Sprintf("• %s: Add to %s%s from %s", addition.Rule,
+				getLevelStyledText(addition.Level), list, addition.Source),
+		})
+	}
+	return changes
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"time"
+
+	"claude-permissions/config"
+	"claude-permissions/types"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// flashDuration is how long the status bar flashes for a rejected key or action
+const flashDuration = 150 * time.Millisecond
+
+// flashEndMsg redraws the status bar once a flash is over
+type flashEndMsg struct{}
+
+// flashStyle replaces the status bar colors during a flash
+var flashStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color(ColorBackground)).
+	Background(lipgloss.Color(ColorError)).
+	Padding(0, 1)
+
+// rejectInput marks the current key as rejected so the bell rings once it is handled
+func rejectInput(m *types.Model) *types.Model {
+	m.BellPending = true
+	return m
+}
+
+// ringBell returns the configured feedback for a rejected key, or nil when none is pending
+func ringBell(m *types.Model) tea.Cmd {
+	if !m.BellPending {
+		return nil
+	}
+	m.BellPending = false
+
+	mode := config.BellVisual
+	if m.Config != nil {
+		mode = m.Config.Display.Bell
+	}
+
+	switch mode {
+	case config.BellAudible:
+		return tea.Raw("\a")
+	case config.BellVisual:
+		m.FlashUntil = time.Now().Add(flashDuration)
+		return tea.Tick(flashDuration, func(time.Time) tea.Msg {
+			return flashEndMsg{}
+		})
+	}
+	return nil
+}
+
+// statusBarFlashing reports whether the status bar is currently flashing
+func statusBarFlashing(m *types.Model) bool {
+	return time.Now().Before(m.FlashUntil)
+}
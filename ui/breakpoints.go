@@ -0,0 +1,73 @@
+package ui
+
+// breakpoint is a range of terminal widths the layout adapts to
+type breakpoint int
+
+// Breakpoints from narrowest to widest
+const (
+	breakpointNarrow breakpoint = iota
+	breakpointNormal
+	breakpointWide
+)
+
+// Widths separating the breakpoints
+const (
+	// narrowMaxWidth is the widest a narrow layout gets
+	narrowMaxWidth = 59
+
+	// wideMinWidth is the narrowest a wide layout gets
+	wideMinWidth = 161
+)
+
+// breakpointFor returns the breakpoint of a width
+func breakpointFor(width int) breakpoint {
+	switch {
+	case width <= narrowMaxWidth:
+		return breakpointNarrow
+	case width >= wideMinWidth:
+		return breakpointWide
+	}
+	return breakpointNormal
+}
+
+// responsive is a constraint a component declares per breakpoint
+type responsive[T any] struct {
+	narrow, normal, wide T
+}
+
+// at returns the constraint for a breakpoint
+func (r responsive[T]) at(b breakpoint) T {
+	switch b {
+	case breakpointNarrow:
+		return r.narrow
+	case breakpointWide:
+		return r.wide
+	}
+	return r.normal
+}
+
+// Layout constraints of the content components
+var (
+	// stackedColumns stacks the organization columns top to bottom on narrow terminals,
+	// where side by side each would be too narrow to read
+	stackedColumns = responsive[bool]{narrow: true}
+
+	// duplicatesBesideColumns shows the Duplicates screen next to the organization columns
+	// on wide terminals
+	duplicatesBesideColumns = responsive[bool]{wide: true}
+
+	// compactPanels lays panels out as compact density does on narrow terminals, where
+	// stacked columns leave little height for rules
+	compactPanels = responsive[bool]{narrow: true}
+)
+
+// breakpoint returns the breakpoint of the component's width
+func (c *ContentComponent) breakpoint() breakpoint {
+	return breakpointFor(c.width)
+}
+
+// compact reports whether panels drop their blank lines, either for compact density or at
+// a breakpoint that asks for it
+func (c *ContentComponent) compact() bool {
+	return isCompactDensity(c.model) || compactPanels.at(c.breakpoint())
+}
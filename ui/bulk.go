@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// keyBulkResolve opens the modal resolving every remaining duplicate at once
+const keyBulkResolve = "!"
+
+// Bulk strategies besides keeping a fixed level
+const (
+	strategyHighest = "highest"
+	strategyLowest  = "lowest"
+)
+
+// bulkOption is one way to resolve the remaining duplicates
+type bulkOption struct {
+	key      string // Key applying the option directly
+	strategy string // A level name, strategyHighest, or strategyLowest
+	label    string
+}
+
+// bulkOptions lists the strategies in the order shown; "!" followed by a number key keeps
+// every duplicate in that level
+var bulkOptions = []bulkOption{
+	{"1", types.LevelLocal, "Keep in Local"},
+	{"2", types.LevelRepo, "Keep in Repo"},
+	{"3", types.LevelUser, "Keep in User"},
+	{"h", strategyHighest, "Keep the highest priority level"},
+	{"l", strategyLowest, "Keep the lowest priority level"},
+}
+
+// remainingDuplicates returns the indexes of the duplicates not resolved by hand, which
+// includes those resolved automatically from the priority order
+func remainingDuplicates(m *types.Model) []int {
+	var remaining []int
+	for i, dup := range m.Duplicates {
+		if dup.KeepLevel == "" || dup.KeepLevel == dup.DefaultKeepLevel {
+			remaining = append(remaining, i)
+		}
+	}
+	return remaining
+}
+
+// bulkKeepLevel returns the level strategy keeps dup in, or "" when it does not apply
+func bulkKeepLevel(m *types.Model, dup types.Duplicate, strategy string) string {
+	priority := m.Config.DuplicatePriority()
+	switch strategy {
+	case strategyHighest:
+		for _, level := range priority {
+			if slices.Contains(dup.Levels, level) {
+				return level
+			}
+		}
+	case strategyLowest:
+		for _, level := range slices.Backward(priority) {
+			if slices.Contains(dup.Levels, level) {
+				return level
+			}
+		}
+	default:
+		if slices.Contains(dup.Levels, strategy) {
+			return strategy
+		}
+	}
+	return ""
+}
+
+// BulkResolveModal implements types.Modal for resolving the remaining duplicates at once
+type BulkResolveModal struct {
+	model  *types.Model
+	cursor int
+	chosen string // Strategy picked with ENTER or its key
+}
+
+// NewBulkResolveModal creates the bulk resolution modal
+func NewBulkResolveModal(model *types.Model) *BulkResolveModal {
+	return &BulkResolveModal{model: model}
+}
+
+// Strategy returns the chosen strategy
+func (bm *BulkResolveModal) Strategy() string {
+	return bm.chosen
+}
+
+// openBulkResolve opens the bulk resolution modal when duplicates are left to resolve
+func openBulkResolve(m *types.Model) *types.Model {
+	if len(remainingDuplicates(m)) == 0 {
+		return rejectInput(m)
+	}
+	m.ActiveModal = NewBulkResolveModal(m)
+	return m
+}
+
+// RenderModal lists the strategies with how many duplicates each would resolve
+func (bm *BulkResolveModal) RenderModal(width, height int) string {
+	contentWidth := min(60, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	remaining := remainingDuplicates(bm.model)
+	lines := []string{
+		titleStyle.Render("Resolve Remaining Duplicates"),
+		"",
+		fmt.Sprintf("%d duplicate(s) not resolved by hand:", len(remaining)),
+		"",
+	}
+	for i, option := range bulkOptions {
+		count := 0
+		for _, index := range remaining {
+			if bulkKeepLevel(bm.model, bm.model.Duplicates[index], option.strategy) != "" {
+				count++
+			}
+		}
+		// The highlight replaces the key's accent color, which would reset its background
+		format := "%s  %-32s %d"
+		if i == bm.cursor {
+			lines = append(lines, SelectedItemStyle.Render("> "+fmt.Sprintf(format,
+				displayKey(option.key), option.label, count)))
+			continue
+		}
+		lines = append(lines, "  "+fmt.Sprintf(format,
+			AccentStyle.Render(displayKey(option.key)), option.label, count))
+	}
+
+	instructions := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(contentWidth-4).
+		Padding(1, 0, 0, 0).
+		Render(joinFooterActions([]string{
+			formatFooterAction("ENTER", "Apply"),
+			formatFooterAction("ESC", "Cancel"),
+		}))
+	lines = append(lines, instructions)
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// HandleInput moves between strategies; ENTER or a strategy's key applies it
+func (bm *BulkResolveModal) HandleInput(key string) (handled bool, result interface{}) {
+	switch key {
+	case keyUp, "k":
+		bm.cursor = max(bm.cursor-1, 0)
+	case keyDown, "j":
+		bm.cursor = min(bm.cursor+1, len(bulkOptions)-1)
+	case keyEnter:
+		bm.chosen = bulkOptions[bm.cursor].strategy
+		return true, "bulk_resolve"
+	case keyEscapeLong, keyEscape:
+		return true, "cancel"
+	default:
+		for _, option := range bulkOptions {
+			if strings.EqualFold(key, option.key) {
+				bm.chosen = option.strategy
+				return true, "bulk_resolve"
+			}
+		}
+	}
+	return false, nil
+}
+
+// applyBulkResolution keeps each remaining duplicate where the chosen strategy says,
+// leaving duplicates it does not apply to as they are
+func applyBulkResolution(m *types.Model, bm *BulkResolveModal) *types.Model {
+	m.ActiveModal = nil
+	resolved := 0
+	for _, index := range remainingDuplicates(m) {
+		if level := bulkKeepLevel(m, m.Duplicates[index], bm.Strategy()); level != "" {
+			m.Duplicates[index].KeepLevel = level
+			resolved++
+		}
+	}
+	updateDuplicatesTableData(m)
+	m.Notifications.Info("Resolved %d duplicate(s)", resolved)
+	return m
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+
+	"claude-permissions/debug"
+	"claude-permissions/paths"
+	"claude-permissions/types"
+)
+
+// Let the debug server's /changes endpoint list the pending changes as the confirm screen does
+func init() {
+	debug.RegisterChangesProvider(pendingChangeSet)
+}
+
+// pendingChangeSections lists the pending changes in the order the confirm screen shows them
+func pendingChangeSections(m *types.Model) []types.ChangeSection {
+	sections := permissionMoveSections(m)
+	add := func(kind, title string, changes []types.PendingChange) {
+		if len(changes) > 0 {
+			sections = append(sections,
+				types.ChangeSection{Kind: kind, Title: title, Changes: changes})
+		}
+	}
+
+	add(types.ChangeDuplicate, "Duplicate Resolutions", duplicateResolutionChanges(m))
+	add(types.ChangeContradiction, "Contradiction Resolutions", contradictionResolutionChanges(m))
+	add(types.ChangeConsolidation, "Consolidations", consolidationChanges(m))
+	add(types.ChangeStale, "Stale Rule Removals", staleRemovalChanges(m))
+
+	// Rules edited or deleted in the inspector, pasted from the clipboard, or added by a
+	// preset or import
+	add(types.ChangeEdit, "Rule Edits", ruleEditChanges(m))
+	add(types.ChangePaste, "Pasted Rules", pastedRuleChanges(m))
+	add(types.ChangeAddition, "Preset and Import Rules", ruleAdditionChanges(m))
+
+	add(types.ChangeSameLevel, "Same-Level Duplicates", sameLevelChanges(m))
+	add(types.ChangeHook, "Hook Changes", hookChanges(m))
+	add(types.ChangeEnv, "Environment Changes", envChanges(m))
+	add(types.ChangeDirectory, "Additional Directory Changes", directoryChanges(m))
+	add(types.ChangeMode, "Permission Mode Changes", modeChanges(m))
+
+	// Warn that hand-edited files lose their comments and trailing commas
+	add(types.ChangeFormatting, "Formatting", formattingChanges(m))
+
+	return sections
+}
+
+// buildPendingChangesList builds the lines of the pending changes for display, with the
+// permission moves grouped by destination level
+func buildPendingChangesList(m *types.Model) []string {
+	var changeLines []string
+	for _, section := range pendingChangeSections(m) {
+		changeLines = append(changeLines, section.Title+":")
+		for _, change := range section.Changes {
+			changeLines = append(changeLines, change.Line)
+		}
+		if section.Kind == types.ChangeMove {
+			changeLines = append(changeLines, "") // Empty line after each level's moves
+		}
+	}
+	return changeLines
+}
+
+// formattingChanges lists the changed files that lose their comments and trailing commas
+func formattingChanges(m *types.Model) []types.PendingChange {
+	var changes []types.PendingChange
+	for _, level := range changedLevels(m) {
+		if level.Normalized {
+			changes = append(changes, types.PendingChange{
+				Kind:   types.ChangeFormatting,
+				Level:  level.Name,
+				Detail: level.Path,
+				Line: fmt.Sprintf("• %s: Comments and trailing commas removed",
+					paths.Abbreviate(level.Path)),
+			})
+		}
+	}
+	return changes
+}
+
+// levelList returns level as a list, empty when level is
+func levelList(level string) []string {
+	if level == "" {
+		return nil
+	}
+	return []string{level}
+}
+
+// pendingChangeSet lists the pending changes, files, and risks as the confirm screen shows
+// them, using the open screen's file selection or else the one it opens with
+func pendingChangeSet(m *types.Model) debug.ChangeSet {
+	modal, ok := m.ActiveModal.(*ConfirmChangesModal)
+	if !ok {
+		modal = NewConfirmChangesModal(m)
+	}
+
+	set := debug.ChangeSet{
+		Sections: pendingChangeSections(m),
+		Risks:    riskyChanges(m, modal.selectedLevels()),
+	}
+	for _, level := range changedLevels(m) {
+		set.Files = append(set.Files, debug.ChangedFile{
+			Level:    level.Name,
+			Path:     level.Path,
+			Selected: modal.selected[level.Name],
+		})
+	}
+	return set
+}
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/clipboard"
+	"claude-permissions/logging"
+	"claude-permissions/types"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// Keys copying the selected rule and pasting a rule into the focused column
+const (
+	keyCopy  = "y"
+	keyPaste = "P"
+)
+
+// clipboardReadMsg carries the clipboard text read with the operating system's tools
+type clipboardReadMsg struct {
+	text string
+}
+
+// copyRule copies rule with OSC52, which reaches the user's terminal over SSH, and locally
+// also with the operating system's clipboard tools for terminals without OSC52
+func copyRule(m *types.Model, rule string) tea.Cmd {
+	m.Notifications.Info("Copied %s", rule)
+	if clipboard.Remote() {
+		return tea.SetClipboard(rule)
+	}
+	log := logFor(m, logging.ComponentClipboard)
+	return tea.Batch(tea.SetClipboard(rule), func() tea.Msg {
+		if err := clipboard.Write(rule); err != nil {
+			log.Debug("clipboard_write_failed", logging.Err(err))
+		}
+		return nil
+	})
+}
+
+// handleCopyKey copies the rule selected on the Duplicates or Organization screen
+func handleCopyKey(m *types.Model) (*types.Model, tea.Cmd) {
+	rule := selectedRuleName(m)
+	if rule == "" {
+		return rejectInput(m), nil
+	}
+	return m, copyRule(m, rule)
+}
+
+// handlePasteKey reads the clipboard to add its rule to the focused column. Locally the
+// operating system's tools are tried first; over SSH, or without them, the terminal is asked
+// with OSC52 and answers with a tea.ClipboardMsg.
+func handlePasteKey(m *types.Model) (*types.Model, tea.Cmd) {
+	if m.CurrentScreen != types.ScreenOrganization || hasUnresolvedDuplicates(m) {
+		return rejectInput(m), nil
+	}
+	m.PastePending = true
+	return m, func() tea.Msg {
+		if !clipboard.Remote() {
+			if text, err := clipboard.Read(); err == nil {
+				return clipboardReadMsg{text: text}
+			}
+		}
+		return tea.ReadClipboard()
+	}
+}
+
+// handlePastedText adds the pasted rule to the focused column as a pending rule. A rule
+// copied from a settings file may keep its quotes and trailing comma.
+func handlePastedText(m *types.Model, text string) *types.Model {
+	if !m.PastePending {
+		return m
+	}
+	m.PastePending = false
+
+	rule := strings.Trim(strings.TrimSpace(text), `"',`)
+	if strings.ContainsAny(rule, "\r\n") {
+		m.Notifications.Warn("The clipboard holds several lines; copy a single rule")
+		return rejectInput(m)
+	}
+	if err := validateRule(rule); err != nil {
+		m.Notifications.Warn("Cannot paste: %v", err)
+		return rejectInput(m)
+	}
+	_, levelName := getCurrentColumnInfo(m)
+	level := getLevelByName(m, levelName)
+	if level.LoadError != "" {
+		m.Notifications.Warn("%s settings could not be loaded and are read-only", level.Name)
+		return rejectInput(m)
+	}
+	for _, name := range editableLevels {
+		if slices.Contains(getLevelByName(m, name).Permissions, rule) {
+			m.Notifications.Warn("%s is already in %s", rule, name)
+			return rejectInput(m)
+		}
+	}
+
+	addPastedRule(m, levelName, rule)
+	m.ColumnSelections[m.FocusedColumn] = len(getColumnPermissions(m)) - 1
+	m.Notifications.Info("Pasted %s into %s", rule, levelName)
+	return m
+}
+
+// addPastedRule appends rule to the level as a pending addition
+func addPastedRule(m *types.Model, levelName, rule string) {
+	level := getLevelByName(m, levelName)
+	level.Permissions = append(level.Permissions, rule)
+	m.Permissions = append(m.Permissions, types.Permission{
+		Name:          rule,
+		CurrentLevel:  levelName,
+		OriginalLevel: levelName,
+		Added:         true,
+	})
+}
+
+// pastedRules returns the rules pasted since the last save
+func pastedRules(m *types.Model) []types.Permission {
+	var pasted []types.Permission
+	for _, perm := range m.Permissions {
+		if perm.Added {
+			pasted = append(pasted, perm)
+		}
+	}
+	return pasted
+}
+
+// resetPastedRules removes every pasted rule from its level
+func resetPastedRules(m *types.Model) {
+	for _, perm := range pastedRules(m) {
+		level := getLevelByName(m, perm.CurrentLevel)
+		level.Permissions = removePermission(level.Permissions, perm.Name)
+	}
+	m.Permissions = slices.DeleteFunc(m.Permissions, func(perm types.Permission) bool {
+		return perm.Added
+	})
+}
+
+// pasteAuditEntries returns an audit entry for each pasted rule; rules added by a preset or
+// import have their own
+func pasteAuditEntries(m *types.Model, now time.Time) []audit.Entry {
+	var entries []audit.Entry
+	for _, perm := range pastedRules(m) {
+		if _, ok := ruleAdditionOf(m, perm); ok {
+			continue
+		}
+		entries = append(entries, audit.Entry{
+			Timestamp: now,
+			Rule:      perm.Name,
+			To:        perm.CurrentLevel,
+			Action:    audit.ActionAddRule,
+		})
+	}
+	return entries
+}
+
+// pastedRuleChanges lists the rules pasted from the clipboard, leaving out those added by a
+// preset or import
+func pastedRuleChanges(m *types.Model) []types.PendingChange {
+	var changes []types.PendingChange
+	for _, perm := range pastedRules(m) {
+		if _, ok := ruleAdditionOf(m, perm); ok {
+			continue
+		}
+		changes = append(changes, types.PendingChange{
+			Kind: types.ChangePaste,
+			Rule: perm.Name,
+			To:   perm.CurrentLevel,
+			Line: fmt.Sprintf("• %s: Add to %s",
+				perm.Name, getLevelStyledText(perm.CurrentLevel)),
+		})
+	}
+	return changes
+}
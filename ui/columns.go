@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// balanceColumnWidths splits total among the columns in proportion to the width their content
+// needs. Each column stays between half and twice an equal share so that none disappears.
+func balanceColumnWidths(total int, needs []int) []int {
+	count := len(needs)
+	floor := total / (2 * count)
+	ceiling := max(2*total/count, (total+count-1)/count)
+
+	sum := 0
+	for _, need := range needs {
+		sum += max(need, 1)
+	}
+	widths := make([]int, count)
+	used := 0
+	for i, need := range needs {
+		widths[i] = min(max(total*max(need, 1)/sum, floor), ceiling)
+		used += widths[i]
+	}
+
+	// Hand out or take back the rounding and clamping difference one cell at a time
+	for i := 0; used != total; i = (i + 1) % count {
+		switch {
+		case used < total && widths[i] < ceiling:
+			widths[i]++
+			used++
+		case used > total && widths[i] > floor:
+			widths[i]--
+			used--
+		}
+	}
+	return widths
+}
+
+// columnNeed returns the width a column needs to show its header and rows uncut
+func (c *ContentComponent) columnNeed(header string, rows []string) int {
+	need := lipgloss.Width(header)
+	for _, row := range rows {
+		need = max(need, lipgloss.Width(row))
+	}
+	_, horizontal := c.panelPadding()
+	return need + NormalBorderStyle.GetHorizontalBorderSize() + 2*horizontal
+}
+
+// permissionColumnNeed returns the width a permission column needs
+func (c *ContentComponent) permissionColumnNeed(level string) int {
+	perms := c.getColumnPermissionStructs(level)
+	rows := make([]string, 0, len(perms))
+	for _, perm := range perms {
+		rows = append(rows, c.renderPermissionItem(perm, false, 0))
+	}
+	return c.columnNeed(c.renderColumnHeader(level, false), rows)
+}
+
+// managedColumnNeed returns the width the managed policy column needs
+func (c *ContentComponent) managedColumnNeed() int {
+	managed := c.model.ManagedLevel
+	rows := make([]string, 0, len(managed.Permissions)+len(managed.Deny))
+	for _, perm := range managed.Permissions {
+		rows = append(rows, "  "+perm)
+	}
+	for _, perm := range managed.Deny {
+		rows = append(rows, "  ✗ "+perm)
+	}
+	header := fmt.Sprintf("Managed (%d) read-only", len(rows))
+	return c.columnNeed(header, rows)
+}
+
+// columnRowWidth returns the width left for rows inside a column of the given width
+func (c *ContentComponent) columnRowWidth(width int) int {
+	_, horizontal := c.panelPadding()
+	return width - NormalBorderStyle.GetHorizontalBorderSize() - 2*horizontal
+}
+
+// truncateText shortens s to width cells, ending it with an ellipsis when anything is cut
+func truncateText(s string, width int) string {
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		w := lipgloss.Width(string(r))
+		if used+w > width-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return b.String() + "…"
+}
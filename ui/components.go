@@ -2,13 +2,19 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"claude-permissions/config"
 	"claude-permissions/types"
 
+	"github.com/charmbracelet/bubbles/v2/table"
 	"github.com/charmbracelet/lipgloss/v2"
 )
 
+// provenanceBadgeStyle marks rules that were not written by hand
+var provenanceBadgeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorCount))
+
 // Level display constants to avoid goconst warnings
 const (
 	levelDisplayLocal = "Local"
@@ -79,6 +85,11 @@ type ContentComponent struct {
 	width  int
 	height int
 	model  *types.Model
+
+	// pane is set when the component renders one side of the wide layout, and inactive
+	// when that side does not have the keyboard
+	pane     bool
+	inactive bool
 }
 
 // Layout constants for consistent width calculations across all screens
@@ -93,6 +104,10 @@ const (
 	// Value determined through testing to provide optimal balance between
 	// maximizing usable space and preventing terminal overflow.
 	ContentWidthBuffer = 0
+
+	// minDuplicateRows keeps a few duplicates in view however much the sections below the
+	// table take
+	minDuplicateRows = 3
 )
 
 // NewContentComponent creates a new content component
@@ -112,14 +127,53 @@ func (c *ContentComponent) getConsistentContentWidth() int {
 
 // View renders the appropriate content based on current screen
 func (c *ContentComponent) View() string {
-	switch c.model.CurrentScreen {
-	case types.ScreenDuplicates:
-		return c.renderDuplicatesContent()
-	case types.ScreenOrganization:
-		return c.renderOrganizationContent()
-	default:
-		return c.renderDuplicatesContent()
+	if wideLayout(c.model, c.width) {
+		return c.renderWideLayout()
+	}
+	return currentScreen(c.model).View(c)
+}
+
+// wideLayout reports whether a terminal width shows the Duplicates and Organization screens
+// side by side: it must be at the wide breakpoint, one of the two screens must be current,
+// and the Duplicates screen must have something to resolve
+func wideLayout(m *types.Model, width int) bool {
+	if !duplicatesBesideColumns.at(breakpointFor(width)) ||
+		(m.CurrentScreen != types.ScreenDuplicates && m.CurrentScreen != types.ScreenOrganization) {
+		return false
 	}
+	return len(m.Duplicates) > 0 || len(m.Contradictions) > 0 || len(m.SameLevelDuplicates) > 0
+}
+
+// wideLayoutPanes returns the widths of the Duplicates pane on the left and the Organization
+// pane on the right
+func wideLayoutPanes(width int) (int, int) {
+	return width / 2, width - width/2
+}
+
+// paneComponent returns the component rendering the given screen at the current window size,
+// which is one pane of the wide layout when it is shown
+func paneComponent(m *types.Model, screen, height int) *ContentComponent {
+	if !wideLayout(m, m.Width) {
+		return NewContentComponent(m.Width, height, m)
+	}
+	duplicatesWidth, organizationWidth := wideLayoutPanes(m.Width)
+	width := organizationWidth
+	if screen == types.ScreenDuplicates {
+		width = duplicatesWidth
+	}
+	pane := NewContentComponent(width, height, m)
+	pane.pane = true
+	pane.inactive = m.CurrentScreen != screen
+	return pane
+}
+
+// renderWideLayout renders the Duplicates screen next to the Organization columns, so the
+// effect of resolving a duplicate shows in the columns without switching screens
+func (c *ContentComponent) renderWideLayout() string {
+	duplicates := paneComponent(c.model, types.ScreenDuplicates, c.height)
+	organization := paneComponent(c.model, types.ScreenOrganization, c.height)
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		duplicates.renderDuplicatesContent(), organization.renderOrganizationContent())
 }
 
 // renderDuplicatesContent renders the duplicates screen content
@@ -134,24 +188,124 @@ func (c *ContentComponent) renderDuplicatesContent() string {
 		contentWidth = 20
 	}
 
-	if len(c.model.Duplicates) == 0 {
+	if len(c.model.Duplicates) == 0 && len(c.model.Contradictions) == 0 &&
+		len(c.model.SameLevelDuplicates) == 0 {
 		emptyMessage := "No duplicate permissions found across levels"
+		if findings := c.renderManagedFindings(); findings != "" {
+			emptyMessage = lipgloss.JoinVertical(lipgloss.Center, emptyMessage, "", findings)
+		}
 		return BlockingMessageStyle.
 			Width(contentWidth).
 			Height(c.height).
 			Render(emptyMessage)
 	}
 
+	borderColor := ColorBorderFocused
+	if c.inactive {
+		borderColor = ColorBorderNormal
+	}
 	tableStyle := lipgloss.NewStyle().
 		Width(contentWidth).
 		Height(c.height).
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(ColorBorderFocused)). // Use centralized theme
-		Padding(1)
+		BorderForeground(lipgloss.Color(borderColor)). // Use centralized theme
+		Padding(c.panelPadding())
+
+	// Use the actual duplicates table from the model, followed by contradictions and rules
+	// listed twice in one file; the table is left out when there are no duplicates
+	var sections []string
+	if len(c.model.Duplicates) > 0 {
+		sections = append(sections, c.renderDuplicatesPage())
+	}
+	for _, section := range c.duplicatesSections() {
+		if len(sections) > 0 {
+			sections = append(sections, "")
+		}
+		sections = append(sections, section)
+	}
+	return tableStyle.Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
 
-	// Use the actual duplicates table from the model
-	tableContent := c.model.DuplicatesTable.View()
-	return tableStyle.Render(tableContent)
+// duplicatesSections returns the non-empty sections listed below the duplicates table
+func (c *ContentComponent) duplicatesSections() []string {
+	var sections []string
+	for _, section := range []string{
+		c.renderContradictions(),
+		c.renderSameLevelDuplicates(),
+		c.renderManagedFindings(),
+	} {
+		if section != "" {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// visibleDuplicateRows returns how many duplicates fit in the table once the sections below
+// it are laid out, keeping a line for the page indicator when they do not all fit
+func (c *ContentComponent) visibleDuplicateRows() int {
+	vertical, _ := c.panelPadding()
+	header := lipgloss.Height(CreateTableStyles().Header.Render(""))
+	rows := c.height - NormalBorderStyle.GetVerticalBorderSize() - 2*vertical - header
+	for _, section := range c.duplicatesSections() {
+		rows -= lipgloss.Height(section) + 1
+	}
+	if rows < len(c.model.Duplicates) {
+		rows--
+	}
+	return max(rows, minDuplicateRows)
+}
+
+// renderDuplicatesPage renders the page of the duplicates table holding the selection, with
+// a line below it placing the page among all duplicates when they do not all fit
+func (c *ContentComponent) renderDuplicatesPage() string {
+	m := c.model
+	total := len(m.Duplicates)
+	rows := min(c.visibleDuplicateRows(), total)
+	cursor := m.DuplicatesTable.Cursor()
+	start := pageOffset(m.DuplicatesOffset, cursor, rows, total)
+	end := start + rows
+
+	page := table.New(
+		table.WithColumns(m.DuplicatesTable.Columns()),
+		table.WithRows(m.DuplicatesTable.Rows()[start:end]),
+		table.WithFocused(m.DuplicatesTable.Focused()),
+		// Styles come first since the height leaves room for the styled header
+		table.WithStyles(CreateTableStyles()),
+		table.WithHeight(lipgloss.Height(CreateTableStyles().Header.Render(""))+rows),
+	)
+	page.SetCursor(cursor - start)
+	if rows == total {
+		return page.View()
+	}
+
+	indicator := fmt.Sprintf("%d–%d of %d", start+1, end, total)
+	if start > 0 {
+		indicator += fmt.Sprintf("  ↑ %d more", start)
+	}
+	if end < total {
+		indicator += fmt.Sprintf("  ↓ %d more", total-end)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, page.View(), scrollIndicatorStyle.Render(indicator))
+}
+
+// renderManagedFindings lists rules already covered or contradicted by managed policy
+func (c *ContentComponent) renderManagedFindings() string {
+	if len(c.model.ManagedFindings) == 0 {
+		return ""
+	}
+
+	lines := []string{TitleStyle.Render("Managed Policy")}
+	for _, f := range c.model.ManagedFindings {
+		subject := fmt.Sprintf("%s (%s)", f.Name, getLevelStyledText(f.Level))
+		if f.Kind == types.ManagedContradicted {
+			lines = append(lines, ErrorStyle.Render("• ")+subject+
+				" contradicted by managed deny "+f.ManagedRule)
+		} else {
+			lines = append(lines, "• "+subject+" already covered by managed rule "+f.ManagedRule)
+		}
+	}
+	return strings.Join(lines, "\n")
 }
 
 // renderOrganizationContent renders the three-column organization screen or blocking message
@@ -160,51 +314,152 @@ func (c *ContentComponent) renderOrganizationContent() string {
 		return ""
 	}
 
-	// Check if there are unresolved duplicates - if so, show blocking message
-	if hasUnresolvedDuplicates(c.model) {
+	// Check if there are unresolved duplicates - if so, show blocking message, except next
+	// to the Duplicates pane where the columns preview the resolutions
+	if hasUnresolvedDuplicates(c.model) && !c.pane {
 		return c.renderBlockingMessage()
 	}
 
-	// Use centralized width calculation and divide among columns
-	totalContentWidth := c.getConsistentContentWidth()
-	baseColumnWidth := totalContentWidth / 3
-	remainder := totalContentWidth % 3
-
-	// Distribute remainder to columns to use full width
-	columnWidths := []int{baseColumnWidth, baseColumnWidth, baseColumnWidth}
-	for i := 0; i < remainder; i++ {
-		columnWidths[i]++
+	// Use centralized width calculation and share it by how wide each column's rules are
+	needs := []int{
+		c.permissionColumnNeed(levelDisplayLocal),
+		c.permissionColumnNeed(levelDisplayRepo),
+		c.permissionColumnNeed(levelDisplayUser),
+	}
+	// A read-only managed policy column is added when a managed settings file exists
+	if c.model.ManagedLevel.Exists {
+		needs = append(needs, c.managedColumnNeed())
+	}
+	columnWidths := balanceColumnWidths(c.getConsistentContentWidth(), needs)
+	if c.columnsStacked() {
+		for i := range columnWidths {
+			columnWidths[i] = c.getConsistentContentWidth()
+		}
 	}
 
 	// Render each column
-	localColumn := c.renderPermissionColumn(levelDisplayLocal, columnWidths[0], 0)
-	repoColumn := c.renderPermissionColumn(levelDisplayRepo, columnWidths[1], 1)
-	userColumn := c.renderPermissionColumn(levelDisplayUser, columnWidths[2], 2)
+	columns := []string{
+		c.renderPermissionColumn(levelDisplayLocal, columnWidths[0], 0),
+		c.renderPermissionColumn(levelDisplayRepo, columnWidths[1], 1),
+		c.renderPermissionColumn(levelDisplayUser, columnWidths[2], 2),
+	}
+	if c.model.ManagedLevel.Exists {
+		columns = append(columns, c.renderManagedColumn(columnWidths[3]))
+	}
 
+	if c.columnsStacked() {
+		return lipgloss.JoinVertical(lipgloss.Left, columns...)
+	}
 	// Join horizontally using pure lipgloss
-	return lipgloss.JoinHorizontal(lipgloss.Top, localColumn, repoColumn, userColumn)
+	return lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+}
+
+// columnsStacked reports whether the organization columns are stacked top to bottom
+func (c *ContentComponent) columnsStacked() bool {
+	return stackedColumns.at(c.breakpoint())
+}
+
+// columnHeight returns the height of each organization column: the content height, or an
+// equal share of it when the columns are stacked
+func (c *ContentComponent) columnHeight() int {
+	if !c.columnsStacked() {
+		return c.height
+	}
+	count := len(editableLevels)
+	if c.model.ManagedLevel.Exists {
+		count++
+	}
+	return c.height / count
+}
+
+// managedColumnStyle dims the read-only managed policy column
+var managedColumnStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorTextSecondary))
+
+// renderManagedColumn renders the read-only managed policy rules (never focusable)
+func (c *ContentComponent) renderManagedColumn(width int) string {
+	managed := c.model.ManagedLevel
+	count := len(managed.Permissions) + len(managed.Deny)
+
+	header := managedColumnStyle.
+		Bold(true).
+		Padding(0, 1).
+		Margin(0, 0, c.headerMarginBottom(), 0).
+		Render(fmt.Sprintf("Managed (%d) read-only", count))
+
+	rowWidth := c.columnRowWidth(width)
+	items := make([]string, 0, count)
+	for _, perm := range managed.Permissions {
+		items = append(items, truncateText("  "+perm, rowWidth))
+	}
+	for _, perm := range managed.Deny {
+		items = append(items, truncateText("  ✗ "+perm, rowWidth))
+	}
+	if len(items) == 0 {
+		items = append(items, "No permissions")
+	}
+
+	// The column has no cursor, so overflow is summarized on the last visible row
+	if rows := c.visibleColumnRows(); len(items) > rows {
+		hidden := len(items) - rows + 1
+		items = append(items[:rows-1], fmt.Sprintf("  … %d more", hidden))
+	}
+
+	content := managedColumnStyle.Render(strings.Join(items, "\n"))
+	column := c.joinColumnSections(header, content)
+	return NormalBorderStyle.Width(width).Height(c.columnHeight()).Padding(c.panelPadding()).
+		Render(column)
 }
 
 // renderPermissionColumn renders a single permission column
 func (c *ContentComponent) renderPermissionColumn(level string, width int, columnIndex int) string {
-	focused := c.model.FocusedColumn == columnIndex
+	focused := c.model.FocusedColumn == columnIndex && !c.inactive
 	style := c.getColumnStyle(focused, width)
-	header := c.renderColumnHeader(level)
-	content := c.renderColumnContent(level, columnIndex, focused)
-	columnContent := lipgloss.JoinVertical(lipgloss.Left, header, "", content)
-	return style.Render(columnContent)
+	header := c.renderColumnHeader(level, focused)
+	content := c.renderColumnContent(level, columnIndex, focused, c.columnRowWidth(width))
+	return style.Render(c.joinColumnSections(header, content))
+}
+
+// isCompactDensity reports whether the user selected the compact list density
+func isCompactDensity(m *types.Model) bool {
+	return m.Config != nil && m.Config.Display.Density == config.DensityCompact
+}
+
+// panelPadding returns the vertical and horizontal padding for bordered panels
+func (c *ContentComponent) panelPadding() (int, int) {
+	if c.compact() {
+		return 0, 1
+	}
+	return 1, 1
+}
+
+// headerMarginBottom returns the blank space below column headers
+func (c *ContentComponent) headerMarginBottom() int {
+	if c.compact() {
+		return 0
+	}
+	return 1
+}
+
+// joinColumnSections joins a column header and its items, separated by a blank
+// line unless the compact density is active
+func (c *ContentComponent) joinColumnSections(header, content string) string {
+	if c.compact() {
+		return lipgloss.JoinVertical(lipgloss.Left, header, content)
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, header, "", content)
 }
 
 // getColumnStyle returns the appropriate style for focused/unfocused columns
 func (c *ContentComponent) getColumnStyle(focused bool, width int) lipgloss.Style {
 	if focused {
-		return FocusedBorderStyle.Width(width).Height(c.height).Padding(1)
+		return FocusedBorderStyle.Width(width).Height(c.columnHeight()).Padding(c.panelPadding())
 	}
-	return NormalBorderStyle.Width(width).Height(c.height).Padding(1)
+	return NormalBorderStyle.Width(width).Height(c.columnHeight()).Padding(c.panelPadding())
 }
 
-// renderColumnHeader creates the styled header for a column
-func (c *ContentComponent) renderColumnHeader(level string) string {
+// renderColumnHeader creates the styled header for a column; the focused column's header
+// is shown in inverse video
+func (c *ContentComponent) renderColumnHeader(level string, focused bool) string {
 	var headerStyle lipgloss.Style
 	var count int
 
@@ -214,43 +469,63 @@ func (c *ContentComponent) renderColumnHeader(level string) string {
 		headerStyle = LocalLevelStyle.
 			Background(lipgloss.Color(ColorBackground)).
 			Padding(0, 1).
-			Margin(0, 0, 1, 0)
+			Margin(0, 0, c.headerMarginBottom(), 0)
 	case levelDisplayRepo:
 		count = len(c.model.RepoLevel.Permissions)
 		headerStyle = RepoLevelStyle.
 			Background(lipgloss.Color(ColorBackground)).
 			Padding(0, 1).
-			Margin(0, 0, 1, 0)
+			Margin(0, 0, c.headerMarginBottom(), 0)
 	case levelDisplayUser:
 		count = len(c.model.UserLevel.Permissions)
 		headerStyle = UserLevelStyle.
 			Background(lipgloss.Color(ColorBackground)).
 			Padding(0, 1).
-			Margin(0, 0, 1, 0)
+			Margin(0, 0, c.headerMarginBottom(), 0)
+	}
+
+	if focused {
+		// Nested count styling would end the inverse video early, so render it plainly
+		return headerStyle.Reverse(true).Render(fmt.Sprintf("%s (%d)", level, count))
 	}
 
 	headerText := level + " " + CountStyle.Render(fmt.Sprintf("(%d)", count))
 	return headerStyle.Render(headerText)
 }
 
-// renderColumnContent creates the content for a column
-func (c *ContentComponent) renderColumnContent(level string, columnIndex int, focused bool) string {
+// renderColumnContent creates the content for a column, rendering only the rows inside
+// the column's scroll window, each cut to rowWidth
+func (c *ContentComponent) renderColumnContent(
+	level string, columnIndex int, focused bool, rowWidth int,
+) string {
 	levelPermissions := c.getColumnPermissionStructs(level)
-
-	var permissionItems []string
 	if len(levelPermissions) == 0 {
-		permissionItems = append(permissionItems, "No permissions")
-	} else {
-		for i, perm := range levelPermissions {
-			isSelected := focused && i == c.model.ColumnSelections[columnIndex]
-			permItem := c.renderPermissionItem(perm, isSelected)
-			permissionItems = append(permissionItems, permItem)
-		}
+		return "No permissions"
+	}
+
+	start := min(c.model.ColumnOffsets[columnIndex], len(levelPermissions)-1)
+	end := min(start+c.visibleColumnRows(), len(levelPermissions))
+
+	permissionItems := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		isSelected := focused && i == c.model.ColumnSelections[columnIndex]
+		permItem := c.renderPermissionItem(levelPermissions[i], isSelected, rowWidth)
+		permissionItems = append(permissionItems, permItem)
 	}
 
 	return strings.Join(permissionItems, "\n")
 }
 
+// visibleColumnRows returns how many permission rows fit below a column header
+func (c *ContentComponent) visibleColumnRows() int {
+	vertical, _ := c.panelPadding()
+	header := c.renderColumnHeader(levelDisplayLocal, false)
+	// The joined sections end with an empty content line, which is itself a row
+	sections := lipgloss.Height(c.joinColumnSections(header, "")) - 1
+	rows := c.columnHeight() - NormalBorderStyle.GetVerticalBorderSize() - 2*vertical - sections
+	return max(rows, 1)
+}
+
 // getColumnPermissionStructs returns Permission structs for the specified level
 func (c *ContentComponent) getColumnPermissionStructs(level string) []types.Permission {
 	var targetLevel string
@@ -267,15 +542,39 @@ func (c *ContentComponent) getColumnPermissionStructs(level string) []types.Perm
 
 	var columnPerms []types.Permission
 	for _, perm := range c.model.Permissions {
-		if perm.CurrentLevel == targetLevel {
+		if c.columnLevel(perm) == targetLevel {
 			columnPerms = append(columnPerms, perm)
 		}
 	}
 	return columnPerms
 }
 
-// renderPermissionItem renders a single permission with selection highlighting and origin indicator
-func (c *ContentComponent) renderPermissionItem(perm types.Permission, isSelected bool) string {
+// columnLevel returns the column showing perm. Next to the Duplicates pane a duplicated
+// rule is shown at the level chosen to keep it, previewing the resolution.
+func (c *ContentComponent) columnLevel(perm types.Permission) string {
+	if c.pane {
+		if dup, ok := duplicateOf(c.model, perm.Name); ok && dup.KeepLevel != "" {
+			return dup.KeepLevel
+		}
+	}
+	return perm.CurrentLevel
+}
+
+// duplicateOf returns the duplicate of the named rule, if it is one
+func duplicateOf(m *types.Model, name string) (types.Duplicate, bool) {
+	for _, dup := range m.Duplicates {
+		if dup.Name == name {
+			return dup, true
+		}
+	}
+	return types.Duplicate{}, false
+}
+
+// renderPermissionItem renders a single permission with selection highlighting and origin
+// indicator. A positive width cuts the rule short so the row fits.
+func (c *ContentComponent) renderPermissionItem(
+	perm types.Permission, isSelected bool, width int,
+) string {
 	// Build origin indicator text if moved
 	var originText string
 	if perm.CurrentLevel != perm.OriginalLevel {
@@ -289,14 +588,45 @@ func (c *ContentComponent) renderPermissionItem(perm types.Permission, isSelecte
 		)
 	}
 
+	// Mark rules added by import, preset, or suggestion
+	if perm.Provenance != nil {
+		originText += " " + provenanceBadgeStyle.Render("◆")
+	}
+
+	if perm.Added {
+		originText += OriginIndicatorStyle.Render(" (new)")
+	}
+
+	// Preview duplicate and contradiction resolutions next to the Duplicates pane
+	if _, ok := duplicateOf(c.model, perm.Name); ok && c.pane {
+		originText += OriginIndicatorStyle.Render(" (kept)")
+	}
+	if deniedByContradiction(c.model, perm) {
+		originText += OriginIndicatorStyle.Render(" (remove)")
+	}
+
+	// Show pending inspector edits next to the rule as loaded
+	if edit, ok := ruleEditFor(c.model, perm.CurrentLevel, perm.Name); ok {
+		if edit.Replacement == "" {
+			originText += OriginIndicatorStyle.Render(" (delete)")
+		} else {
+			originText += OriginIndicatorStyle.Render(" → " + edit.Replacement)
+		}
+	}
+
+	name := perm.Name
+	if width > 0 {
+		name = truncateText(name, width-2-lipgloss.Width(originText))
+	}
+
 	// Add selection highlighting if this item is selected
 	if isSelected {
 		// Highlight only the permission name, not the origin indicator
-		highlightedName := SelectedItemStyle.Render("> " + perm.Name)
+		highlightedName := SelectedItemStyle.Render("> " + name)
 		return highlightedName + originText
 	}
 
-	return "  " + perm.Name + originText
+	return "  " + name + originText
 }
 
 // getOriginStyle returns the appropriate style for the origin level indicator
@@ -313,6 +643,18 @@ func (c *ContentComponent) getOriginStyle(level string) lipgloss.Style {
 	}
 }
 
+// deniedByContradiction reports whether saving removes the allow rule from its level because
+// the deny side of a contradiction wins
+func deniedByContradiction(m *types.Model, perm types.Permission) bool {
+	for _, c := range m.Contradictions {
+		if c.Name == perm.Name && c.Winner == types.WinnerDeny &&
+			slices.Contains(losingLevels(c), perm.CurrentLevel) {
+			return true
+		}
+	}
+	return false
+}
+
 // renderBlockingMessage renders the blocking message when duplicates need to be resolved
 func (c *ContentComponent) renderBlockingMessage() string {
 	contentWidth := c.getConsistentContentWidth()
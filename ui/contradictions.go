@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/types"
+)
+
+// Keys picking the winning side of the selected contradiction
+const (
+	keyAllowWins = "a"
+	keyDenyWins  = "d"
+)
+
+// contradictionsFocused reports whether up/down and a/d act on the contradictions section
+func contradictionsFocused(m *types.Model) bool {
+	if len(m.Contradictions) == 0 {
+		return false
+	}
+	return m.ContradictionsFocused || len(m.Duplicates) == 0
+}
+
+// handleContradictionNavigation moves within the contradictions section, returning focus to
+// the duplicates table when moving up past the first contradiction
+func handleContradictionNavigation(m *types.Model, key string) *types.Model {
+	switch key {
+	case keyUp, "k":
+		if m.ContradictionCursor > 0 {
+			m.ContradictionCursor--
+		} else if len(m.Duplicates) > 0 {
+			m.ContradictionsFocused = false
+		}
+	case keyDown, "j":
+		if m.ContradictionCursor < len(m.Contradictions)-1 {
+			m.ContradictionCursor++
+		}
+	}
+	return m
+}
+
+// handleContradictionResolution marks the allowed or denied side of the selected
+// contradiction as the winner
+func handleContradictionResolution(m *types.Model, key string) *types.Model {
+	if !contradictionsFocused(m) || m.ContradictionCursor >= len(m.Contradictions) {
+		return rejectInput(m)
+	}
+
+	winner := types.WinnerAllow
+	if key == keyDenyWins {
+		winner = types.WinnerDeny
+	}
+	m.Contradictions[m.ContradictionCursor].Winner = winner
+	return m
+}
+
+// losingLevels returns the levels whose entry for the contradiction is removed on save
+func losingLevels(c types.Contradiction) []string {
+	switch c.Winner {
+	case types.WinnerAllow:
+		return c.DenyLevels
+	case types.WinnerDeny:
+		return c.AllowLevels
+	}
+	return nil
+}
+
+// winningLevels returns the levels whose entry for the contradiction is kept
+func winningLevels(c types.Contradiction) []string {
+	switch c.Winner {
+	case types.WinnerAllow:
+		return c.AllowLevels
+	case types.WinnerDeny:
+		return c.DenyLevels
+	}
+	return nil
+}
+
+// refreshContradictionLevels sets the allowed and denied levels of each contradiction to
+// where its rule is now, so a resolution removes the losing side from the level a move put
+// it in rather than the one it was loaded from
+func refreshContradictionLevels(m *types.Model) {
+	for i := range m.Contradictions {
+		c := &m.Contradictions[i]
+		c.AllowLevels, c.DenyLevels = nil, nil
+		for _, level := range []types.SettingsLevel{m.UserLevel, m.RepoLevel, m.LocalLevel} {
+			if slices.Contains(level.Permissions, c.Name) {
+				c.AllowLevels = append(c.AllowLevels, level.Name)
+			}
+			if slices.Contains(level.Deny, c.Name) {
+				c.DenyLevels = append(c.DenyLevels, level.Name)
+			}
+		}
+	}
+}
+
+// applyContradictionResolutions removes the losing side of each resolved contradiction
+func applyContradictionResolutions(m *types.Model) {
+	for _, c := range m.Contradictions {
+		for _, name := range losingLevels(c) {
+			level := getLevelByName(m, name)
+			if level == nil {
+				continue
+			}
+			if c.Winner == types.WinnerAllow {
+				level.Deny = removePermission(level.Deny, c.Name)
+			} else {
+				level.Permissions = removePermission(level.Permissions, c.Name)
+			}
+		}
+	}
+}
+
+// contradictionAuditEntries returns an audit entry for each level losing a contradiction
+func contradictionAuditEntries(m *types.Model, now time.Time) []audit.Entry {
+	var entries []audit.Entry
+	for _, c := range m.Contradictions {
+		for _, level := range losingLevels(c) {
+			entries = append(entries, audit.Entry{
+				Timestamp: now,
+				Rule:      c.Name,
+				From:      level,
+				To:        strings.Join(winningLevels(c), ", "),
+				Action:    audit.ActionResolveContradiction,
+			})
+		}
+	}
+	return entries
+}
+
+// contradictionResolutionChanges lists the losing sides of the resolved contradictions
+func contradictionResolutionChanges(m *types.Model) []types.PendingChange {
+	var changes []types.PendingChange
+	for _, c := range m.Contradictions {
+		if c.Winner == "" {
+			continue
+		}
+		side := "deny"
+		if c.Winner == types.WinnerDeny {
+			side = "allow"
+		}
+		changes = append(changes, types.PendingChange{
+			Kind:   types.ChangeContradiction,
+			Rule:   c.Name,
+			From:   losingLevels(c),
+			Detail: c.Winner,
+			Line: fmt.Sprintf("• %s: Remove %s from %s (%s wins)",
+				c.Name, side, styledLevels(losingLevels(c)), c.Winner),
+		})
+	}
+	return changes
+}
+
+// styledLevels joins level names in their theme colors
+func styledLevels(levels []string) string {
+	styled := make([]string, 0, len(levels))
+	for _, level := range levels {
+		styled = append(styled, getLevelStyledText(level))
+	}
+	return strings.Join(styled, ", ")
+}
+
+// renderContradictionsStatusText describes the selected contradiction for the status bar
+func renderContradictionsStatusText(m *types.Model) string {
+	if m.ContradictionCursor >= len(m.Contradictions) {
+		return "Resolve allow/deny contradictions"
+	}
+	c := m.Contradictions[m.ContradictionCursor]
+	return fmt.Sprintf("%s allowed in %s, denied in %s (choose A/D)     [%d contradictions]",
+		c.Name, strings.Join(c.AllowLevels, ", "), strings.Join(c.DenyLevels, ", "),
+		len(m.Contradictions))
+}
+
+// renderContradictions lists rules that are both allowed and denied, with the selected one
+// highlighted while the section has focus
+func (c *ContentComponent) renderContradictions() string {
+	m := c.model
+	if len(m.Contradictions) == 0 {
+		return ""
+	}
+
+	focused := contradictionsFocused(m)
+	lines := []string{TitleStyle.Render("Contradictions")}
+	for i, contradiction := range m.Contradictions {
+		winner := "unresolved"
+		switch contradiction.Winner {
+		case types.WinnerAllow:
+			winner = "allow wins"
+		case types.WinnerDeny:
+			winner = "deny wins"
+		}
+		// The highlight replaces level colors, which would reset its background
+		format := "%s  allow: %s  deny: %s  → %s"
+		if focused && i == m.ContradictionCursor {
+			lines = append(lines, SelectedItemStyle.Render("> "+fmt.Sprintf(format,
+				contradiction.Name, strings.Join(contradiction.AllowLevels, ", "),
+				strings.Join(contradiction.DenyLevels, ", "), winner)))
+			continue
+		}
+		lines = append(lines, "  "+fmt.Sprintf(format, contradiction.Name,
+			styledLevels(contradiction.AllowLevels), styledLevels(contradiction.DenyLevels),
+			winner))
+	}
+	return strings.Join(lines, "\n")
+}
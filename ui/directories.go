@@ -0,0 +1,437 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/paths"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// directoryRow locates one additionalDirectories entry on the Directories screen
+type directoryRow struct {
+	level string
+	index int // Index into the level's Directories
+}
+
+// directoryRows lists every entry in screen order; DirectoryCursor indexes this list
+func directoryRows(m *types.Model) []directoryRow {
+	var rows []directoryRow
+	for _, name := range editableLevels {
+		for i := range getLevelByName(m, name).Directories {
+			rows = append(rows, directoryRow{level: name, index: i})
+		}
+	}
+	return rows
+}
+
+// selectedDirectoryRow returns the row under the cursor, clamping the cursor to the list
+func selectedDirectoryRow(m *types.Model) (directoryRow, bool) {
+	rows := directoryRows(m)
+	if len(rows) == 0 {
+		return directoryRow{}, false
+	}
+	m.DirectoryCursor = max(min(m.DirectoryCursor, len(rows)-1), 0)
+	return rows[m.DirectoryCursor], true
+}
+
+// selectDirectory moves the cursor to an entry of a level, if it is listed
+func selectDirectory(m *types.Model, dir, level string) {
+	for i, row := range directoryRows(m) {
+		if row.level == level && getLevelByName(m, level).Directories[row.index] == dir {
+			m.DirectoryCursor = i
+			return
+		}
+	}
+}
+
+// resolveDirectory returns the directory an entry refers to: "~/" is the home directory and
+// relative entries are relative to the project root
+func resolveDirectory(m *types.Model, dir string) string {
+	if rest, ok := strings.CutPrefix(dir, "~/"); ok {
+		if home, _, err := paths.HomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	if filepath.IsAbs(dir) || m.ProjectRoot == "" {
+		return dir
+	}
+	return filepath.Join(m.ProjectRoot, dir)
+}
+
+// validateDirectory reports why an entry cannot be added to a level, or nil
+func validateDirectory(m *types.Model, dir, level string) error {
+	if dir == "" {
+		return errors.New("enter a directory")
+	}
+	if slices.Contains(getLevelByName(m, level).Directories, dir) {
+		return fmt.Errorf("%s is already listed in %s", dir, level)
+	}
+	info, err := os.Stat(resolveDirectory(m, dir))
+	if err != nil {
+		return fmt.Errorf("%s does not exist", resolveDirectory(m, dir))
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", resolveDirectory(m, dir))
+	}
+	return nil
+}
+
+// directoryStatus describes how an entry resolves for the Directories screen
+func directoryStatus(m *types.Model, dir string) string {
+	kind := "relative"
+	if filepath.IsAbs(dir) || strings.HasPrefix(dir, "~/") {
+		kind = "absolute"
+	}
+	if info, err := os.Stat(resolveDirectory(m, dir)); err != nil || !info.IsDir() {
+		return kind + ", missing"
+	}
+	return kind
+}
+
+// directoriesChanged reports whether a level's entries differ from those loaded
+func directoriesChanged(m *types.Model, level string) bool {
+	return !slices.Equal(getLevelByName(m, level).Directories, m.OriginalDirectories[level])
+}
+
+// hasDirectoryChanges reports whether any entry was added, removed, or moved
+func hasDirectoryChanges(m *types.Model) bool {
+	return slices.ContainsFunc(editableLevels, func(level string) bool {
+		return directoriesChanged(m, level)
+	})
+}
+
+// resetDirectories restores every level's entries as loaded
+func resetDirectories(m *types.Model) {
+	for _, name := range editableLevels {
+		getLevelByName(m, name).Directories = slices.Clone(m.OriginalDirectories[name])
+	}
+	m.DirectoryCursor = 0
+}
+
+// handleDirectoryNavigation moves the cursor through the entries of all levels
+func handleDirectoryNavigation(m *types.Model, key string) *types.Model {
+	switch key {
+	case keyUp, "k":
+		m.DirectoryCursor--
+	case keyDown, "j":
+		m.DirectoryCursor++
+	}
+	m.DirectoryCursor = max(min(m.DirectoryCursor, len(directoryRows(m))-1), 0)
+	return m
+}
+
+// handleDirectoryKey adds, edits, or removes an entry on the Directories screen
+func handleDirectoryKey(m *types.Model, key string) *types.Model {
+	row, ok := selectedDirectoryRow(m)
+	switch key {
+	case keyAdd:
+		level := types.LevelLocal
+		if ok {
+			level = row.level
+		}
+		m.ActiveModal = NewDirectoryModal("", level)
+	case keyEdit:
+		if !ok {
+			return rejectInput(m)
+		}
+		dir := getLevelByName(m, row.level).Directories[row.index]
+		m.ActiveModal = NewDirectoryModal(dir, row.level)
+	case keyDelete:
+		if !ok {
+			return rejectInput(m)
+		}
+		level := getLevelByName(m, row.level)
+		level.Directories = slices.Delete(level.Directories, row.index, row.index+1)
+		m.DirectoryCursor = max(min(m.DirectoryCursor, len(directoryRows(m))-1), 0)
+	}
+	return m
+}
+
+// handleDirectoryMove moves the selected entry to the level of a number key
+func handleDirectoryMove(m *types.Model, key string) *types.Model {
+	row, ok := selectedDirectoryRow(m)
+	toLevel := getTargetLevel(key)
+	if !ok || toLevel == row.level {
+		return rejectInput(m)
+	}
+
+	from, to := getLevelByName(m, row.level), getLevelByName(m, toLevel)
+	dir := from.Directories[row.index]
+	if slices.Contains(to.Directories, dir) {
+		return rejectInput(m)
+	}
+	from.Directories = slices.Delete(from.Directories, row.index, row.index+1)
+	to.Directories = append(to.Directories, dir)
+	selectDirectory(m, dir, toLevel)
+	return m
+}
+
+// applyDirectoryEdit stores the entry typed into the directory modal, keeping the modal open
+// with an error when the directory is invalid
+func applyDirectoryEdit(m *types.Model, dm *DirectoryModal) *types.Model {
+	dir := strings.TrimSpace(dm.input)
+	level := getLevelByName(m, dm.level)
+	if dir != dm.original {
+		if err := validateDirectory(m, dir, dm.level); err != nil {
+			dm.err = err.Error()
+			return m
+		}
+	}
+
+	if i := slices.Index(level.Directories, dm.original); dm.original != "" && i >= 0 {
+		level.Directories[i] = dir
+	} else {
+		level.Directories = append(level.Directories, dir)
+	}
+	selectDirectory(m, dir, dm.level)
+	m.ActiveModal = nil
+	return m
+}
+
+// directoryAuditEntries returns an audit entry for each entry added, removed, or moved to
+// another level
+func directoryAuditEntries(m *types.Model, now time.Time) []audit.Entry {
+	// The levels each entry was added to and removed from
+	added := make(map[string][]string)
+	removed := make(map[string][]string)
+	for _, level := range editableLevels {
+		current, original := getLevelByName(m, level).Directories, m.OriginalDirectories[level]
+		for _, dir := range current {
+			if !slices.Contains(original, dir) {
+				added[dir] = append(added[dir], level)
+			}
+		}
+		for _, dir := range original {
+			if !slices.Contains(current, dir) {
+				removed[dir] = append(removed[dir], level)
+			}
+		}
+	}
+
+	var entries []audit.Entry
+	for _, level := range editableLevels {
+		for _, dir := range getLevelByName(m, level).Directories {
+			if !slices.Contains(added[dir], level) {
+				continue
+			}
+			entry := audit.Entry{
+				Timestamp: now,
+				Rule:      dir,
+				To:        level,
+				Action:    audit.ActionAddDirectory,
+			}
+			// An entry removed from one level and added to another was moved
+			if from := removed[dir]; len(from) > 0 {
+				entry.From, entry.Action = from[0], audit.ActionMoveDirectory
+				removed[dir] = from[1:]
+			}
+			entries = append(entries, entry)
+		}
+	}
+	for _, level := range editableLevels {
+		for _, dir := range m.OriginalDirectories[level] {
+			if slices.Contains(removed[dir], level) {
+				entries = append(entries, audit.Entry{
+					Timestamp: now,
+					Rule:      dir,
+					From:      level,
+					Action:    audit.ActionRemoveDirectory,
+				})
+			}
+		}
+	}
+	return entries
+}
+
+// directoryChanges lists the additional directories added, moved, and removed
+func directoryChanges(m *types.Model) []types.PendingChange {
+	var changes []types.PendingChange
+	for _, entry := range directoryAuditEntries(m, time.Time{}) {
+		change := types.PendingChange{Kind: types.ChangeDirectory, Rule: entry.Rule}
+		switch entry.Action {
+		case audit.ActionAddDirectory:
+			change.To = entry.To
+			change.Line = fmt.Sprintf("• %s: Add to %s",
+				entry.Rule, getLevelStyledText(entry.To))
+		case audit.ActionMoveDirectory:
+			change.From, change.To = []string{entry.From}, entry.To
+			change.Line = fmt.Sprintf("• %s: %s → %s",
+				entry.Rule, getLevelStyledText(entry.From), getLevelStyledText(entry.To))
+		default:
+			change.From = []string{entry.From}
+			change.Line = fmt.Sprintf("• %s: Remove from %s",
+				entry.Rule, getLevelStyledText(entry.From))
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// renderDirectoriesStatusText describes the selected entry for the status bar
+func renderDirectoriesStatusText(m *types.Model) string {
+	row, ok := selectedDirectoryRow(m)
+	if !ok {
+		return "No additional directories in any level"
+	}
+	dir := getLevelByName(m, row.level).Directories[row.index]
+	return fmt.Sprintf("%s in %s → %s", dir, row.level, resolveDirectory(m, dir))
+}
+
+// visibleDirectoryLines returns how many lines fit inside the Directories panel
+func (c *ContentComponent) visibleDirectoryLines() int {
+	vertical, _ := c.panelPadding()
+	lines := c.height - NormalBorderStyle.GetVerticalBorderSize() - 2*vertical
+	return max(lines, 1)
+}
+
+// renderDirectoriesContent lists the additionalDirectories of each level with the selected
+// entry highlighted
+func (c *ContentComponent) renderDirectoriesContent() string {
+	if c.width <= 0 || c.height <= 0 {
+		return ""
+	}
+
+	m := c.model
+	contentWidth := max(c.getConsistentContentWidth(), 20)
+	_, horizontal := c.panelPadding()
+	rowWidth := contentWidth - NormalBorderStyle.GetHorizontalBorderSize() - 2*horizontal
+	rowStyle := lipgloss.NewStyle().MaxWidth(rowWidth)
+
+	selected, hasSelection := selectedDirectoryRow(m)
+	selectedLine := 0
+	var lines []string
+	for _, name := range editableLevels {
+		dirs := getLevelByName(m, name).Directories
+		lines = append(lines, TitleStyle.Render(name)+fmt.Sprintf(" (%d)", len(dirs)))
+		if len(dirs) == 0 {
+			lines = append(lines, OriginIndicatorStyle.Render("  No additional directories"))
+		}
+		for i, dir := range dirs {
+			line := fmt.Sprintf("%-48s  %s", dir, directoryStatus(m, dir))
+			if hasSelection && selected.level == name && selected.index == i {
+				selectedLine = len(lines)
+				lines = append(lines, rowStyle.Render(SelectedItemStyle.Render("> "+line)))
+				continue
+			}
+			lines = append(lines, rowStyle.Render("  "+line))
+		}
+	}
+
+	// Scroll just far enough to keep the selected entry visible
+	visible := c.visibleDirectoryLines()
+	start := max(selectedLine-visible+1, 0)
+	end := min(start+visible, len(lines))
+
+	return lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(c.height).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorBorderFocused)).
+		Padding(c.panelPadding()).
+		Render(strings.Join(lines[start:end], "\n"))
+}
+
+// DirectoryModal implements types.Modal for adding or editing one additionalDirectories entry
+type DirectoryModal struct {
+	original string // The edited entry, "" when adding
+	level    string
+	input    string
+	err      string
+}
+
+// NewDirectoryModal creates the directory prompt; an empty dir adds an entry to level
+func NewDirectoryModal(dir, level string) *DirectoryModal {
+	return &DirectoryModal{original: dir, level: level, input: dir}
+}
+
+// CapturesText reports that printable keys are typed into the directory input
+func (dm *DirectoryModal) CapturesText() bool {
+	return true
+}
+
+// RenderModal renders the directory prompt
+func (dm *DirectoryModal) RenderModal(width, height int) string {
+	contentWidth := min(80, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	title := "Add Directory"
+	if dm.original != "" {
+		title = "Edit Directory"
+	}
+	lines := []string{
+		titleStyle.Render(title),
+		"",
+		fmt.Sprintf("%s %s", AccentStyle.Render("Level:"), getLevelStyledText(dm.level)),
+		fmt.Sprintf("%s %s█", AccentStyle.Render("Directory:"), dm.input),
+		CountStyle.Render("Absolute, ~/, or relative to the project root; must exist"),
+	}
+	if dm.err != "" {
+		lines = append(lines, "", ErrorStyle.Render(dm.err))
+	}
+
+	actions := []string{
+		formatFooterAction("ENTER", "Confirm"),
+		formatFooterAction("ESC", "Cancel"),
+	}
+	if dm.original == "" {
+		actions = append([]string{formatFooterAction("TAB", "Change level")}, actions...)
+	}
+	instructions := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(contentWidth-4).
+		Padding(1, 0, 0, 0).
+		Render(joinFooterActions(actions))
+	lines = append(lines, instructions)
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// HandleInput processes keyboard input for the directory prompt; TAB cycles the level of a
+// new entry
+func (dm *DirectoryModal) HandleInput(key string) (handled bool, result interface{}) {
+	switch key {
+	case keyEnter:
+		return true, "directory_set"
+	case keyEscapeLong, keyEscape:
+		return true, "cancel"
+	case "tab":
+		if dm.original == "" {
+			next := (slices.Index(editableLevels, dm.level) + 1) % len(editableLevels)
+			dm.level = editableLevels[next]
+		}
+	case "backspace":
+		if runes := []rune(dm.input); len(runes) > 0 {
+			dm.input = string(runes[:len(runes)-1])
+		}
+	case "space":
+		dm.input += " "
+	default:
+		if len([]rune(key)) == 1 {
+			dm.input += key
+		}
+	}
+	dm.err = ""
+	return false, nil
+}
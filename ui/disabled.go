@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"claude-permissions/logging"
+	"claude-permissions/metadata"
+	"claude-permissions/types"
+)
+
+// actionPurgeExpired is the small modal action offering to purge expired soft-deleted rules
+const actionPurgeExpired = "purge_expired"
+
+// softDeleteReason describes why a duplicate was removed from its level
+func softDeleteReason(keepLevel string) string {
+	return "duplicate kept in " + keepLevel
+}
+
+// OfferExpiredPurge asks whether to permanently drop soft-deleted rules whose grace period
+// has passed
+func OfferExpiredPurge(m *types.Model) {
+	var lines []string
+	for _, name := range []string{types.LevelLocal, types.LevelRepo, types.LevelUser} {
+		for _, rule := range metadata.Expired(*getLevelByName(m, name), time.Now()) {
+			lines = append(lines, fmt.Sprintf("%s: %s", name, rule))
+		}
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	m.ActiveModal = NewSmallModal(
+		"Purge Expired Rules?",
+		"These removed duplicates are past their grace period:\n\n"+
+			strings.Join(lines, "\n")+
+			"\n\nForget them? Declining asks again next time.",
+		actionPurgeExpired,
+	)
+}
+
+// purgeExpired drops expired soft-deleted rules from every level's sidecar
+func purgeExpired(m *types.Model) *types.Model {
+	now := time.Now()
+	purged := 0
+	for _, name := range []string{types.LevelLocal, types.LevelRepo, types.LevelUser} {
+		level := getLevelByName(m, name)
+		expired := metadata.Expired(*level, now)
+		if len(expired) == 0 {
+			continue
+		}
+		for _, rule := range expired {
+			delete(level.Disabled, rule)
+		}
+		if err := metadata.Save(*level); err != nil {
+			logFor(m, logging.ComponentSidecar).Error("purge_expired_failed",
+				logging.LevelName(name), logging.Err(err))
+			m.Notifications.Error("Purge failed: %v", err)
+			return m
+		}
+		purged += len(expired)
+	}
+	m.Notifications.Info("Purged %d expired rule(s)", purged)
+	return m
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"claude-permissions/docs"
+	"claude-permissions/keymap"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/bubbles/v2/viewport"
+)
+
+// keyDocs opens the embedded reference for the selected rule's tool
+const keyDocs = "D"
+
+// DocModal implements types.Modal for reading one section of the embedded rule reference
+type DocModal struct {
+	keys     keymap.KeyMap
+	title    string
+	viewport viewport.Model
+}
+
+// NewDocModal creates a scrollable view of a reference section
+func NewDocModal(keys keymap.KeyMap, section docs.Section) *DocModal {
+	dm := &DocModal{keys: keys, title: section.Title + " Rules", viewport: viewport.New()}
+	dm.viewport.SetContent(section.Body)
+	return dm
+}
+
+// RenderModal renders the section as a full-screen scrollable page
+func (dm *DocModal) RenderModal(width, height int) string {
+	return renderScrollableModal(&dm.viewport, dm.title, width, height)
+}
+
+// HandleInput scrolls the section and closes it on ESC, ENTER, or the docs key
+func (dm *DocModal) HandleInput(key string) (handled bool, result interface{}) {
+	if handled, result, ok := scrollModalInput(&dm.viewport, key); ok {
+		return handled, result
+	}
+	if dm.keys.Action(key) == keymap.Docs {
+		return true, "cancel"
+	}
+	return false, nil
+}
+
+// selectedRuleName returns the rule under the cursor of the current screen, or ""
+func selectedRuleName(m *types.Model) string {
+	switch m.CurrentScreen {
+	case types.ScreenDuplicates:
+		if contradictionsFocused(m) {
+			if m.ContradictionCursor < len(m.Contradictions) {
+				return m.Contradictions[m.ContradictionCursor].Name
+			}
+			return ""
+		}
+		if cursor := m.DuplicatesTable.Cursor(); cursor >= 0 && cursor < len(m.Duplicates) {
+			return m.Duplicates[cursor].Name
+		}
+	case types.ScreenOrganization:
+		columnPerms := getColumnPermissions(m)
+		if selected := m.ColumnSelections[m.FocusedColumn]; selected < len(columnPerms) {
+			return columnPerms[selected].Name
+		}
+	}
+	return ""
+}
+
+// openDocs shows the reference section for the selected rule's tool
+func openDocs(m *types.Model) *types.Model {
+	rule := selectedRuleName(m)
+	if rule == "" {
+		return rejectInput(m)
+	}
+	m.ActiveModal = NewDocModal(m.Keys, docs.For(rule))
+	return m
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"time"
+
+	"claude-permissions/keymap"
+	"claude-permissions/notify"
+	"claude-permissions/types"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// PermissionProvider supplies the permissions shown by an Editor. Load replaces the
+// project in m with the one containing dir; it is also used for project switching and
+// for reloading after a save.
+type PermissionProvider interface {
+	Load(m *types.Model, dir string) error
+}
+
+// ProviderFunc adapts a function to the PermissionProvider interface
+type ProviderFunc func(m *types.Model, dir string) error
+
+// Load calls f(m, dir)
+func (f ProviderFunc) Load(m *types.Model, dir string) error {
+	return f(m, dir)
+}
+
+// Editor is the permission editor as a tea.Model that other Bubble Tea programs can embed.
+// Hosts forward messages to Update, size the pane with SetSize or a tea.WindowSizeMsg, and
+// place View wherever the pane belongs. The quit key returns tea.Quit.
+type Editor struct {
+	model *types.Model
+}
+
+// NewEditor creates an editor for the project containing dir using the default keymap
+func NewEditor(provider PermissionProvider, dir string) (*Editor, error) {
+	m := &types.Model{
+		Notifications: notify.NewQueue(),
+		Session:       types.SessionStats{StartedAt: time.Now()},
+		Keys:          keymap.Default(),
+		ProjectLoader: provider.Load,
+	}
+	if err := provider.Load(m, dir); err != nil {
+		return nil, err
+	}
+	return NewEditorFromModel(m), nil
+}
+
+// NewEditorFromModel creates an editor around an already loaded model
+func NewEditorFromModel(m *types.Model) *Editor {
+	return &Editor{model: m}
+}
+
+// Init implements tea.Model interface
+func (e *Editor) Init() tea.Cmd {
+	return Init(e.model)
+}
+
+// Update implements tea.Model interface
+func (e *Editor) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	newModel, cmd := Update(e.model, msg)
+	e.model = newModel
+	return e, cmd
+}
+
+// View implements tea.Model interface
+func (e *Editor) View() string {
+	return View(e.model)
+}
+
+// GetView implements debug.ViewProvider interface
+func (e *Editor) GetView() string {
+	return e.View()
+}
+
+// SetSize sets the pane dimensions, for hosts that lay the editor out themselves
+func (e *Editor) SetSize(width, height int) {
+	e.Update(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Model returns the editor state
+func (e *Editor) Model() *types.Model {
+	return e.model
+}
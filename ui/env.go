@@ -0,0 +1,481 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// envRow is one variable at one level on the Env screen
+type envRow struct {
+	name  string
+	level string
+}
+
+// envRows lists every variable by name, then by LOCAL/REPO/USER level; EnvCursor indexes it
+func envRows(m *types.Model) []envRow {
+	var rows []envRow
+	for _, name := range envNames(m) {
+		for _, level := range envLevels(m, name) {
+			rows = append(rows, envRow{name: name, level: level})
+		}
+	}
+	return rows
+}
+
+// envNames returns the sorted names of the variables set at any level
+func envNames(m *types.Model) []string {
+	names := make(map[string]bool)
+	for _, level := range editableLevels {
+		for name := range getLevelByName(m, level).Env {
+			names[name] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(names))
+}
+
+// envLevels returns the levels that set a variable, in LOCAL/REPO/USER order
+func envLevels(m *types.Model, name string) []string {
+	var levels []string
+	for _, level := range editableLevels {
+		if _, ok := getLevelByName(m, level).Env[name]; ok {
+			levels = append(levels, level)
+		}
+	}
+	return levels
+}
+
+// selectedEnvRow returns the row under the cursor, clamping the cursor to the list
+func selectedEnvRow(m *types.Model) (envRow, bool) {
+	rows := envRows(m)
+	if len(rows) == 0 {
+		return envRow{}, false
+	}
+	m.EnvCursor = max(min(m.EnvCursor, len(rows)-1), 0)
+	return rows[m.EnvCursor], true
+}
+
+// selectEnvRow moves the cursor to a variable at a level, if it is listed
+func selectEnvRow(m *types.Model, name, level string) {
+	for i, row := range envRows(m) {
+		if row.name == name && row.level == level {
+			m.EnvCursor = i
+			return
+		}
+	}
+}
+
+// envChanged reports whether a level's variables differ from those loaded
+func envChanged(m *types.Model, level string) bool {
+	return !maps.Equal(getLevelByName(m, level).Env, m.OriginalEnv[level])
+}
+
+// hasEnvChanges reports whether any variable was edited or a cross-level duplicate resolved
+func hasEnvChanges(m *types.Model) bool {
+	return len(m.EnvKeepLevels) > 0 || slices.ContainsFunc(editableLevels, func(level string) bool {
+		return envChanged(m, level)
+	})
+}
+
+// resetEnv restores every level's variables as loaded
+func resetEnv(m *types.Model) {
+	for _, name := range editableLevels {
+		getLevelByName(m, name).Env = maps.Clone(m.OriginalEnv[name])
+	}
+	m.EnvKeepLevels = nil
+	m.EnvCursor = 0
+}
+
+// pruneEnvKeepLevels drops resolutions that no longer apply after an edit, so a save never
+// removes a variable from every level
+func pruneEnvKeepLevels(m *types.Model) {
+	for name, keep := range m.EnvKeepLevels {
+		levels := envLevels(m, name)
+		if len(levels) < 2 || !slices.Contains(levels, keep) {
+			delete(m.EnvKeepLevels, name)
+		}
+	}
+}
+
+// handleEnvNavigation moves the cursor through the variables of all levels
+func handleEnvNavigation(m *types.Model, key string) *types.Model {
+	switch key {
+	case keyUp, "k":
+		m.EnvCursor--
+	case keyDown, "j":
+		m.EnvCursor++
+	}
+	m.EnvCursor = max(min(m.EnvCursor, len(envRows(m))-1), 0)
+	return m
+}
+
+// handleEnvKey adds, edits, or deletes a variable on the Env screen
+func handleEnvKey(m *types.Model, key string) *types.Model {
+	row, ok := selectedEnvRow(m)
+	switch key {
+	case keyAdd:
+		level := types.LevelLocal
+		if ok {
+			level = row.level
+		}
+		m.ActiveModal = NewEnvModal("", "", level)
+	case keyEdit:
+		if !ok {
+			return rejectInput(m)
+		}
+		value := getLevelByName(m, row.level).Env[row.name]
+		m.ActiveModal = NewEnvModal(row.name, value, row.level)
+	case keyDelete:
+		if !ok {
+			return rejectInput(m)
+		}
+		delete(getLevelByName(m, row.level).Env, row.name)
+		pruneEnvKeepLevels(m)
+		m.EnvCursor = max(min(m.EnvCursor, len(envRows(m))-1), 0)
+	}
+	return m
+}
+
+// handleEnvKeep keeps the selected variable at the level of a number key, removing it from
+// the other levels on save
+func handleEnvKeep(m *types.Model, key string) *types.Model {
+	row, ok := selectedEnvRow(m)
+	if !ok {
+		return rejectInput(m)
+	}
+	levels := envLevels(m, row.name)
+	keepLevel := getTargetLevel(key)
+	if len(levels) < 2 || !slices.Contains(levels, keepLevel) {
+		return rejectInput(m)
+	}
+
+	if m.EnvKeepLevels == nil {
+		m.EnvKeepLevels = make(map[string]string)
+	}
+	m.EnvKeepLevels[row.name] = keepLevel
+	return m
+}
+
+// applyEnvEdit stores the variable entered in the env modal, keeping the modal open with an
+// error when the input is invalid
+func applyEnvEdit(m *types.Model, em *EnvModal) *types.Model {
+	name, value, err := em.Variable()
+	if err != nil {
+		em.SetError(err)
+		return m
+	}
+
+	level := getLevelByName(m, em.Level())
+	if _, exists := level.Env[name]; exists && name != em.original {
+		em.SetError(fmt.Errorf("%s is already set in %s", name, em.Level()))
+		return m
+	}
+
+	if level.Env == nil {
+		level.Env = make(map[string]string)
+	}
+	if em.original != "" {
+		delete(level.Env, em.original)
+	}
+	level.Env[name] = value
+	pruneEnvKeepLevels(m)
+	selectEnvRow(m, name, em.Level())
+	m.ActiveModal = nil
+	return m
+}
+
+// applyEnvResolutions removes each variable resolved to a kept level from the other levels
+func applyEnvResolutions(m *types.Model) {
+	for name, keep := range m.EnvKeepLevels {
+		for _, level := range envLevels(m, name) {
+			if level != keep {
+				delete(getLevelByName(m, level).Env, name)
+			}
+		}
+	}
+}
+
+// envAuditEntries returns an audit entry for each variable set, deleted, or removed in favor
+// of another level
+func envAuditEntries(m *types.Model, now time.Time) []audit.Entry {
+	var entries []audit.Entry
+	for _, level := range editableLevels {
+		current, original := getLevelByName(m, level).Env, m.OriginalEnv[level]
+		for _, name := range slices.Sorted(maps.Keys(current)) {
+			if value, ok := original[name]; !ok || value != current[name] {
+				entries = append(entries, audit.Entry{
+					Timestamp: now,
+					Rule:      name,
+					From:      level,
+					To:        level,
+					Action:    audit.ActionSetEnv,
+				})
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(original)) {
+			if _, ok := current[name]; !ok {
+				entries = append(entries, audit.Entry{
+					Timestamp: now,
+					Rule:      name,
+					From:      level,
+					Action:    audit.ActionDeleteEnv,
+				})
+			}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(m.EnvKeepLevels)) {
+		keep := m.EnvKeepLevels[name]
+		for _, level := range envLevels(m, name) {
+			if level != keep {
+				entries = append(entries, audit.Entry{
+					Timestamp: now,
+					Rule:      name,
+					From:      level,
+					To:        keep,
+					Action:    audit.ActionDeleteEnv,
+				})
+			}
+		}
+	}
+	return entries
+}
+
+// envChanges lists the environment variables set and removed
+func envChanges(m *types.Model) []types.PendingChange {
+	var changes []types.PendingChange
+	for _, entry := range envAuditEntries(m, time.Time{}) {
+		change := types.PendingChange{Kind: types.ChangeEnv, Rule: entry.Rule}
+		switch {
+		case entry.Action == audit.ActionSetEnv:
+			change.Level = entry.From
+			change.Line = fmt.Sprintf("• %s: Set in %s",
+				entry.Rule, getLevelStyledText(entry.From))
+		case entry.To != "":
+			change.From, change.To = []string{entry.From}, entry.To
+			change.Line = fmt.Sprintf("• %s: Remove from %s (keep in %s)",
+				entry.Rule, getLevelStyledText(entry.From), getLevelStyledText(entry.To))
+		default:
+			change.From = []string{entry.From}
+			change.Line = fmt.Sprintf("• %s: Remove from %s",
+				entry.Rule, getLevelStyledText(entry.From))
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// renderEnvStatusText describes the selected variable for the status bar
+func renderEnvStatusText(m *types.Model) string {
+	row, ok := selectedEnvRow(m)
+	if !ok {
+		return "No env variables in any level"
+	}
+	levels := envLevels(m, row.name)
+	if len(levels) < 2 {
+		return fmt.Sprintf("%s set in %s", row.name, row.level)
+	}
+	return fmt.Sprintf("%s set in %s (choose 1/2/3 to keep one)",
+		row.name, strings.Join(levels, ", "))
+}
+
+// envStatus describes whether a variable set at several levels is resolved
+func envStatus(m *types.Model, row envRow) string {
+	if len(envLevels(m, row.name)) < 2 {
+		return ""
+	}
+	switch keep := m.EnvKeepLevels[row.name]; keep {
+	case "":
+		return "duplicate, unresolved"
+	case row.level:
+		return "keep"
+	default:
+		return "remove (kept in " + keep + ")"
+	}
+}
+
+// visibleEnvLines returns how many lines fit inside the Env panel
+func (c *ContentComponent) visibleEnvLines() int {
+	vertical, _ := c.panelPadding()
+	lines := c.height - NormalBorderStyle.GetVerticalBorderSize() - 2*vertical
+	return max(lines, 1)
+}
+
+// renderEnvContent lists every variable with its level, value, and duplicate resolution
+func (c *ContentComponent) renderEnvContent() string {
+	if c.width <= 0 || c.height <= 0 {
+		return ""
+	}
+
+	m := c.model
+	contentWidth := max(c.getConsistentContentWidth(), 20)
+	rows := envRows(m)
+	if len(rows) == 0 {
+		return BlockingMessageStyle.
+			Width(contentWidth).
+			Height(c.height).
+			Render("No env variables in any level. Press " +
+				strings.ToUpper(keyAdd) + " to add one.")
+	}
+
+	_, horizontal := c.panelPadding()
+	rowWidth := contentWidth - NormalBorderStyle.GetHorizontalBorderSize() - 2*horizontal
+	rowStyle := lipgloss.NewStyle().MaxWidth(rowWidth)
+	format := "%-24s  %-6s  %-24s  %s"
+	lines := []string{TitleStyle.Render(fmt.Sprintf(format, "Name", "Level", "Value", "Status"))}
+
+	selected, _ := selectedEnvRow(m)
+	for _, row := range rows {
+		value := getLevelByName(m, row.level).Env[row.name]
+		line := fmt.Sprintf(format, row.name, row.level, value, envStatus(m, row))
+		if row == selected {
+			lines = append(lines, rowStyle.Render(SelectedItemStyle.Render("> "+line)))
+			continue
+		}
+		lines = append(lines, rowStyle.Render("  "+line))
+	}
+
+	// Scroll just far enough to keep the selected variable visible below the header
+	visible := c.visibleEnvLines() - 1
+	start := max(m.EnvCursor-visible+1, 0)
+	end := min(start+visible, len(rows))
+	body := append([]string{lines[0]}, lines[1+start:1+end]...)
+
+	return lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(c.height).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorBorderFocused)).
+		Padding(c.panelPadding()).
+		Render(strings.Join(body, "\n"))
+}
+
+// EnvModal implements types.Modal for adding or editing one variable as NAME=value
+type EnvModal struct {
+	original string // Name of the edited variable, "" when adding
+	level    string
+	input    string
+	err      string
+}
+
+// NewEnvModal creates the variable prompt; an empty name adds a variable to level
+func NewEnvModal(name, value, level string) *EnvModal {
+	em := &EnvModal{original: name, level: level}
+	if name != "" {
+		em.input = name + "=" + value
+	}
+	return em
+}
+
+// Level returns the level the variable is set in
+func (em *EnvModal) Level() string {
+	return em.level
+}
+
+// Variable parses the input as NAME=value
+func (em *EnvModal) Variable() (name, value string, err error) {
+	name, value, found := strings.Cut(em.input, "=")
+	name = strings.TrimSpace(name)
+	if !found || name == "" {
+		return "", "", errors.New("enter the variable as NAME=value")
+	}
+	if strings.ContainsAny(name, " \t") {
+		return "", "", fmt.Errorf("variable name %q contains whitespace", name)
+	}
+	return name, value, nil
+}
+
+// SetError shows err below the input, keeping the prompt open
+func (em *EnvModal) SetError(err error) {
+	em.err = err.Error()
+}
+
+// CapturesText reports that printable keys are typed into the variable input
+func (em *EnvModal) CapturesText() bool {
+	return true
+}
+
+// RenderModal renders the variable prompt
+func (em *EnvModal) RenderModal(width, height int) string {
+	contentWidth := min(80, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	title := "Add Env Variable"
+	if em.original != "" {
+		title = "Edit " + em.original
+	}
+	lines := []string{
+		titleStyle.Render(title),
+		"",
+		fmt.Sprintf("%s %s", AccentStyle.Render("Level:"), getLevelStyledText(em.level)),
+		fmt.Sprintf("%s %s█", AccentStyle.Render("Variable:"), em.input),
+		CountStyle.Render("NAME=value"),
+	}
+	if em.err != "" {
+		lines = append(lines, "", ErrorStyle.Render(em.err))
+	}
+
+	actions := []string{
+		formatFooterAction("ENTER", "Confirm"),
+		formatFooterAction("ESC", "Cancel"),
+	}
+	if em.original == "" {
+		actions = append([]string{formatFooterAction("TAB", "Change level")}, actions...)
+	}
+	instructions := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(contentWidth-4).
+		Padding(1, 0, 0, 0).
+		Render(joinFooterActions(actions))
+	lines = append(lines, instructions)
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// HandleInput processes keyboard input for the variable prompt; TAB cycles the level of a
+// new variable
+func (em *EnvModal) HandleInput(key string) (handled bool, result interface{}) {
+	switch key {
+	case keyEnter:
+		return true, "env_set"
+	case keyEscapeLong, keyEscape:
+		return true, "cancel"
+	case "tab":
+		if em.original == "" {
+			next := (slices.Index(editableLevels, em.level) + 1) % len(editableLevels)
+			em.level = editableLevels[next]
+		}
+	case "backspace":
+		if runes := []rune(em.input); len(runes) > 0 {
+			em.input = string(runes[:len(runes)-1])
+		}
+	case "space":
+		em.input += " "
+	default:
+		if len([]rune(key)) == 1 {
+			em.input += key
+		}
+	}
+	em.err = ""
+	return false, nil
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"slices"
+
+	"claude-permissions/types"
+)
+
+// Keys moving focus; TAB and shift+TAB move between screens, the others between the regions
+// of the current screen
+const (
+	keyNextScreen = "tab"
+	keyPrevScreen = "shift+tab"
+	keyFocusLeft  = "left"
+	keyFocusRight = "right"
+)
+
+// focusRing is implemented by screens split into regions that take the cursor keys in turn,
+// such as the Organization columns. Focus is tracked by the screen's own model fields, so it
+// survives modals: while one is open it captures every key, and on closing the keys return
+// to the region that had them.
+type focusRing interface {
+	// Regions names the screen's regions in traversal order
+	Regions(m *types.Model) []string
+
+	// Focus returns the index of the focused region
+	Focus(m *types.Model) int
+
+	// SetFocus focuses the region, rejecting it when the region cannot take focus
+	SetFocus(m *types.Model, region int) *types.Model
+}
+
+// isFocusKey reports whether key moves focus between screens or regions
+func isFocusKey(key string) bool {
+	switch key {
+	case keyNextScreen, keyPrevScreen, keyFocusLeft, keyFocusRight, keyFocusLocal, keyFocusRepo,
+		keyFocusUser:
+		return true
+	}
+	return false
+}
+
+// handleFocusKey moves focus for a key isFocusKey accepts
+func handleFocusKey(m *types.Model, key string) *types.Model {
+	switch key {
+	case keyNextScreen:
+		return switchScreen(m, 1)
+	case keyPrevScreen:
+		return switchScreen(m, -1)
+	case keyFocusLeft:
+		return moveFocus(m, -1)
+	case keyFocusRight:
+		return moveFocus(m, 1)
+	case keyFocusLocal:
+		return focusRegion(m, 0)
+	case keyFocusRepo:
+		return focusRegion(m, 1)
+	default:
+		return focusRegion(m, 2)
+	}
+}
+
+// switchScreen moves delta screens along screenOrder, wrapping around and skipping screens
+// that are not available
+func switchScreen(m *types.Model, delta int) *types.Model {
+	count := len(screenOrder)
+	// An unknown screen has index -1, so TAB goes to the first screen
+	index := slices.Index(screenOrder, m.CurrentScreen)
+	for range screenOrder {
+		index = (index + delta + count) % count
+		screen := screens[screenOrder[index]]
+		if optional, ok := screen.(optionalScreen); ok && !optional.Available(m) {
+			continue
+		}
+		m.CurrentScreen = screenOrder[index]
+		screen.Init(m)
+		break
+	}
+	return m
+}
+
+// moveFocus moves delta regions along the current screen's ring, stopping at its ends
+func moveFocus(m *types.Model, delta int) *types.Model {
+	ring, ok := currentScreen(m).(focusRing)
+	if !ok {
+		return rejectInput(m)
+	}
+	target := ring.Focus(m) + delta
+	if target < 0 || target >= len(ring.Regions(m)) {
+		return m
+	}
+	return ring.SetFocus(m, target)
+}
+
+// focusRegion focuses a region of the current screen directly
+func focusRegion(m *types.Model, region int) *types.Model {
+	ring, ok := currentScreen(m).(focusRing)
+	if !ok || region >= len(ring.Regions(m)) {
+		return rejectInput(m)
+	}
+	return ring.SetFocus(m, region)
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"claude-permissions/audit"
+	"claude-permissions/gitrepo"
+	"claude-permissions/logging"
+	"claude-permissions/types"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// actionGitCommit is the small modal action that commits the repo settings file
+const actionGitCommit = "git_commit"
+
+// gitCommitDoneMsg reports that the git commit of the repo settings file has finished
+type gitCommitDoneMsg struct {
+	commit *types.RepoCommit
+	err    error
+}
+
+// offerRepoCommit follows a save that wrote the repo settings file with a git commit of it,
+// asking first unless --git-commit was given
+func offerRepoCommit(m *types.Model, save pendingSave) (*types.Model, tea.Cmd) {
+	m.PendingCommit = nil
+	savedRepo := slices.ContainsFunc(save.levels, func(level types.SettingsLevel) bool {
+		return level.Name == types.LevelRepo
+	})
+	if !savedRepo || !gitrepo.Contains(m.ProjectRoot, m.RepoLevel.Path) {
+		return m, nil
+	}
+
+	m.PendingCommit = &types.RepoCommit{
+		Root:    m.ProjectRoot,
+		Path:    m.RepoLevel.Path,
+		Message: repoCommitMessage(repoRelPath(m.ProjectRoot, m.RepoLevel.Path), save.auditEntries),
+	}
+	if m.GitCommit {
+		return commitRepoSettings(m)
+	}
+	m.ActiveModal = NewSmallModal(
+		"Commit Repo Settings",
+		"Commit the repo settings file to git with this message?\n\n"+m.PendingCommit.Message,
+		actionGitCommit,
+	)
+	return m, nil
+}
+
+// repoCommitMessage lists the changes of entries that touched the repo settings file at
+// rel, one per line in History screen wording
+func repoCommitMessage(rel string, entries []audit.Entry) string {
+	lines := []string{"Update Claude Code permissions in " + rel}
+	for _, entry := range entries {
+		if entryLevel(entry) != types.LevelRepo && entry.To != types.LevelRepo {
+			continue
+		}
+		if len(lines) == 1 {
+			lines = append(lines, "")
+		}
+		action := historyActionLabels[entry.Action]
+		if action == "" {
+			action = entry.Action
+		}
+		lines = append(lines, fmt.Sprintf("- %s %s (%s → %s)", action, entry.Rule, entry.From,
+			entry.To))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// repoRelPath returns path relative to the repository root, or path itself when it is not
+// below root
+func repoRelPath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// commitRepoSettings starts the pending repo settings commit, returning the command that runs
+// git off the update loop, since hooks and signing can take a while
+func commitRepoSettings(m *types.Model) (*types.Model, tea.Cmd) {
+	commit := m.PendingCommit
+	m.PendingCommit = nil
+	if commit == nil {
+		return m, nil
+	}
+	return m, func() tea.Msg {
+		err := gitrepo.Commit(commit.Root, commit.Message, commit.Path)
+		return gitCommitDoneMsg{commit: commit, err: err}
+	}
+}
+
+// handleGitCommitDone reports how the repo settings commit went. The file is already saved,
+// so a failed commit only warns.
+func handleGitCommitDone(m *types.Model, msg gitCommitDoneMsg) *types.Model {
+	log := logFor(m, logging.ComponentSave)
+	rel := repoRelPath(msg.commit.Root, msg.commit.Path)
+	if msg.err != nil {
+		log.Warn("git_commit_failed", logging.Path(msg.commit.Path), logging.Err(msg.err))
+		m.Notifications.Warn("%s not committed: %v", rel, msg.err)
+		return m
+	}
+	log.Info("git_committed", logging.Path(msg.commit.Path))
+	m.Notifications.Info("Committed %s", rel)
+	return m
+}
@@ -0,0 +1,263 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"claude-permissions/gitrepo"
+	"claude-permissions/keymap"
+	"claude-permissions/settingsfile"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/bubbles/v2/viewport"
+)
+
+// keyGitDiff compares the repo settings file, pending changes included, with git HEAD
+const keyGitDiff = "H"
+
+// cloneLevel copies a level deeply enough that applying pending changes to the copy leaves
+// the original untouched
+func cloneLevel(level types.SettingsLevel) types.SettingsLevel {
+	level.Permissions = slices.Clone(level.Permissions)
+	level.Deny = slices.Clone(level.Deny)
+	level.Hooks = slices.Clone(level.Hooks)
+	level.Directories = slices.Clone(level.Directories)
+	level.Env = maps.Clone(level.Env)
+	level.Provenance = maps.Clone(level.Provenance)
+	level.Disabled = maps.Clone(level.Disabled)
+	if level.Modes != nil {
+		modes := *level.Modes
+		level.Modes = &modes
+	}
+	return level
+}
+
+// pendingLevel returns the named level as a save would write it
+func pendingLevel(m *types.Model, name string) types.SettingsLevel {
+	return pendingLevels(m)[name]
+}
+
+// pendingLevels returns the editable levels as a save would write them, keyed by name,
+// applying the pending changes to copies of the levels and restoring the originals afterwards
+func pendingLevels(m *types.Model) map[string]types.SettingsLevel {
+	levels := []*types.SettingsLevel{&m.LocalLevel, &m.RepoLevel, &m.UserLevel}
+	originals := make([]types.SettingsLevel, len(levels))
+	for i, level := range levels {
+		originals[i] = *level
+		*level = cloneLevel(*level)
+	}
+	defer func() {
+		for i, level := range levels {
+			*level = originals[i]
+		}
+	}()
+
+	applyPendingChanges(m)
+	pending := make(map[string]types.SettingsLevel, len(levels))
+	for _, level := range levels {
+		pending[level.Name] = *level
+	}
+	return pending
+}
+
+// readLevelFile parses the settings file at path as it is on disk; a missing file yields
+// an empty level
+func readLevelFile(name, path string) (types.SettingsLevel, error) {
+	level := types.SettingsLevel{Name: name, Path: path}
+	data, err := os.ReadFile(path) // #nosec G304 - the level's own settings file
+	if errors.Is(err, os.ErrNotExist) {
+		return level, nil
+	}
+	if err != nil {
+		return level, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return level, settingsfile.Parse(&level, data)
+}
+
+// committedLevel parses the settings file at path as committed at git HEAD. A file that
+// was never committed yields an empty level and reports false.
+func committedLevel(root, name, path string) (types.SettingsLevel, bool, error) {
+	level := types.SettingsLevel{Name: name, Path: path}
+	data, err := gitrepo.Show(root, path)
+	if errors.Is(err, gitrepo.ErrNotCommitted) {
+		return level, false, nil
+	}
+	if err != nil {
+		return level, false, err
+	}
+	return level, true, settingsfile.Parse(&level, data)
+}
+
+// levelChanges lists what changed between two versions of a level: added (+), removed (-),
+// and changed (~) rules, directories, hooks, env variables, and modes. Env values are left
+// out as they may be secrets.
+func levelChanges(before, after types.SettingsLevel) []string {
+	var lines []string
+	listed := func(kind string, before, after []string) {
+		added, removed := diffStrings(before, after)
+		for _, item := range added {
+			lines = append(lines, SuccessStyle.Render(fmt.Sprintf("+ %s %s", kind, item)))
+		}
+		for _, item := range removed {
+			lines = append(lines, ErrorStyle.Render(fmt.Sprintf("- %s %s", kind, item)))
+		}
+	}
+	listed("allow", before.Permissions, after.Permissions)
+	listed("deny", before.Deny, after.Deny)
+	listed("directory", before.Directories, after.Directories)
+
+	hookLabels := make(map[string]string)
+	hookKeys := func(hooks []types.Hook) []string {
+		keys := make([]string, 0, len(hooks))
+		for _, h := range hooks {
+			hookLabels[hookKey(h)] = hookLabel(h)
+			keys = append(keys, hookKey(h))
+		}
+		return keys
+	}
+	added, removed := diffStrings(hookKeys(before.Hooks), hookKeys(after.Hooks))
+	for _, key := range added {
+		lines = append(lines, SuccessStyle.Render("+ hook "+hookLabels[key]))
+	}
+	for _, key := range removed {
+		lines = append(lines, ErrorStyle.Render("- hook "+hookLabels[key]))
+	}
+
+	names := slices.Collect(maps.Keys(before.Env))
+	for name := range after.Env {
+		if _, ok := before.Env[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		old, inBefore := before.Env[name]
+		value, inAfter := after.Env[name]
+		switch {
+		case !inBefore:
+			lines = append(lines, SuccessStyle.Render("+ env "+name))
+		case !inAfter:
+			lines = append(lines, ErrorStyle.Render("- env "+name))
+		case old != value:
+			lines = append(lines, WarningStyle.Render("~ env "+name))
+		}
+	}
+
+	oldModes, newModes := before.CurrentModes(), after.CurrentModes()
+	if oldModes.DefaultMode != newModes.DefaultMode {
+		lines = append(lines, WarningStyle.Render(fmt.Sprintf("~ defaultMode %s → %s",
+			modeText(oldModes.DefaultMode, modeColumnDefault),
+			modeText(newModes.DefaultMode, modeColumnDefault))))
+	}
+	if oldModes.DisableBypass != newModes.DisableBypass {
+		lines = append(lines, WarningStyle.Render(fmt.Sprintf(
+			"~ disableBypassPermissionsMode %s → %s",
+			modeText(oldModes.DisableBypass, modeColumnDisableBypass),
+			modeText(newModes.DisableBypass, modeColumnDisableBypass))))
+	}
+	return lines
+}
+
+// diffStrings returns the items only in after (added) and only in before (removed), each
+// in its list's order
+func diffStrings(before, after []string) ([]string, []string) {
+	var added, removed []string
+	for _, item := range after {
+		if !slices.Contains(before, item) {
+			added = append(added, item)
+		}
+	}
+	for _, item := range before {
+		if !slices.Contains(after, item) {
+			removed = append(removed, item)
+		}
+	}
+	return added, removed
+}
+
+// gitDiffSection renders one comparison under its heading
+func gitDiffSection(heading string, changes []string) []string {
+	lines := []string{AccentStyle.Render(heading)}
+	if len(changes) == 0 {
+		return append(lines, CountStyle.Render("  No differences"), "")
+	}
+	for _, change := range changes {
+		lines = append(lines, "  "+change)
+	}
+	return append(lines, "")
+}
+
+// repoGitDiff describes how the repo settings file differs from git HEAD, split into the
+// changes already on disk, such as a colleague's edits pulled in or made outside the editor,
+// and the pending changes the next save would write
+func repoGitDiff(m *types.Model) (string, error) {
+	path := m.RepoLevel.Path
+	committed, tracked, err := committedLevel(m.ProjectRoot, types.LevelRepo, path)
+	if err != nil {
+		return "", err
+	}
+	onDisk, err := readLevelFile(types.LevelRepo, path)
+	if err != nil {
+		return "", err
+	}
+
+	lines := []string{repoRelPath(m.ProjectRoot, path), ""}
+	if !tracked {
+		lines = append(lines, WarningStyle.Render(
+			"Not committed at HEAD yet; compared with an empty file"), "")
+	}
+	lines = append(lines, gitDiffSection("HEAD → on disk (uncommitted changes)",
+		levelChanges(committed, onDisk))...)
+	lines = append(lines, gitDiffSection("On disk → after save (your pending changes)",
+		levelChanges(onDisk, pendingLevel(m, types.LevelRepo)))...)
+	return strings.Join(lines, "\n"), nil
+}
+
+// openGitDiff shows how the repo settings file differs from git HEAD; projects outside a
+// git repository have nothing to compare with
+func openGitDiff(m *types.Model) *types.Model {
+	if m.RepoLevel.Path == "" || !gitrepo.Contains(m.ProjectRoot, m.RepoLevel.Path) {
+		return rejectInput(m)
+	}
+	body, err := repoGitDiff(m)
+	if err != nil {
+		m.Notifications.Warn("Cannot compare with HEAD: %v", err)
+		return m
+	}
+	m.ActiveModal = NewGitDiffModal(m.Keys, body)
+	return m
+}
+
+// GitDiffModal implements types.Modal for the comparison of the repo settings file with
+// git HEAD
+type GitDiffModal struct {
+	keys     keymap.KeyMap
+	viewport viewport.Model
+}
+
+// NewGitDiffModal creates a scrollable view of a comparison
+func NewGitDiffModal(keys keymap.KeyMap, body string) *GitDiffModal {
+	gm := &GitDiffModal{keys: keys, viewport: viewport.New()}
+	gm.viewport.SetContent(body)
+	return gm
+}
+
+// RenderModal renders the comparison as a full-screen scrollable page
+func (gm *GitDiffModal) RenderModal(width, height int) string {
+	return renderScrollableModal(&gm.viewport, "Repo Settings vs HEAD", width, height)
+}
+
+// HandleInput scrolls the comparison and closes it on ESC, ENTER, or the diff key
+func (gm *GitDiffModal) HandleInput(key string) (handled bool, result interface{}) {
+	if handled, result, ok := scrollModalInput(&gm.viewport, key); ok {
+		return handled, result
+	}
+	if gm.keys.Action(key) == keymap.GitDiff {
+		return true, "cancel"
+	}
+	return false, nil
+}
@@ -0,0 +1,263 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"claude-permissions/keymap"
+
+	"github.com/charmbracelet/bubbles/v2/viewport"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// helpEntry describes one action in the help overlay
+type helpEntry struct {
+	actions     []string
+	description string
+}
+
+// helpSection groups the actions available on one screen
+type helpSection struct {
+	title   string
+	entries []helpEntry
+}
+
+// helpSections lists every bindable action, grouped by the screen where it applies
+var helpSections = []helpSection{
+	{
+		title: "Everywhere",
+		entries: []helpEntry{
+			{[]string{keymap.SwitchScreen}, "Cycle through the screens"},
+			{[]string{keymap.PrevScreen}, "Cycle back through the screens"},
+			{[]string{keymap.SwitchProject}, "Switch project"},
+			{[]string{keymap.Suggest}, "Review suggestions to consolidate narrow rules"},
+			{[]string{keymap.Stale}, "Review rules whose scripts or paths no longer exist"},
+			{[]string{keymap.Modes}, "Edit defaultMode and disableBypassPermissionsMode"},
+			{[]string{keymap.GitDiff}, "Compare the repo settings file with git HEAD"},
+			{[]string{keymap.Export}, "Export all levels to a permission set file"},
+			{[]string{keymap.Import}, "Import a permission set file into its levels"},
+			{[]string{keymap.Presets}, "Add a Go, Node, Python, or Rust preset to a level"},
+			{[]string{keymap.Profiles}, "Save the rules as a named profile or restore one"},
+			{[]string{keymap.Docs}, "Explain how the selected rule's tool is matched"},
+			{[]string{keymap.ToggleDensity}, "Toggle compact/comfortable density"},
+			{[]string{keymap.DismissHint}, "Dismiss the current tip or notification"},
+			{[]string{keymap.Help}, "Show this help"},
+			{[]string{keymap.Quit}, "Quit"},
+		},
+	},
+	{
+		title: "Organization",
+		entries: []helpEntry{
+			{[]string{keymap.Up, keymap.Down}, "Move within the focused column"},
+			{[]string{keymap.Top, keymap.Bottom}, "Jump to the top or bottom of the column"},
+			{[]string{keymap.HalfPageDown, keymap.HalfPageUp}, "Move down or up half a page"},
+			{[]string{keymap.Jump}, "Jump to the next rule starting with the letter typed next"},
+			{[]string{keymap.Inspect}, "Inspect, edit, delete, or copy the selected rule"},
+			{[]string{keymap.Copy}, "Copy the selected rule to the clipboard"},
+			{[]string{keymap.Paste}, "Paste a rule from the clipboard into the column"},
+			{[]string{keymap.Left, keymap.Right}, "Move between columns"},
+			{
+				[]string{keymap.FocusLocal, keymap.FocusRepo, keymap.FocusUser},
+				"Focus the LOCAL/REPO/USER column",
+			},
+			{
+				[]string{keymap.MoveLocal, keymap.MoveRepo, keymap.MoveUser},
+				"Move the selected rule to LOCAL/REPO/USER",
+			},
+			{[]string{keymap.Save}, "Review and save changes"},
+			{[]string{keymap.Reset}, "Reset all changes"},
+		},
+	},
+	{
+		title: "Duplicates",
+		entries: []helpEntry{
+			{[]string{keymap.Up, keymap.Down}, "Move between duplicates"},
+			{[]string{keymap.Left, keymap.Right}, "Move between duplicates and contradictions"},
+			{
+				[]string{keymap.MoveLocal, keymap.MoveRepo, keymap.MoveUser},
+				"Keep the selected rule in LOCAL/REPO/USER",
+			},
+			{[]string{keymap.BulkResolve}, "Resolve every remaining duplicate at once"},
+			{[]string{keymap.AllowWins}, "Keep the allow side of the selected contradiction"},
+			{[]string{keymap.DenyWins}, "Keep the deny side of the selected contradiction"},
+			{[]string{keymap.Save}, "Review and save changes"},
+			{[]string{keymap.Reset}, "Reset all changes"},
+		},
+	},
+	{
+		title: "Hooks",
+		entries: []helpEntry{
+			{[]string{keymap.Up, keymap.Down}, "Move between hooks"},
+			{
+				[]string{keymap.MoveLocal, keymap.MoveRepo, keymap.MoveUser},
+				"Move the selected hook to LOCAL/REPO/USER",
+			},
+			{[]string{keymap.MoveHookUp}, "Run the selected hook earlier"},
+			{[]string{keymap.MoveHookDown}, "Run the selected hook later"},
+			{[]string{keymap.Save}, "Review and save changes"},
+			{[]string{keymap.Reset}, "Reset all changes"},
+		},
+	},
+	{
+		title: "Env",
+		entries: []helpEntry{
+			{[]string{keymap.Up, keymap.Down}, "Move between variables"},
+			{[]string{keymap.Add}, "Add a variable"},
+			{[]string{keymap.Edit}, "Edit the selected variable"},
+			{[]string{keymap.Delete}, "Delete the selected variable"},
+			{
+				[]string{keymap.MoveLocal, keymap.MoveRepo, keymap.MoveUser},
+				"Keep a variable set at several levels in LOCAL/REPO/USER",
+			},
+			{[]string{keymap.Save}, "Review and save changes"},
+			{[]string{keymap.Reset}, "Reset all changes"},
+		},
+	},
+	{
+		title: "Directories",
+		entries: []helpEntry{
+			{[]string{keymap.Up, keymap.Down}, "Move between additional directories"},
+			{[]string{keymap.Add}, "Add a directory"},
+			{[]string{keymap.Edit}, "Edit the selected directory"},
+			{[]string{keymap.Delete}, "Remove the selected directory"},
+			{
+				[]string{keymap.MoveLocal, keymap.MoveRepo, keymap.MoveUser},
+				"Move the selected directory to LOCAL/REPO/USER",
+			},
+			{[]string{keymap.Save}, "Review and save changes"},
+			{[]string{keymap.Reset}, "Reset all changes"},
+		},
+	},
+	{
+		title: "Policy",
+		entries: []helpEntry{
+			{[]string{keymap.Up, keymap.Down}, "Scroll through policy violations"},
+		},
+	},
+	{
+		title: "History",
+		entries: []helpEntry{
+			{[]string{keymap.Up, keymap.Down}, "Scroll through saved changes"},
+		},
+	},
+}
+
+// HelpModal implements types.Modal for the full-screen list of key bindings
+type HelpModal struct {
+	keys     keymap.KeyMap
+	viewport viewport.Model
+}
+
+// NewHelpModal creates a help overlay listing the bindings of keys
+func NewHelpModal(keys keymap.KeyMap) *HelpModal {
+	hm := &HelpModal{keys: keys, viewport: viewport.New()}
+	hm.viewport.SetContent(hm.renderSections())
+	return hm
+}
+
+// renderSections renders every help section with all keys bound to each action
+func (hm *HelpModal) renderSections() string {
+	keyStyle := AccentStyle.Width(20)
+	headingStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorTitle))
+
+	var lines []string
+	for i, section := range helpSections {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, headingStyle.Render(section.title))
+		for _, entry := range section.entries {
+			lines = append(lines, "  "+keyStyle.Render(hm.entryKeys(entry))+entry.description)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// entryKeys lists all keys of an entry's actions, e.g. "↑ K / ↓ J"
+func (hm *HelpModal) entryKeys(entry helpEntry) string {
+	groups := make([]string, 0, len(entry.actions))
+	for _, action := range entry.actions {
+		keys := hm.keys.Keys(action)
+		labels := make([]string, 0, len(keys))
+		for _, key := range keys {
+			label, isArrow := arrowLabels[key]
+			if !isArrow {
+				label = displayKey(key)
+			}
+			labels = append(labels, label)
+		}
+		groups = append(groups, strings.Join(labels, " "))
+	}
+	return strings.Join(groups, " / ")
+}
+
+// RenderModal renders the help as a full-screen scrollable list
+func (hm *HelpModal) RenderModal(width, height int) string {
+	return renderScrollableModal(&hm.viewport, "Key Bindings", width, height)
+}
+
+// renderScrollableModal renders a full-screen titled viewport with a scroll indicator
+func renderScrollableModal(vp *viewport.Model, title string, width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Align(lipgloss.Center).
+		Width(width).
+		Padding(1)
+
+	// Title (3) + border (2) + footer (1)
+	vp.SetHeight(max(height-6, 3))
+	vp.SetWidth(width - 4)
+	// Re-clamp the offset, which may be past the bottom after the terminal grew
+	vp.SetYOffset(vp.YOffset)
+
+	contentStyle := lipgloss.NewStyle().
+		Width(width).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorBorderNormal)).
+		Background(lipgloss.Color(ColorBackground)).
+		Padding(0, 1)
+	content := contentStyle.Render(vp.View())
+
+	actions := []string{formatFooterAction("ESC", "Close")}
+	if !vp.AtTop() || !vp.AtBottom() {
+		scrolled := fmt.Sprintf("Scroll (%d%%)", int(vp.ScrollPercent()*100))
+		actions = append([]string{formatFooterAction("↑↓", scrolled)}, actions...)
+	}
+	footer := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(width).
+		Render(joinFooterActions(actions))
+
+	return lipgloss.JoinVertical(lipgloss.Top, titleStyle.Render(title), content, footer)
+}
+
+// HandleInput scrolls the help and closes it on ESC, ENTER, or the help key
+func (hm *HelpModal) HandleInput(key string) (handled bool, result interface{}) {
+	if handled, result, ok := scrollModalInput(&hm.viewport, key); ok {
+		return handled, result
+	}
+	if hm.keys.Action(key) == keymap.Help {
+		return true, "cancel"
+	}
+	return false, nil
+}
+
+// scrollModalInput scrolls vp and closes on ESC or ENTER; ok is false for other keys
+func scrollModalInput(vp *viewport.Model, key string) (handled bool, result interface{}, ok bool) {
+	switch key {
+	case keyUp, "k":
+		vp.LineUp(1)
+	case keyDown, "j":
+		vp.LineDown(1)
+	case "pgup":
+		vp.ViewUp()
+	case "pgdown", "space":
+		vp.ViewDown()
+	case keyEnter, keyEscapeLong, keyEscape:
+		return true, "cancel", true
+	default:
+		return false, nil, false
+	}
+	return false, nil, true
+}
@@ -2,9 +2,13 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"claude-permissions/config"
 	"claude-permissions/debug"
+	"claude-permissions/keymap"
+	"claude-permissions/logging"
 	"claude-permissions/types"
 
 	"github.com/charmbracelet/bubbles/v2/table"
@@ -16,32 +20,109 @@ import (
 func handleKeyPress(m *types.Model, msg tea.KeyMsg) (*types.Model, tea.Cmd) {
 	key := msg.String()
 
-	if key == "q" || key == "ctrl+c" {
+	// A save in progress holds every key but ctrl+c until its files are written
+	if _, saving := m.ActiveModal.(*SaveProgressModal); saving && key != "ctrl+c" {
+		return m, nil
+	}
+	if key == "ctrl+c" || (m.Keys.Action(key) == keymap.Quit && !capturesText(m.ActiveModal)) {
 		return m, tea.Quit
 	}
 
-	// Handle modal input first if modal is shown
+	// Handle modal input first if modal is shown; modals use fixed keys
+	if loadErrorModal, ok := m.ActiveModal.(*LoadErrorModal); ok {
+		return handleLoadErrorInput(m, loadErrorModal, key)
+	}
+	if inspector, ok := m.ActiveModal.(*InspectorModal); ok {
+		return handleInspectorInput(m, inspector, key)
+	}
 	if m.ActiveModal != nil {
-		return handleActiveModalInput(m, key), nil
+		return handleActiveModalInput(m, key)
+	}
+
+	// Letters jump to a rule; after the jump key any letter does, even a bound one
+	if m.JumpPending || isJumpLetter(m, key) {
+		return handleJumpToLetter(m, key), nil
 	}
 
-	return handleNonModalKeys(m, msg, key)
+	return handleNonModalKeys(m, canonicalKey(m, key))
 }
 
-// handleNonModalKeys handles key input when no modal is shown
-func handleNonModalKeys(m *types.Model, msg tea.KeyMsg, key string) (*types.Model, tea.Cmd) {
-	if key == "tab" {
-		return handleTabKey(m), nil
+// handleNonModalKeys handles key input when no modal is shown. Keys arrive translated to
+// their default binding, so remapped keys behave like the keys they replace.
+func handleNonModalKeys(m *types.Model, key string) (*types.Model, tea.Cmd) {
+	if isFocusKey(key) {
+		return handleFocusKey(m, key), nil
+	}
+
+	if key == keyDismissHint && m.ActiveHint != "" {
+		dismissHint(m)
+		return m, nil
+	}
+
+	if key == keyDismissHint && m.Notifications.Len() > 0 {
+		m.Notifications.Dismiss()
+		return m, nil
+	}
+
+	if key == keyToggleDensity {
+		return handleDensityToggle(m), nil
+	}
+
+	if key == keyHelp {
+		m.ActiveModal = NewHelpModal(m.Keys)
+		return m, nil
+	}
+
+	if key == keySwitchProject && m.ProjectLoader != nil {
+		return openProjectSwitcher(m), nil
 	}
 
-	// Handle ESC key for reset functionality on permissions screen
-	if key == keyEscapeLong || key == keyEscape || msg.Key().Code == tea.KeyEscape {
-		return handleEscapeKey(m), nil
+	if key == keyExport || key == keyImport {
+		return openPermsetPrompt(m, key), nil
 	}
 
-	// Handle ENTER key for confirmation screen transition
-	if key == keyEnter || msg.Key().Code == tea.KeyEnter {
-		return handleEnterKey(m), nil
+	if key == keyPresets {
+		return openPresets(m), nil
+	}
+
+	if key == keyProfiles {
+		return openProfiles(m), nil
+	}
+
+	if key == keyDocs {
+		return openDocs(m), nil
+	}
+
+	if key == keySuggest {
+		return openSuggestions(m), nil
+	}
+
+	if key == keyStale {
+		return openStaleReview(m), nil
+	}
+
+	if key == keyModes {
+		return openModes(m), nil
+	}
+
+	if key == keyGitDiff {
+		return openGitDiff(m), nil
+	}
+
+	if key == keyCopy {
+		return handleCopyKey(m)
+	}
+
+	if key == keyPaste {
+		return handlePasteKey(m)
+	}
+
+	if key == keyInspect {
+		return openInspector(m), nil
+	}
+
+	if isMotionKey(key) {
+		return handleColumnMotion(m, key), nil
 	}
 
 	// Handle number keys for moving permissions
@@ -49,94 +130,82 @@ func handleNonModalKeys(m *types.Model, msg tea.KeyMsg, key string) (*types.Mode
 		return handleNumberKeys(m, key), nil
 	}
 
-	return handleNavigationKeys(m, key), nil
+	// The remaining keys, such as the cursor keys, ENTER, and ESC, depend on the screen
+	return currentScreen(m).Update(m, key), nil
 }
 
-// handleEnterKey handles ENTER key based on current screen
-func handleEnterKey(m *types.Model) *types.Model {
-	switch m.CurrentScreen {
-	case types.ScreenDuplicates, types.ScreenOrganization:
-		// Launch confirm changes modal if there are pending changes
-		if hasPendingChanges(m) {
-			m.ActiveModal = NewConfirmChangesModal(m)
-		}
+// handleDensityToggle switches between comfortable and compact density and persists the choice
+func handleDensityToggle(m *types.Model) *types.Model {
+	if m.Config == nil {
+		return m
 	}
-	return m
-}
 
-// handleTabKey switches between screens
-func handleTabKey(m *types.Model) *types.Model {
-	if m.CurrentScreen == types.ScreenDuplicates {
-		m.CurrentScreen = types.ScreenOrganization
+	if m.Config.Display.Density == config.DensityCompact {
+		m.Config.Display.Density = config.DensityComfortable
 	} else {
-		m.CurrentScreen = types.ScreenDuplicates
+		m.Config.Display.Density = config.DensityCompact
 	}
-	return m
-}
 
-// handleNavigationKeys handles navigation and passes through to components
-func handleNavigationKeys(m *types.Model, key string) *types.Model {
-	switch key {
-	case keyUp, "k":
-		return handleUpDownNavigation(m, key)
-	case keyDown, "j":
-		return handleUpDownNavigation(m, key)
-	case "left", "h":
-		return handleLeftNavigation(m)
-	case "right", "l":
-		return handleRightNavigation(m)
+	if err := m.Config.Save(); err != nil {
+		logFor(m, logging.ComponentConfig).Warn("config_save_failed",
+			logging.Path(m.Config.Path()), logging.Err(err))
+		m.Notifications.Warn("Density not saved: %v", err)
 	}
 	return m
 }
 
-// handleLeftNavigation handles left arrow navigation
-func handleLeftNavigation(m *types.Model) *types.Model {
-	if m.CurrentScreen == types.ScreenOrganization && m.FocusedColumn > 0 {
-		// Block navigation if there are unresolved duplicates
-		if hasUnresolvedDuplicates(m) {
-			return m
-		}
-		m.FocusedColumn--
+// openProjectSwitcher shows the project switcher with recently opened projects
+func openProjectSwitcher(m *types.Model) *types.Model {
+	var recent []string
+	if m.StateStore != nil {
+		recent = m.StateStore.RecentProjects()
 	}
+	m.ActiveModal = NewProjectModal(m.ProjectRoot, recent, hasPendingChanges(m))
 	return m
 }
 
-// handleRightNavigation handles right arrow navigation
-func handleRightNavigation(m *types.Model) *types.Model {
-	if m.CurrentScreen == types.ScreenOrganization && m.FocusedColumn < 2 {
-		// Block navigation if there are unresolved duplicates
-		if hasUnresolvedDuplicates(m) {
-			return m
-		}
-		m.FocusedColumn++
+// switchProject reloads all levels for the selected project, keeping the switcher open on failure
+func switchProject(m *types.Model, pm *ProjectModal) *types.Model {
+	if err := m.ProjectLoader(m, pm.Selected()); err != nil {
+		logFor(m, logging.ComponentProject).Warn("project_switch_failed",
+			logging.Project(pm.Selected()), logging.Err(err))
+		pm.SetError(err)
+		return m
+	}
+	m.ActiveModal = nil
+	m.ActiveHint = ""
+	m.SessionDirty = false
+	m.Notifications.Info("Opened %s", m.ProjectRoot)
+	OfferLoadErrors(m)
+	if m.ActiveModal == nil {
+		OfferSessionRestore(m)
+	}
+	if m.ActiveModal == nil {
+		OfferExpiredPurge(m)
 	}
 	return m
 }
 
-// handleNumberKeys handles 1/2/3 keys for moving permissions or resolving duplicates
-func handleNumberKeys(m *types.Model, key string) *types.Model {
-	switch m.CurrentScreen {
-	case types.ScreenDuplicates:
-		return handleDuplicateResolution(m, key)
-	case types.ScreenOrganization:
-		// Block permission moves if there are unresolved duplicates
-		if hasUnresolvedDuplicates(m) {
-			return m
-		}
-		return handlePermissionMove(m, key)
-	}
-	return m
+// textInputModal is implemented by modals that consume printable keys as text
+type textInputModal interface {
+	CapturesText() bool
+}
+
+// capturesText reports whether the modal consumes printable keys such as "q"
+func capturesText(modal types.Modal) bool {
+	input, ok := modal.(textInputModal)
+	return ok && input.CapturesText()
 }
 
 // handleDuplicateResolution handles number keys on duplicates screen
 func handleDuplicateResolution(m *types.Model, key string) *types.Model {
-	if len(m.Duplicates) == 0 {
-		return m
+	if len(m.Duplicates) == 0 || contradictionsFocused(m) {
+		return rejectInput(m)
 	}
 
 	cursor := m.DuplicatesTable.Cursor()
 	if cursor >= len(m.Duplicates) {
-		return m
+		return rejectInput(m)
 	}
 
 	var keepLevel string
@@ -150,6 +219,11 @@ func handleDuplicateResolution(m *types.Model, key string) *types.Model {
 		keepLevel = types.LevelUser
 	}
 
+	// A duplicate can only be kept in a level that contains it
+	if !slices.Contains(m.Duplicates[cursor].Levels, keepLevel) {
+		return rejectInput(m)
+	}
+
 	// Update the duplicate's keep level
 	m.Duplicates[cursor].KeepLevel = keepLevel
 
@@ -163,12 +237,12 @@ func handleDuplicateResolution(m *types.Model, key string) *types.Model {
 func handlePermissionMove(m *types.Model, key string) *types.Model {
 	currentLevelPerms, fromLevel := getCurrentColumnInfo(m)
 	if len(currentLevelPerms) == 0 {
-		return m
+		return rejectInput(m)
 	}
 
 	currentSelection := m.ColumnSelections[m.FocusedColumn]
 	if currentSelection >= len(currentLevelPerms) {
-		return m
+		return rejectInput(m)
 	}
 
 	permissionToMove := currentLevelPerms[currentSelection]
@@ -176,12 +250,13 @@ func handlePermissionMove(m *types.Model, key string) *types.Model {
 
 	// Don't move if already in target level
 	if fromLevel == toLevel {
-		return m
+		return rejectInput(m)
 	}
 
 	// Perform the immediate move
 	movePermissionBetweenLevels(m, permissionToMove, fromLevel, toLevel)
 	updateSelectionAfterMove(m, currentSelection)
+	showHint(m, hintFirstMove)
 
 	return m
 }
@@ -242,13 +317,55 @@ func movePermissionBetweenLevels(m *types.Model, permission, fromLevel, toLevel
 		m.UserLevel.Permissions = addPermissionSorted(m.UserLevel.Permissions, permission)
 	}
 
+	// Provenance travels with the rule so its sidecar entry is written to the new level
+	moveProvenance(m, permission, fromLevel, toLevel)
+
 	// Update the Permission struct in the model's consolidated view
 	for i := range m.Permissions {
 		if m.Permissions[i].Name == permission && m.Permissions[i].CurrentLevel == fromLevel {
 			m.Permissions[i].CurrentLevel = toLevel
+			// A pasted rule is only ever added, wherever it ends up
+			if m.Permissions[i].Added {
+				m.Permissions[i].OriginalLevel = toLevel
+				moveRuleAddition(m, permission, fromLevel, toLevel)
+			}
 			break
 		}
 	}
+	refreshContradictionLevels(m)
+}
+
+// editableLevels are the levels the editor writes, in LOCAL/REPO/USER order
+var editableLevels = []string{types.LevelLocal, types.LevelRepo, types.LevelUser}
+
+// getLevelByName returns the settings level with the given name, or nil
+func getLevelByName(m *types.Model, name string) *types.SettingsLevel {
+	switch name {
+	case types.LevelLocal:
+		return &m.LocalLevel
+	case types.LevelRepo:
+		return &m.RepoLevel
+	case types.LevelUser:
+		return &m.UserLevel
+	}
+	return nil
+}
+
+// moveProvenance moves a rule's provenance entry between level sidecar maps
+func moveProvenance(m *types.Model, permission, fromLevel, toLevel string) {
+	from, to := getLevelByName(m, fromLevel), getLevelByName(m, toLevel)
+	if from == nil || to == nil {
+		return
+	}
+	entry, ok := from.Provenance[permission]
+	if !ok {
+		return
+	}
+	delete(from.Provenance, permission)
+	if to.Provenance == nil {
+		to.Provenance = make(map[string]types.Provenance)
+	}
+	to.Provenance[permission] = entry
 }
 
 // removePermission removes a permission from a slice
@@ -295,6 +412,21 @@ func getSourceColumnLength(m *types.Model, columnIndex int) int {
 }
 
 const (
+	keyToggleDensity = "z"
+
+	// Adding, editing, and deleting entries on the Env and Directories screens
+	keyAdd    = "n"
+	keyEdit   = "c"
+	keyDelete = "delete"
+
+	keySwitchProject = "p"
+	keyHelp          = "?"
+
+	// Direct column focus, in LOCAL/REPO/USER column order
+	keyFocusLocal = "f1"
+	keyFocusRepo  = "f2"
+	keyFocusUser  = "f3"
+
 	keyUp         = "up"
 	keyDown       = "down"
 	keyEnter      = "enter"
@@ -302,17 +434,6 @@ const (
 	keyEscapeLong = "escape"
 )
 
-// handleUpDownNavigation passes up/down keys to the appropriate component
-func handleUpDownNavigation(m *types.Model, key string) *types.Model {
-	switch m.CurrentScreen {
-	case types.ScreenDuplicates:
-		return handleDuplicatesNavigation(m, key)
-	case types.ScreenOrganization:
-		return handleOrganizationNavigation(m, key)
-	}
-	return m
-}
-
 // handleDuplicatesNavigation handles up/down navigation for duplicates screen
 func handleDuplicatesNavigation(m *types.Model, key string) *types.Model {
 	var keyMsg tea.KeyMsg
@@ -324,6 +445,14 @@ func handleDuplicatesNavigation(m *types.Model, key string) *types.Model {
 	default:
 		return m
 	}
+
+	// Moving down past the last duplicate continues into the contradictions section
+	lastRow := m.DuplicatesTable.Cursor() == len(m.Duplicates)-1
+	if lastRow && (key == keyDown || key == "j") && len(m.Contradictions) > 0 {
+		m.ContradictionsFocused = true
+		return m
+	}
+
 	m.DuplicatesTable, _ = m.DuplicatesTable.Update(keyMsg)
 	return m
 }
@@ -332,7 +461,8 @@ func handleDuplicatesNavigation(m *types.Model, key string) *types.Model {
 func handleOrganizationNavigation(m *types.Model, key string) *types.Model {
 	// Block navigation if there are unresolved duplicates
 	if hasUnresolvedDuplicates(m) {
-		return m
+		showHint(m, hintOrganizationBlocked)
+		return rejectInput(m)
 	}
 
 	var levelPerms []string
@@ -371,43 +501,28 @@ func renderModal(m *types.Model, baseContent string) string {
 		return baseContent
 	}
 
-	// Ask the modal what to render
-	modalContent := m.ActiveModal.RenderModal(m.Width, m.Height)
-
-	// Calculate modal dimensions after rendering
-	modalHeight := lipgloss.Height(modalContent)
-	modalWidth := lipgloss.Width(modalContent)
-
 	// Use Lipgloss v2 Canvas and Layer compositing for proper background visibility
+	modalContent, x, y := placeModal(m)
 	baseLayer := lipgloss.NewLayer(baseContent)
-	modalLayer := lipgloss.NewLayer(modalContent).
-		X((m.Width - modalWidth) / 2).   // Center horizontally
-		Y((m.Height - modalHeight) / 2). // Center vertically
-		Z(1)                             // On top
+	modalLayer := lipgloss.NewLayer(modalContent).X(x).Y(y).Z(1) // On top
 
 	canvas := lipgloss.NewCanvas(baseLayer, modalLayer)
 	return canvas.Render()
 }
 
-// buildPendingChangesList builds a list of pending changes for display, grouped by destination level
-func buildPendingChangesList(m *types.Model) []string {
-	var changeLines []string
-
-	// Add permission moves grouped by destination level
-	permissionChanges := buildPermissionMovesList(m)
-	changeLines = append(changeLines, permissionChanges...)
-
-	// Add duplicate resolutions section
-	duplicateChanges := buildDuplicateResolutionsList(m)
-	changeLines = append(changeLines, duplicateChanges...)
+// placeModal renders the active modal and returns it with the position that centers it
+func placeModal(m *types.Model) (content string, x, y int) {
+	// Ask the modal what to render
+	content = m.ActiveModal.RenderModal(m.Width, m.Height)
 
-	return changeLines
+	// Calculate modal dimensions after rendering
+	x = (m.Width - lipgloss.Width(content)) / 2   // Center horizontally
+	y = (m.Height - lipgloss.Height(content)) / 2 // Center vertically
+	return content, x, y
 }
 
-// buildPermissionMovesList builds the permission moves section
-func buildPermissionMovesList(m *types.Model) []string {
-	var changeLines []string
-
+// permissionMoveSections lists the moved permissions, a section per destination level
+func permissionMoveSections(m *types.Model) []types.ChangeSection {
 	// Group permission moves by destination level
 	movesByLevel := map[string][]types.Permission{
 		types.LevelLocal: {},
@@ -422,78 +537,70 @@ func buildPermissionMovesList(m *types.Model) []string {
 		}
 	}
 
-	// Add permission moves grouped by destination level
+	var sections []types.ChangeSection
 	levelOrder := []string{types.LevelLocal, types.LevelRepo, types.LevelUser}
 	for _, level := range levelOrder {
 		moves := movesByLevel[level]
 		if len(moves) > 0 {
-			changeLines = append(changeLines, buildLevelSection(level, moves)...)
+			sections = append(sections, buildLevelSection(level, moves))
 		}
 	}
 
-	return changeLines
+	return sections
 }
 
-// buildLevelSection builds a section for a specific level
-func buildLevelSection(level string, moves []types.Permission) []string {
-	section := make([]string, 0, len(moves)+2) // header + moves + empty line
-
-	// Add section header
-	levelStyled := getLevelStyledText(level)
-	section = append(section, fmt.Sprintf("Moving to %s Level:", levelStyled))
+// buildLevelSection builds the section of the permissions moved to a level
+func buildLevelSection(level string, moves []types.Permission) types.ChangeSection {
+	section := types.ChangeSection{
+		Kind:    types.ChangeMove,
+		Title:   fmt.Sprintf("Moving to %s Level", getLevelStyledText(level)),
+		Changes: make([]types.PendingChange, 0, len(moves)),
+	}
 
 	// Sort permissions alphabetically within level
 	sortPermissionsByName(moves)
 
-	// Add each permission move
 	for _, perm := range moves {
-		originalLevelStyled := getLevelStyledText(perm.OriginalLevel)
-		currentLevelStyled := getLevelStyledText(perm.CurrentLevel)
-		section = append(
-			section,
-			fmt.Sprintf(
-				"• %s: %s → %s",
-				perm.Name,
-				originalLevelStyled,
-				currentLevelStyled,
-			),
-		)
-	}
-	section = append(section, "") // Empty line after each section
+		section.Changes = append(section.Changes, types.PendingChange{
+			Kind: types.ChangeMove,
+			Rule: perm.Name,
+			From: []string{perm.OriginalLevel},
+			To:   perm.CurrentLevel,
+			Line: fmt.Sprintf("• %s: %s → %s", perm.Name,
+				getLevelStyledText(perm.OriginalLevel), getLevelStyledText(perm.CurrentLevel)),
+		})
+	}
 
 	return section
 }
 
-// buildDuplicateResolutionsList builds the duplicate resolutions section
-func buildDuplicateResolutionsList(m *types.Model) []string {
-	var duplicateResolutions []string
+// duplicateResolutionChanges lists the duplicates removed from all but their kept level
+func duplicateResolutionChanges(m *types.Model) []types.PendingChange {
+	var changes []types.PendingChange
 
 	for _, dup := range m.Duplicates {
-		if dup.KeepLevel != "" {
-			otherLevels := []string{}
-			for _, level := range dup.Levels {
-				if level != dup.KeepLevel {
-					// Apply level colors to level names
-					otherLevels = append(otherLevels, getLevelStyledText(level))
-				}
-			}
-			if len(otherLevels) > 0 {
-				// Apply level color to keep level
-				keepLevelStyled := getLevelStyledText(dup.KeepLevel)
-				duplicateResolutions = append(duplicateResolutions,
-					fmt.Sprintf("• %s: Remove from %s (keep in %s)",
-						dup.Name, strings.Join(otherLevels, ", "), keepLevelStyled))
+		if dup.KeepLevel == "" {
+			continue
+		}
+		var otherLevels []string
+		for _, level := range dup.Levels {
+			if level != dup.KeepLevel {
+				otherLevels = append(otherLevels, level)
 			}
 		}
+		if len(otherLevels) > 0 {
+			changes = append(changes, types.PendingChange{
+				Kind: types.ChangeDuplicate,
+				Rule: dup.Name,
+				From: otherLevels,
+				To:   dup.KeepLevel,
+				Line: fmt.Sprintf("• %s: Remove from %s (keep in %s)",
+					dup.Name, styledLevels(otherLevels), getLevelStyledText(dup.KeepLevel)),
+			})
+		}
 	}
 
-	var result []string
-	if len(duplicateResolutions) > 0 {
-		result = append(result, "Duplicate Resolutions:")
-		result = append(result, duplicateResolutions...)
-	}
-
-	return result
+	return changes
 }
 
 // sortPermissionsByName sorts permissions alphabetically by name
@@ -507,74 +614,127 @@ func sortPermissionsByName(perms []types.Permission) {
 	}
 }
 
-// handleEscapeKey handles ESC key with screen-specific behavior
-func handleEscapeKey(m *types.Model) *types.Model {
-	switch m.CurrentScreen {
-	case types.ScreenDuplicates:
-		// On duplicates screen: ESC should cancel/exit (only if no pending changes)
-		if hasPendingChanges(m) {
-			m.ActiveModal = NewSmallModal(
-				"Exit with Pending Changes",
-				"You have pending permission moves or duplicate resolutions.\n\n"+
-					"Do you want to discard these changes and exit?",
-				"exit",
-			)
-		}
-		// If no pending changes, ESC does nothing (user should use Q to quit)
-	case types.ScreenOrganization:
-		// On organization screen: ESC should reset changes
-		if hasPendingChanges(m) {
-			m.ActiveModal = NewSmallModal(
-				"Reset All Changes",
-				"Are you sure you want to reset all permission moves and duplicate resolutions?\n\n"+
-					"This will undo all pending changes and return permissions to their original state.",
-				"reset",
-			)
-		}
-		// If no pending changes, ESC does nothing
-	}
-	return m
-}
-
 // handleActiveModalInput handles keyboard input for new modal interface
-func handleActiveModalInput(m *types.Model, key string) *types.Model {
+func handleActiveModalInput(m *types.Model, key string) (*types.Model, tea.Cmd) {
 	handled, result := m.ActiveModal.HandleInput(key)
 	if !handled {
-		return m
+		return m, nil
 	}
 
+	var cmd tea.Cmd
 	// Process the result based on modal type and action
 	switch resultStr := result.(string); resultStr {
 	case "yes":
-		// For small modals, determine action based on the modal's Action field
-		if smallModal, ok := m.ActiveModal.(*SmallModal); ok {
+		// For small modals, determine action based on the modal's Action field.
+		// The modal is cleared first so actions can open a follow-up modal.
+		smallModal, ok := m.ActiveModal.(*SmallModal)
+		m.ActiveModal = nil
+		if ok {
 			switch smallModal.Action {
 			case "reset":
 				m = resetAllChanges(m)
 			case "exit":
 				// For exit action, reset changes and clear modal
 				m = resetAllChanges(m)
+			case actionCreateFiles:
+				m, cmd = saveChanges(m)
+			case actionResumeSession:
+				m = restoreSession(m)
+			case actionPurgeExpired:
+				m = purgeExpired(m)
+			case actionGitCommit:
+				m, cmd = commitRepoSettings(m)
 			}
 		}
-		m.ActiveModal = nil
 	case "no":
-		// Just close the modal without action
+		// Close the modal; declining a session restore also discards the session, and
+		// declining a commit drops it
+		if smallModal, ok := m.ActiveModal.(*SmallModal); ok {
+			switch smallModal.Action {
+			case actionResumeSession:
+				discardSession(m)
+			case actionGitCommit:
+				m.PendingCommit = nil
+			}
+		}
 		m.ActiveModal = nil
 	case "execute":
-		// For confirm changes modal - execute all changes and close modal
-		// TODO: Here we would actually save the changes to files
-		// For now, just close the modal (changes remain in memory)
+		// For confirm changes modal - close it and write the ticked files
+		m.SaveSelection = nil
+		if confirmModal, ok := m.ActiveModal.(*ConfirmChangesModal); ok {
+			m.SaveSelection = confirmModal.Selection()
+		}
 		m.ActiveModal = nil
+		m, cmd = startSave(m)
 	case "cancel":
 		// For confirm changes modal - just close modal and return to main screen
 		m.ActiveModal = nil
+	case "apply_suggestions":
+		if suggestionsModal, ok := m.ActiveModal.(*SuggestionsModal); ok {
+			m.Consolidations = suggestionsModal.Accepted()
+		}
+		m.ActiveModal = nil
+	case "apply_modes":
+		if modesModal, ok := m.ActiveModal.(*ModesModal); ok {
+			applyModes(m, modesModal)
+		}
+		m.ActiveModal = nil
+	case "remove_stale":
+		if staleModal, ok := m.ActiveModal.(*StaleModal); ok {
+			m.StaleRemovals = staleModal.Selected()
+		}
+		m.ActiveModal = nil
+	case "export":
+		if pathModal, ok := m.ActiveModal.(*PathModal); ok {
+			m = exportPermset(m, pathModal)
+		}
+	case "import":
+		if pathModal, ok := m.ActiveModal.(*PathModal); ok {
+			m = importPermset(m, pathModal)
+		}
+	case "env_set":
+		if envModal, ok := m.ActiveModal.(*EnvModal); ok {
+			m = applyEnvEdit(m, envModal)
+		}
+	case "bulk_resolve":
+		if bulkModal, ok := m.ActiveModal.(*BulkResolveModal); ok {
+			m = applyBulkResolution(m, bulkModal)
+		}
+	case "rule_set":
+		if ruleModal, ok := m.ActiveModal.(*RuleEditModal); ok {
+			m = applyRuleEdit(m, ruleModal)
+		}
+	case "directory_set":
+		if directoryModal, ok := m.ActiveModal.(*DirectoryModal); ok {
+			m = applyDirectoryEdit(m, directoryModal)
+		}
+	case "apply_preset":
+		if presetModal, ok := m.ActiveModal.(*PresetModal); ok {
+			m = applyPreset(m, presetModal)
+		}
+	case "profile_save":
+		if profileModal, ok := m.ActiveModal.(*ProfileModal); ok {
+			m = saveProfile(m, profileModal)
+		}
+	case "profile_restore":
+		if profileModal, ok := m.ActiveModal.(*ProfileModal); ok {
+			m = restoreProfile(m, profileModal)
+		}
+	case "profile_delete":
+		if profileModal, ok := m.ActiveModal.(*ProfileModal); ok {
+			m = deleteProfile(m, profileModal)
+		}
+	case "switch_project":
+		if projectModal, ok := m.ActiveModal.(*ProjectModal); ok {
+			m = switchProject(m, projectModal)
+		}
 	case "quit":
 		// For confirm changes modal - quit application
 		// The main program loop should handle this by checking for quit signals
 		m.ActiveModal = nil
 	}
 
-	return m
+	return m, cmd
 }
 
 // hasPendingChanges checks if there are any pending permission moves or duplicate resolutions
@@ -593,7 +753,26 @@ func hasPendingChanges(m *types.Model) bool {
 		}
 	}
 
-	return false
+	// Check if any contradictions have been resolved
+	for _, c := range m.Contradictions {
+		if c.Winner != "" {
+			return true
+		}
+	}
+
+	return len(m.Consolidations) > 0 || len(pendingStaleRemovals(m)) > 0 ||
+		len(pendingRuleEdits(m)) > 0 || len(pastedRules(m)) > 0 || len(m.RuleAdditions) > 0 ||
+		sameLevelCount(m) > 0 || hasHookChanges(m) ||
+		hasEnvChanges(m) || hasDirectoryChanges(m) || hasModeChanges(m)
+}
+
+// hasUserChanges reports whether the user made pending changes, unlike hasPendingChanges
+// ignoring duplicate resolutions assigned automatically at load
+func hasUserChanges(m *types.Model) bool {
+	_, pending := captureSession(m)
+	return pending || len(pendingRuleEdits(m)) > 0 || len(pastedRules(m)) > 0 ||
+		len(m.RuleAdditions) > 0 || hasHookChanges(m) || hasEnvChanges(m) ||
+		hasDirectoryChanges(m) || hasModeChanges(m)
 }
 
 // getLevelStyledText returns a styled level name using the appropriate theme color
@@ -624,13 +803,26 @@ func resetAllChanges(m *types.Model) *types.Model {
 		}
 	}
 
-	// Reset duplicate resolutions
+	// Reset duplicate and contradiction resolutions, accepted suggestions, and stale removals
 	for i := range m.Duplicates {
 		m.Duplicates[i].KeepLevel = ""
 	}
+	for i := range m.Contradictions {
+		m.Contradictions[i].Winner = ""
+	}
+	m.Consolidations = nil
+	m.StaleRemovals = nil
+	m.RuleEdits = nil
+	resetRuleAdditions(m)
+	resetPastedRules(m)
+	resetHooks(m)
+	resetEnv(m)
+	resetDirectories(m)
+	resetModes(m)
 
-	// Reset column selections to 0
+	// Reset column selections and scroll positions to 0
 	m.ColumnSelections = [3]int{0, 0, 0}
+	m.ColumnOffsets = [3]int{0, 0, 0}
 
 	return m
 }
@@ -649,6 +841,14 @@ func handleLaunchConfirmChanges(
 	return m
 }
 
+// handleLoadState replaces the model with the state posted to the debug server
+func handleLoadState(m *types.Model, msg debug.LoadStateMsg) *types.Model {
+	msg.State.Apply(m)
+	m.DuplicatesTable = createDuplicatesTableFromData(m.Duplicates)
+	m.DuplicatesTable.SetCursor(msg.State.Cursor.DuplicatesCursor)
+	return m
+}
+
 // applyMockChangesToModel applies mock permission moves and duplicate resolutions to the model
 func applyMockChangesToModel(m *types.Model, request *debug.LaunchConfirmChangesRequest) {
 	// Apply permission moves
@@ -703,6 +903,7 @@ func updateModelLevelPermissions(m *types.Model, permName, fromLevel, toLevel st
 	case types.LevelUser:
 		m.UserLevel.Permissions = addPermissionToArraySorted(m.UserLevel.Permissions, permName)
 	}
+	refreshContradictionLevels(m)
 }
 
 // removePermissionFromArray removes a permission from a slice
@@ -737,9 +938,10 @@ func addPermissionToArraySorted(perms []string, permission string) []string {
 
 // hasUnresolvedDuplicates checks if there are duplicates that need to be committed.
 //
-// Duplicates are auto-assigned KeepLevel values during initialization based on priority
-// (User > Repo > Local). However, they are considered "unresolved" until the user
-// commits them via ENTER → confirmation modal → save to files.
+// Duplicates are auto-assigned KeepLevel values during initialization based on the configured
+// priority (--priority or duplicates.priority, default User > Repo > Local). However, they are
+// considered "unresolved" until the user commits them via ENTER → confirmation modal → save to
+// files.
 //
 // The presence of ANY duplicates in m.Duplicates means they need resolution/commitment,
 // regardless of their KeepLevel assignment. Only after successful commit are duplicates
@@ -794,7 +996,6 @@ func createDuplicatesTableFromData(duplicates []types.Duplicate) table.Model {
 		table.WithColumns(columns),
 		table.WithRows(rows),
 		table.WithFocused(true),
-		table.WithHeight(7),
 	)
 
 	// Apply consistent table styling using centralized theme
@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"log/slog"
+
+	"claude-permissions/keymap"
+	"claude-permissions/logging"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// Hint identifiers for one-time contextual help
+const (
+	hintDuplicatesScreen    = "duplicates_screen"
+	hintOrganizationScreen  = "organization_screen"
+	hintFirstMove           = "first_move"
+	hintOrganizationBlocked = "organization_blocked"
+)
+
+// keyDismissHint dismisses the active hint permanently
+const keyDismissHint = "x"
+
+// hintMessages maps hint identifiers to their help text
+var hintMessages = map[string]string{
+	hintDuplicatesScreen: "These rules exist at more than one level. " +
+		"Press 1/2/3 to pick the level that keeps each rule, then ENTER to review.",
+	hintOrganizationScreen: "Use ←→ to pick a column and 1/2/3 to move the selected rule " +
+		"to LOCAL/REPO/USER.",
+	hintFirstMove: "Moved rules show their original level in parentheses. " +
+		"Press ESC to undo all pending moves.",
+	hintOrganizationBlocked: "Organizing is locked until duplicates are resolved. " +
+		"Press TAB to return to the Duplicates screen.",
+}
+
+// screenHints maps each screen to the hint shown on its first visit
+var screenHints = map[int]string{
+	types.ScreenDuplicates:   hintDuplicatesScreen,
+	types.ScreenOrganization: hintOrganizationScreen,
+}
+
+// hintBarStyle is the style for the one-line hint bar above the status bar
+var hintBarStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color(ColorInfo)).
+	Italic(true).
+	Padding(0, 1)
+
+// showHint displays a hint unless it has already been dismissed
+func showHint(m *types.Model, id string) {
+	if m.StateStore == nil || m.StateStore.HintSeen(id) {
+		return
+	}
+	m.ActiveHint = id
+}
+
+// showScreenHint shows the current screen's hint, replacing another screen's hint
+func showScreenHint(m *types.Model) {
+	id, ok := screenHints[m.CurrentScreen]
+	if !ok || m.ActiveHint == id {
+		return
+	}
+
+	// Only screen hints are replaced; action hints stay until dismissed
+	if m.ActiveHint == "" || isScreenHint(m.ActiveHint) {
+		m.ActiveHint = ""
+		showHint(m, id)
+	}
+}
+
+// isScreenHint reports whether id belongs to a screen rather than an action
+func isScreenHint(id string) bool {
+	for _, screenHint := range screenHints {
+		if screenHint == id {
+			return true
+		}
+	}
+	return false
+}
+
+// dismissHint remembers the active hint as seen and hides it
+func dismissHint(m *types.Model) {
+	if m.ActiveHint == "" {
+		return
+	}
+	if m.StateStore != nil {
+		if err := m.StateStore.MarkHintSeen(m.ActiveHint); err != nil {
+			logFor(m, logging.ComponentHints).Warn("hint_dismiss_failed",
+				slog.String("hint", m.ActiveHint), logging.Err(err))
+		}
+	}
+	m.ActiveHint = ""
+}
+
+// renderHintBar renders the active hint, or an empty string when none is shown
+func renderHintBar(m *types.Model) string {
+	message, ok := hintMessages[m.ActiveHint]
+	if !ok {
+		return ""
+	}
+	dismissKey := AccentStyle.Render(keyLabel(m, keymap.DismissHint))
+	text := "Tip: " + message + "  " + dismissKey + " · Dismiss"
+	return hintBarStyle.Width(m.Width).Render(text)
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/logging"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// historyLimit is how many of the most recent audit entries the History screen shows
+const historyLimit = 500
+
+// historyActionLabels are the History screen names of audit actions
+var historyActionLabels = map[string]string{
+	audit.ActionMove:                 "Moved",
+	audit.ActionRemoveDuplicate:      "Removed duplicate",
+	audit.ActionResolveContradiction: "Resolved conflict",
+	audit.ActionConsolidate:          "Consolidated",
+	audit.ActionImport:               "Imported",
+	audit.ActionPreset:               "Preset",
+	audit.ActionMoveHook:             "Moved hook",
+	audit.ActionReorderHook:          "Reordered hook",
+	audit.ActionSetEnv:               "Set env",
+	audit.ActionDeleteEnv:            "Removed env",
+	audit.ActionAddDirectory:         "Added directory",
+	audit.ActionRemoveDirectory:      "Removed directory",
+	audit.ActionMoveDirectory:        "Moved directory",
+	audit.ActionRemoveStale:          "Removed stale",
+	audit.ActionSetMode:              "Set mode",
+	audit.ActionEditRule:             "Edited",
+	audit.ActionDeleteRule:           "Deleted",
+	audit.ActionAddRule:              "Pasted",
+}
+
+// collectAuditEntries returns an audit entry for every pending move, duplicate removal,
+// contradiction resolution, consolidated rule, removed stale rule, moved or reordered hook, env
+// change, additional directory change, and permission mode change
+func collectAuditEntries(m *types.Model, changeID string, now time.Time) []audit.Entry {
+	var entries []audit.Entry
+	for _, perm := range m.Permissions {
+		if perm.CurrentLevel != perm.OriginalLevel {
+			entries = append(entries, audit.Entry{
+				Timestamp: now,
+				Rule:      perm.Name,
+				From:      perm.OriginalLevel,
+				To:        perm.CurrentLevel,
+				Action:    audit.ActionMove,
+			})
+		}
+	}
+	for _, dup := range m.Duplicates {
+		if dup.KeepLevel == "" {
+			continue
+		}
+		for _, level := range dup.Levels {
+			if level == dup.KeepLevel {
+				continue
+			}
+			entries = append(entries, audit.Entry{
+				Timestamp: now,
+				Rule:      dup.Name,
+				From:      level,
+				To:        dup.KeepLevel,
+				Action:    audit.ActionRemoveDuplicate,
+			})
+		}
+	}
+	entries = append(entries, contradictionAuditEntries(m, now)...)
+	entries = append(entries, consolidationAuditEntries(m, now)...)
+	entries = append(entries, staleAuditEntries(m, now)...)
+	entries = append(entries, ruleEditAuditEntries(m, now)...)
+	entries = append(entries, pasteAuditEntries(m, now)...)
+	entries = append(entries, ruleAdditionAuditEntries(m, now)...)
+	entries = append(entries, sameLevelAuditEntries(m, now)...)
+	entries = append(entries, hookAuditEntries(m, now)...)
+	entries = append(entries, envAuditEntries(m, now)...)
+	entries = append(entries, directoryAuditEntries(m, now)...)
+	entries = append(entries, modeAuditEntries(m, now)...)
+	for i := range entries {
+		entries[i].ChangeID = changeID
+	}
+	return entries
+}
+
+// loadHistory reads the most recent audit entries into the model
+func loadHistory(m *types.Model) {
+	entries, err := m.AuditLog.Recent(historyLimit)
+	if err != nil {
+		logFor(m, logging.ComponentHistory).Warn("audit_log_read_failed",
+			logging.Path(m.AuditLog.Path()), logging.Err(err))
+		m.Notifications.Warn("History not loaded: %v", err)
+	}
+	m.History = entries
+	m.HistoryOffset = 0
+}
+
+// handleHistoryNavigation scrolls the History screen by one row
+func handleHistoryNavigation(m *types.Model, key string) *types.Model {
+	switch key {
+	case keyUp, "k":
+		m.HistoryOffset--
+	case keyDown, "j":
+		m.HistoryOffset++
+	}
+	scrollHistory(m)
+	return m
+}
+
+// renderHistoryStatusText generates status text for the History screen
+func renderHistoryStatusText(m *types.Model) string {
+	return fmt.Sprintf("%d recent change(s) from %s", len(m.History), m.AuditLog.Path())
+}
+
+// visibleHistoryRows returns how many entries fit below the History header
+func (c *ContentComponent) visibleHistoryRows() int {
+	vertical, _ := c.panelPadding()
+	rows := c.height - NormalBorderStyle.GetVerticalBorderSize() - 2*vertical - 1
+	return max(rows, 1)
+}
+
+// renderHistoryContent renders the read-only list of applied changes, newest first
+func (c *ContentComponent) renderHistoryContent() string {
+	if c.width <= 0 || c.height <= 0 {
+		return ""
+	}
+
+	contentWidth := max(c.getConsistentContentWidth(), 20)
+
+	if len(c.model.History) == 0 {
+		return BlockingMessageStyle.
+			Width(contentWidth).
+			Height(c.height).
+			Render("No saved changes recorded yet")
+	}
+
+	_, horizontal := c.panelPadding()
+	rowWidth := contentWidth - NormalBorderStyle.GetHorizontalBorderSize() - 2*horizontal
+	rowStyle := lipgloss.NewStyle().MaxWidth(rowWidth)
+	lines := []string{TitleStyle.Render(
+		fmt.Sprintf("%-16s  %-17s  %-14s  %s", "When", "Action", "Levels", "Rule"))}
+
+	end := min(c.model.HistoryOffset+c.visibleHistoryRows(), len(c.model.History))
+	for _, entry := range c.model.History[c.model.HistoryOffset:end] {
+		action := historyActionLabels[entry.Action]
+		if action == "" {
+			action = entry.Action
+		}
+		levels := fmt.Sprintf("%s → %s", entry.From, entry.To)
+		line := fmt.Sprintf("%-16s  %-17s  %-14s  %s",
+			entry.Timestamp.Local().Format("2006-01-02 15:04"), action, levels, entry.Rule)
+		lines = append(lines, rowStyle.Render(line))
+	}
+
+	return lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(c.height).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorBorderFocused)).
+		Padding(c.panelPadding()).
+		Render(strings.Join(lines, "\n"))
+}
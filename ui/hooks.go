@@ -0,0 +1,316 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// Keys moving the selected hook before or after its neighbor of the same event
+const (
+	keyMoveHookUp   = "["
+	keyMoveHookDown = "]"
+)
+
+// hookRow locates one hook on the Hooks screen
+type hookRow struct {
+	level string
+	index int // Index into the level's Hooks
+}
+
+// hookRows lists every hook in screen order; HookCursor indexes this list
+func hookRows(m *types.Model) []hookRow {
+	var rows []hookRow
+	for _, name := range editableLevels {
+		for i := range getLevelByName(m, name).Hooks {
+			rows = append(rows, hookRow{level: name, index: i})
+		}
+	}
+	return rows
+}
+
+// selectedHookRow returns the row under the cursor, clamping the cursor to the list
+func selectedHookRow(m *types.Model) (hookRow, bool) {
+	rows := hookRows(m)
+	if len(rows) == 0 {
+		return hookRow{}, false
+	}
+	m.HookCursor = max(min(m.HookCursor, len(rows)-1), 0)
+	return rows[m.HookCursor], true
+}
+
+// hookKey identifies a hook across levels by its event and entry as written
+func hookKey(h types.Hook) string {
+	return h.Event + "\x00" + string(h.Raw)
+}
+
+// hookLabel describes a hook for lists and the audit log, e.g. "PreToolUse(Bash)"
+func hookLabel(h types.Hook) string {
+	matcher := h.Matcher
+	if matcher == "" {
+		matcher = "*"
+	}
+	return fmt.Sprintf("%s(%s)", h.Event, matcher)
+}
+
+// containsHook reports whether hooks includes h
+func containsHook(hooks []types.Hook, h types.Hook) bool {
+	return slices.ContainsFunc(hooks, func(other types.Hook) bool {
+		return hookKey(other) == hookKey(h)
+	})
+}
+
+// hookOrigin returns the level a hook was loaded from, or "" for an unknown hook
+func hookOrigin(m *types.Model, h types.Hook) string {
+	for _, name := range editableLevels {
+		if containsHook(m.OriginalHooks[name], h) {
+			return name
+		}
+	}
+	return ""
+}
+
+// hooksChanged reports whether a level's hooks differ from those loaded, in content or order
+func hooksChanged(m *types.Model, level string) bool {
+	return !slices.EqualFunc(getLevelByName(m, level).Hooks, m.OriginalHooks[level],
+		func(a, b types.Hook) bool { return hookKey(a) == hookKey(b) })
+}
+
+// hasHookChanges reports whether any hook was moved or reordered
+func hasHookChanges(m *types.Model) bool {
+	return slices.ContainsFunc(editableLevels, func(level string) bool {
+		return hooksChanged(m, level)
+	})
+}
+
+// resetHooks restores every level's hooks as loaded
+func resetHooks(m *types.Model) {
+	for _, name := range editableLevels {
+		getLevelByName(m, name).Hooks = slices.Clone(m.OriginalHooks[name])
+	}
+	m.HookCursor = 0
+}
+
+// handleHookNavigation moves the cursor through the hooks of all levels
+func handleHookNavigation(m *types.Model, key string) *types.Model {
+	rows := hookRows(m)
+	switch key {
+	case keyUp, "k":
+		m.HookCursor--
+	case keyDown, "j":
+		m.HookCursor++
+	}
+	m.HookCursor = max(min(m.HookCursor, len(rows)-1), 0)
+	return m
+}
+
+// handleHookReorder swaps the selected hook with the previous or next hook of the same event,
+// since hooks only run in order within an event
+func handleHookReorder(m *types.Model, key string) *types.Model {
+	row, ok := selectedHookRow(m)
+	if !ok {
+		return rejectInput(m)
+	}
+
+	hooks := getLevelByName(m, row.level).Hooks
+	neighbor := row.index - 1
+	if key == keyMoveHookDown {
+		neighbor = row.index + 1
+	}
+	if neighbor < 0 || neighbor >= len(hooks) || hooks[neighbor].Event != hooks[row.index].Event {
+		return rejectInput(m)
+	}
+
+	hooks[row.index], hooks[neighbor] = hooks[neighbor], hooks[row.index]
+	m.HookCursor += neighbor - row.index
+	return m
+}
+
+// handleHookMove moves the selected hook to the level of a number key, after the target
+// level's hooks of the same event
+func handleHookMove(m *types.Model, key string) *types.Model {
+	row, ok := selectedHookRow(m)
+	toLevel := getTargetLevel(key)
+	if !ok || toLevel == row.level {
+		return rejectInput(m)
+	}
+
+	from, to := getLevelByName(m, row.level), getLevelByName(m, toLevel)
+	hook := from.Hooks[row.index]
+	from.Hooks = slices.Delete(from.Hooks, row.index, row.index+1)
+	insertAt := hookInsertIndex(to.Hooks, hook.Event)
+	to.Hooks = slices.Insert(to.Hooks, insertAt, hook)
+
+	// The cursor follows the hook to its new level
+	for i, r := range hookRows(m) {
+		if r.level == toLevel && r.index == insertAt {
+			m.HookCursor = i
+			break
+		}
+	}
+	return m
+}
+
+// hookInsertIndex returns where a hook of event is appended so that PreToolUse hooks stay
+// ahead of PostToolUse hooks
+func hookInsertIndex(hooks []types.Hook, event string) int {
+	insertAt := 0
+	for i, h := range hooks {
+		if h.Event == event || event == types.HookPostToolUse {
+			insertAt = i + 1
+		}
+	}
+	return insertAt
+}
+
+// hookAuditEntries returns an audit entry for each hook moved to another level and each hook
+// whose position within its level changed
+func hookAuditEntries(m *types.Model, now time.Time) []audit.Entry {
+	var entries []audit.Entry
+	for _, name := range editableLevels {
+		current, original := getLevelByName(m, name).Hooks, m.OriginalHooks[name]
+
+		var stayed []types.Hook
+		for _, h := range current {
+			if containsHook(original, h) {
+				stayed = append(stayed, h)
+				continue
+			}
+			entries = append(entries, audit.Entry{
+				Timestamp: now,
+				Rule:      hookLabel(h),
+				From:      hookOrigin(m, h),
+				To:        name,
+				Action:    audit.ActionMoveHook,
+			})
+		}
+
+		// Compare the order of the hooks that stayed against their order when loaded
+		var kept []types.Hook
+		for _, h := range original {
+			if containsHook(stayed, h) {
+				kept = append(kept, h)
+			}
+		}
+		for i, h := range stayed {
+			if i < len(kept) && hookKey(h) != hookKey(kept[i]) {
+				entries = append(entries, audit.Entry{
+					Timestamp: now,
+					Rule:      hookLabel(h),
+					From:      name,
+					To:        name,
+					Action:    audit.ActionReorderHook,
+				})
+			}
+		}
+	}
+	return entries
+}
+
+// hookChanges lists the moved and reordered hooks
+func hookChanges(m *types.Model) []types.PendingChange {
+	var changes []types.PendingChange
+	for _, entry := range hookAuditEntries(m, time.Time{}) {
+		if entry.Action == audit.ActionReorderHook {
+			changes = append(changes, types.PendingChange{
+				Kind:   types.ChangeHook,
+				Rule:   entry.Rule,
+				Level:  entry.To,
+				Detail: entry.Action,
+				Line: fmt.Sprintf("• %s: Reordered in %s",
+					entry.Rule, getLevelStyledText(entry.To)),
+			})
+			continue
+		}
+		changes = append(changes, types.PendingChange{
+			Kind:   types.ChangeHook,
+			Rule:   entry.Rule,
+			From:   levelList(entry.From),
+			To:     entry.To,
+			Detail: entry.Action,
+			Line: fmt.Sprintf("• %s: %s → %s",
+				entry.Rule, getLevelStyledText(entry.From), getLevelStyledText(entry.To)),
+		})
+	}
+	return changes
+}
+
+// renderHooksStatusText describes the selected hook for the status bar
+func renderHooksStatusText(m *types.Model) string {
+	row, ok := selectedHookRow(m)
+	if !ok {
+		return "No PreToolUse or PostToolUse hooks"
+	}
+	hook := getLevelByName(m, row.level).Hooks[row.index]
+	status := fmt.Sprintf("%s in %s", hookLabel(hook), row.level)
+	if origin := hookOrigin(m, hook); origin != row.level {
+		status += fmt.Sprintf(" (originally %s)", origin)
+	}
+	return status
+}
+
+// visibleHookLines returns how many lines fit inside the Hooks panel
+func (c *ContentComponent) visibleHookLines() int {
+	vertical, _ := c.panelPadding()
+	lines := c.height - NormalBorderStyle.GetVerticalBorderSize() - 2*vertical
+	return max(lines, 1)
+}
+
+// renderHooksContent lists the hooks of each level with the selected one highlighted
+func (c *ContentComponent) renderHooksContent() string {
+	if c.width <= 0 || c.height <= 0 {
+		return ""
+	}
+
+	m := c.model
+	contentWidth := max(c.getConsistentContentWidth(), 20)
+	_, horizontal := c.panelPadding()
+	rowWidth := contentWidth - NormalBorderStyle.GetHorizontalBorderSize() - 2*horizontal
+	rowStyle := lipgloss.NewStyle().MaxWidth(rowWidth)
+
+	selected, hasSelection := selectedHookRow(m)
+	selectedLine := 0
+	var lines []string
+	for _, name := range editableLevels {
+		hooks := getLevelByName(m, name).Hooks
+		lines = append(lines, TitleStyle.Render(name)+fmt.Sprintf(" (%d)", len(hooks)))
+		if len(hooks) == 0 {
+			lines = append(lines, OriginIndicatorStyle.Render("  No hooks"))
+		}
+		for i, hook := range hooks {
+			line := fmt.Sprintf("%-11s  %-16s  %s", hook.Event, hook.Matcher,
+				strings.Join(hook.Commands, "; "))
+			var originText string
+			if origin := hookOrigin(m, hook); origin != name {
+				originText = OriginIndicatorStyle.Render(" (") +
+					c.getOriginStyle(origin).Render(origin) + OriginIndicatorStyle.Render(")")
+			}
+			if hasSelection && selected.level == name && selected.index == i {
+				selectedLine = len(lines)
+				highlighted := SelectedItemStyle.Render("> " + line)
+				lines = append(lines, rowStyle.Render(highlighted+originText))
+				continue
+			}
+			lines = append(lines, rowStyle.Render("  "+line+originText))
+		}
+	}
+
+	// Scroll just far enough to keep the selected hook visible
+	visible := c.visibleHookLines()
+	start := max(selectedLine-visible+1, 0)
+	end := min(start+visible, len(lines))
+
+	return lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(c.height).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorBorderFocused)).
+		Padding(c.panelPadding()).
+		Render(strings.Join(lines[start:end], "\n"))
+}
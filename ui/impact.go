@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"claude-permissions/rules"
+	"claude-permissions/types"
+)
+
+// precedenceLevels returns the levels in the order Claude Code consults them, managed
+// policy first when it exists, then Local, Repo, and User
+func precedenceLevels(m *types.Model) []*types.SettingsLevel {
+	var levels []*types.SettingsLevel
+	if m.ManagedLevel.Exists {
+		levels = append(levels, &m.ManagedLevel)
+	}
+	return append(levels, &m.LocalLevel, &m.RepoLevel, &m.UserLevel)
+}
+
+// moveImpact describes how moving rule from the from level to target would change the
+// effective permissions. A copy already in target makes it a duplicate, an allow rule at a
+// level consulted first that covers it leaves it shadowed, and the user level carries it
+// into every project.
+func moveImpact(m *types.Model, rule, from, target string) string {
+	var effects []string
+	if level := getLevelByName(m, target); level != nil &&
+		slices.Contains(level.Permissions, rule) {
+		effects = append(effects, "duplicates the copy already there")
+	}
+
+	narrow := rules.Parse(rule)
+	for _, level := range precedenceLevels(m) {
+		if level.Name == target {
+			break
+		}
+		for _, other := range level.Permissions {
+			// The rule's own entry leaves with the move
+			if level.Name == from && other == rule {
+				continue
+			}
+			if rules.Covers(rules.Parse(other), narrow) {
+				effects = append(effects, fmt.Sprintf("shadowed by %s in %s", other, level.Name))
+			}
+		}
+	}
+
+	switch {
+	case target == types.LevelUser:
+		effects = append(effects, "applies in every project")
+	case from == types.LevelUser:
+		effects = append(effects, "no longer applies in other projects")
+	}
+	if len(effects) == 0 {
+		return "takes effect as before"
+	}
+	return strings.Join(effects, "; ")
+}
+
+// moveImpactLines describes moving rule to each editable level other than its own
+func moveImpactLines(m *types.Model, rule, from string) []string {
+	var lines []string
+	for _, target := range editableLevels {
+		if target == from {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", getLevelStyledText(target),
+			moveImpact(m, rule, from, target)))
+	}
+	return lines
+}
@@ -0,0 +1,419 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/rules"
+	"claude-permissions/types"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// Key opening the inspector for the selected rule
+const keyInspect = "v"
+
+// InspectorModal implements types.Modal for the selected rule's details and quick actions
+type InspectorModal struct {
+	model *types.Model
+	perm  types.Permission
+}
+
+// NewInspectorModal creates the inspector for perm
+func NewInspectorModal(model *types.Model, perm types.Permission) *InspectorModal {
+	return &InspectorModal{model: model, perm: perm}
+}
+
+// openInspector opens the inspector for the rule selected on the Organization screen
+func openInspector(m *types.Model) *types.Model {
+	columnPerms := getColumnPermissions(m)
+	selected := m.ColumnSelections[m.FocusedColumn]
+	if m.CurrentScreen != types.ScreenOrganization || hasUnresolvedDuplicates(m) ||
+		selected >= len(columnPerms) {
+		return rejectInput(m)
+	}
+	m.ActiveModal = NewInspectorModal(m, columnPerms[selected])
+	return m
+}
+
+// ruleLocations lists the levels whose allow or deny list holds rule, e.g. "Repo (deny)"
+func ruleLocations(m *types.Model, rule string) []string {
+	var locations []string
+	levels := []*types.SettingsLevel{&m.LocalLevel, &m.RepoLevel, &m.UserLevel}
+	if m.ManagedLevel.Exists {
+		levels = append(levels, &m.ManagedLevel)
+	}
+	for _, level := range levels {
+		if slices.Contains(level.Permissions, rule) {
+			locations = append(locations, level.Name)
+		}
+		if slices.Contains(level.Deny, rule) {
+			locations = append(locations, level.Name+" (deny)")
+		}
+	}
+	return locations
+}
+
+// coveringRules lists the other allow rules of any level that already permit everything
+// rule does, each with its level
+func coveringRules(m *types.Model, rule string) []string {
+	narrow := rules.Parse(rule)
+	var covering []string
+	levels := []*types.SettingsLevel{&m.LocalLevel, &m.RepoLevel, &m.UserLevel}
+	if m.ManagedLevel.Exists {
+		levels = append(levels, &m.ManagedLevel)
+	}
+	for _, level := range levels {
+		for _, other := range level.Permissions {
+			if other != rule && rules.Covers(rules.Parse(other), narrow) {
+				covering = append(covering, fmt.Sprintf("%s in %s", other, level.Name))
+			}
+		}
+	}
+	return covering
+}
+
+// RenderModal renders the rule's parsed form, where it is defined, and the quick actions
+func (im *InspectorModal) RenderModal(width, height int) string {
+	contentWidth := min(80, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	bodyStyle := lipgloss.NewStyle().Width(contentWidth - 4)
+	field := func(label, value string) string {
+		return bodyStyle.Render(fmt.Sprintf("%s %s", AccentStyle.Render(label+":"), value))
+	}
+
+	perm := im.perm
+	rule := rules.Parse(perm.Name)
+	specifier := rule.Specifier
+	if specifier == "" {
+		specifier = "(none, every call)"
+	}
+	lines := []string{
+		titleStyle.Render("Rule Inspector"),
+		"",
+		bodyStyle.Render(perm.Name),
+		"",
+		field("Tool", rule.Tool),
+		field("Specifier", specifier),
+		field("Defined in", strings.Join(ruleLocations(im.model, perm.Name), ", ")),
+		field("Level", getLevelStyledText(perm.CurrentLevel)),
+	}
+	if perm.OriginalLevel != perm.CurrentLevel {
+		lines = append(lines, field("Originally", getLevelStyledText(perm.OriginalLevel)))
+	}
+	if p := perm.Provenance; p != nil {
+		lines = append(lines, field("Added by",
+			fmt.Sprintf("%s on %s", p.Source, p.AddedAt.Format("2006-01-02"))))
+	}
+	covering := coveringRules(im.model, perm.Name)
+	if len(covering) == 0 {
+		lines = append(lines, field("Covered by", "no broader rule"))
+	} else {
+		lines = append(lines, field("Covered by", strings.Join(covering, ", ")))
+	}
+	lines = append(lines, field("If moved to", ""))
+	for _, line := range moveImpactLines(im.model, perm.Name, perm.CurrentLevel) {
+		lines = append(lines, bodyStyle.PaddingLeft(2).Render(line))
+	}
+	if edit, ok := ruleEditFor(im.model, perm.CurrentLevel, perm.Name); ok {
+		pending := "delete"
+		if edit.Replacement != "" {
+			pending = "replace with " + edit.Replacement
+		}
+		lines = append(lines, field("Pending", pending))
+	}
+
+	actions := []string{
+		formatFooterAction("1-3", "Move"),
+		formatFooterAction("C", "Edit"),
+		formatFooterAction("DELETE", "Delete"),
+		formatFooterAction("Y", "Copy"),
+		formatFooterAction("ESC", "Close"),
+	}
+	instructions := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(contentWidth-4).
+		Padding(1, 0, 0, 0).
+		Render(joinFooterActions(actions))
+	lines = append(lines, instructions)
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// HandleInput is unused; the inspector's keys are handled by handleInspectorInput, which can
+// return the command copying the rule
+func (im *InspectorModal) HandleInput(key string) (handled bool, result interface{}) {
+	return false, nil
+}
+
+// handleInspectorInput runs the inspector's quick actions on the inspected rule, closing the
+// inspector
+func handleInspectorInput(m *types.Model, im *InspectorModal, key string) (*types.Model, tea.Cmd) {
+	perm := im.perm
+	switch key {
+	case "1", "2", "3":
+		m.ActiveModal = nil
+		return handleNumberKeys(m, key), nil
+	case "c", "C":
+		rule := perm.Name
+		if edit, ok := ruleEditFor(m, perm.CurrentLevel, perm.Name); ok && edit.Replacement != "" {
+			rule = edit.Replacement
+		}
+		m.ActiveModal = NewRuleEditModal(perm.Name, rule, perm.CurrentLevel)
+		return m, nil
+	case "delete", "backspace":
+		m.ActiveModal = nil
+		return toggleRuleDelete(m, perm), nil
+	case "y", "Y":
+		m.ActiveModal = nil
+		return m, copyRule(m, perm.Name)
+	case keyEnter, keyEscapeLong, keyEscape:
+		m.ActiveModal = nil
+	}
+	return m, nil
+}
+
+// ruleEditFor returns the pending edit of rule in level
+func ruleEditFor(m *types.Model, level, rule string) (types.RuleEdit, bool) {
+	for _, edit := range m.RuleEdits {
+		if edit.Level == level && edit.Rule == rule {
+			return edit, true
+		}
+	}
+	return types.RuleEdit{}, false
+}
+
+// setRuleEdit records the pending edit of a rule, replacing any earlier one; a replacement
+// equal to the rule drops the edit
+func setRuleEdit(m *types.Model, edit types.RuleEdit) {
+	m.RuleEdits = slices.DeleteFunc(m.RuleEdits, func(other types.RuleEdit) bool {
+		return other.Level == edit.Level && other.Rule == edit.Rule
+	})
+	if edit.Replacement != edit.Rule {
+		m.RuleEdits = append(m.RuleEdits, edit)
+	}
+}
+
+// toggleRuleDelete marks perm for deletion, or unmarks it when it already is
+func toggleRuleDelete(m *types.Model, perm types.Permission) *types.Model {
+	if level := getLevelByName(m, perm.CurrentLevel); level.LoadError != "" {
+		m.Notifications.Warn("%s settings could not be loaded and are read-only", level.Name)
+		return rejectInput(m)
+	}
+	edit := types.RuleEdit{Level: perm.CurrentLevel, Rule: perm.Name}
+	if pending, ok := ruleEditFor(m, perm.CurrentLevel, perm.Name); ok &&
+		pending.Replacement == "" {
+		edit.Replacement = perm.Name
+	}
+	setRuleEdit(m, edit)
+	return m
+}
+
+// applyRuleEdit stores the rule entered in the edit prompt, keeping the prompt open with an
+// error when the rule is invalid or already in the level
+func applyRuleEdit(m *types.Model, rm *RuleEditModal) *types.Model {
+	replacement := strings.TrimSpace(rm.input)
+	if err := validateRule(replacement); err != nil {
+		rm.err = err.Error()
+		return m
+	}
+	level := getLevelByName(m, rm.level)
+	if level.LoadError != "" {
+		rm.err = fmt.Sprintf("%s settings could not be loaded and are read-only", level.Name)
+		return m
+	}
+	if replacement != rm.original && slices.Contains(level.Permissions, replacement) {
+		rm.err = fmt.Sprintf("%s is already in %s", replacement, rm.level)
+		return m
+	}
+	setRuleEdit(m, types.RuleEdit{Level: rm.level, Rule: rm.original, Replacement: replacement})
+	m.ActiveModal = nil
+	return m
+}
+
+// validateRule checks that rule has the Tool or Tool(specifier) form
+func validateRule(rule string) error {
+	if rule == "" {
+		return errors.New("enter a rule such as Bash(git status)")
+	}
+	parsed := rules.Parse(rule)
+	if parsed.Tool == "" || strings.ContainsAny(parsed.Tool, " ()") {
+		return fmt.Errorf("%q is not of the form Tool or Tool(specifier)", rule)
+	}
+	if strings.Contains(rule, "(") && !strings.HasSuffix(rule, ")") {
+		return fmt.Errorf("%q is missing its closing parenthesis", rule)
+	}
+	return nil
+}
+
+// pendingRuleEdits returns the edits whose rule is still in its level; rules moved away
+// since they were edited are left alone
+func pendingRuleEdits(m *types.Model) []types.RuleEdit {
+	var present []types.RuleEdit
+	for _, edit := range m.RuleEdits {
+		level := getLevelByName(m, edit.Level)
+		if level != nil && slices.Contains(level.Permissions, edit.Rule) {
+			present = append(present, edit)
+		}
+	}
+	return present
+}
+
+// applyRuleEdits replaces edited rules in place and removes deleted ones
+func applyRuleEdits(m *types.Model) {
+	for _, edit := range pendingRuleEdits(m) {
+		level := getLevelByName(m, edit.Level)
+		if edit.Replacement == "" {
+			level.Permissions = removePermission(level.Permissions, edit.Rule)
+			continue
+		}
+		level.Permissions[slices.Index(level.Permissions, edit.Rule)] = edit.Replacement
+	}
+}
+
+// ruleEditAuditEntries returns an audit entry for each rule edited or deleted
+func ruleEditAuditEntries(m *types.Model, now time.Time) []audit.Entry {
+	var entries []audit.Entry
+	for _, edit := range pendingRuleEdits(m) {
+		action := audit.ActionEditRule
+		if edit.Replacement == "" {
+			action = audit.ActionDeleteRule
+		}
+		entries = append(entries, audit.Entry{
+			Timestamp: now,
+			Rule:      edit.Rule,
+			From:      edit.Level,
+			To:        edit.Replacement,
+			Action:    action,
+		})
+	}
+	return entries
+}
+
+// ruleEditChanges lists the rules edited or deleted in the inspector
+func ruleEditChanges(m *types.Model) []types.PendingChange {
+	var changes []types.PendingChange
+	for _, edit := range pendingRuleEdits(m) {
+		if edit.Replacement == "" {
+			changes = append(changes, types.PendingChange{
+				Kind: types.ChangeDelete,
+				Rule: edit.Rule,
+				From: []string{edit.Level},
+				Line: fmt.Sprintf("• %s: Delete from %s",
+					edit.Rule, getLevelStyledText(edit.Level)),
+			})
+			continue
+		}
+		changes = append(changes, types.PendingChange{
+			Kind:  types.ChangeEdit,
+			Rule:  edit.Rule,
+			Level: edit.Level,
+			After: edit.Replacement,
+			Line: fmt.Sprintf("• %s → %s in %s",
+				edit.Rule, edit.Replacement, getLevelStyledText(edit.Level)),
+		})
+	}
+	return changes
+}
+
+// RuleEditModal implements types.Modal for editing an allow rule's text
+type RuleEditModal struct {
+	original string
+	level    string
+	input    string
+	err      string
+}
+
+// NewRuleEditModal creates the prompt editing original in level, prefilled with rule
+func NewRuleEditModal(original, rule, level string) *RuleEditModal {
+	return &RuleEditModal{original: original, level: level, input: rule}
+}
+
+// CapturesText reports that printable keys are typed into the rule input
+func (rm *RuleEditModal) CapturesText() bool {
+	return true
+}
+
+// RenderModal renders the rule prompt
+func (rm *RuleEditModal) RenderModal(width, height int) string {
+	contentWidth := min(80, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	lines := []string{
+		titleStyle.Render("Edit Rule"),
+		"",
+		fmt.Sprintf("%s %s", AccentStyle.Render("Level:"), getLevelStyledText(rm.level)),
+		fmt.Sprintf("%s %s█", AccentStyle.Render("Rule:"), rm.input),
+		CountStyle.Render("Tool or Tool(specifier)"),
+	}
+	if rm.err != "" {
+		lines = append(lines, "", ErrorStyle.Render(rm.err))
+	}
+
+	actions := []string{
+		formatFooterAction("ENTER", "Confirm"),
+		formatFooterAction("ESC", "Cancel"),
+	}
+	instructions := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(contentWidth-4).
+		Padding(1, 0, 0, 0).
+		Render(joinFooterActions(actions))
+	lines = append(lines, instructions)
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// HandleInput processes keyboard input for the rule prompt
+func (rm *RuleEditModal) HandleInput(key string) (handled bool, result interface{}) {
+	switch key {
+	case keyEnter:
+		return true, "rule_set"
+	case keyEscapeLong, keyEscape:
+		return true, "cancel"
+	case "backspace":
+		if runes := []rune(rm.input); len(runes) > 0 {
+			rm.input = string(runes[:len(runes)-1])
+		}
+	case "space":
+		rm.input += " "
+	default:
+		if len([]rune(key)) == 1 {
+			rm.input += key
+		}
+	}
+	rm.err = ""
+	return false, nil
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"os"
+	"strings"
+)
+
+// colorDisabled reports whether the user asked for output without color (https://no-color.org)
+func colorDisabled() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// HighlightJSON colors the keys, strings, literals, and punctuation of JSON text, keeping its
+// layout. Invalid JSON is highlighted on a best-effort basis; with NO_COLOR set the text is
+// returned unchanged.
+func HighlightJSON(src string) string {
+	if colorDisabled() {
+		return src
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(src); {
+		switch c := src[i]; {
+		case c == '"':
+			end := stringEnd(src, i)
+			token := src[i:end]
+			style := JSONStringStyle
+			if isObjectKey(src, end) {
+				style = JSONKeyStyle
+			}
+			out.WriteString(style.Render(token))
+			i = end
+		case strings.IndexByte("{}[],:", c) >= 0:
+			out.WriteString(JSONPunctuationStyle.Render(string(c)))
+			i++
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			out.WriteByte(c)
+			i++
+		default:
+			end := i
+			for end < len(src) && strings.IndexByte("{}[],:\" \t\r\n", src[end]) < 0 {
+				end++
+			}
+			out.WriteString(JSONLiteralStyle.Render(src[i:end]))
+			i = end
+		}
+	}
+	return out.String()
+}
+
+// stringEnd returns the index just past the string starting at the quote src[start], or the
+// end of the line for an unterminated string
+func stringEnd(src string, start int) int {
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		case '\n':
+			return i
+		}
+	}
+	return len(src)
+}
+
+// isObjectKey reports whether the string ending at end is followed by a colon
+func isObjectKey(src string, end int) bool {
+	rest := strings.TrimLeft(src[end:], " \t\r\n")
+	return strings.HasPrefix(rest, ":")
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"strings"
+
+	"claude-permissions/keymap"
+	"claude-permissions/types"
+)
+
+// arrowLabels are display labels that are joined without a separator, e.g. "↑↓"
+var arrowLabels = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+}
+
+// canonicalKey translates a pressed key to the default key of its bound action, since the
+// key handlers are written against the default bindings. Unbound keys yield "".
+func canonicalKey(m *types.Model, key string) string {
+	action := m.Keys.Action(key)
+	if action == "" {
+		return ""
+	}
+	return keymap.Defaults[action][0]
+}
+
+// displayKey renders a non-arrow key, marking uppercase letters so "D" differs from "d"
+func displayKey(key string) string {
+	if len(key) == 1 && key >= "A" && key <= "Z" {
+		return "Shift+" + key
+	}
+	return strings.ToUpper(key)
+}
+
+// keyLabel renders the display keys of one or more actions for the footer, e.g. "1/2/3"
+func keyLabel(m *types.Model, actions ...string) string {
+	labels := make([]string, 0, len(actions))
+	allArrows := true
+	for _, action := range actions {
+		keys := m.Keys.Keys(action)
+		if len(keys) == 0 {
+			continue
+		}
+		label, isArrow := arrowLabels[keys[0]]
+		if !isArrow {
+			label = displayKey(keys[0])
+			allArrows = false
+		}
+		labels = append(labels, label)
+	}
+
+	if allArrows {
+		return strings.Join(labels, "")
+	}
+	return strings.Join(labels, "/")
+}
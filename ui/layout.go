@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"claude-permissions/debug"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// Let the debug server's layout diagnostics place the sections the screen is rendered from
+func init() {
+	debug.RegisterLayoutProvider(layoutComponents)
+}
+
+// layoutComponents measures the sections of the main layout where View stacks them, and the
+// active modal where it is overlaid
+func layoutComponents(m *types.Model) map[string]debug.ComponentPosition {
+	components := make(map[string]debug.ComponentPosition)
+	y := 0
+	for _, section := range mainLayoutSections(m) {
+		height := lipgloss.Height(section.view)
+		components[section.name] = debug.ComponentPosition{
+			X: 0, Y: y, W: lipgloss.Width(section.view), H: height,
+		}
+		y += height
+	}
+	if m.ActiveModal != nil {
+		content, x, y := placeModal(m)
+		components["modal"] = debug.ComponentPosition{
+			X: x, Y: y, W: lipgloss.Width(content), H: lipgloss.Height(content),
+		}
+	}
+	return components
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"claude-permissions/logging"
+	"claude-permissions/types"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// keyOpenFile opens the broken settings file from the load error modal
+const keyOpenFile = "o"
+
+// settingsFileEditedMsg reports that the editor opened on a broken settings file exited
+type settingsFileEditedMsg struct {
+	path string
+	err  error
+}
+
+// brokenLevels returns the editable levels whose settings file could not be loaded
+func brokenLevels(m *types.Model) []types.SettingsLevel {
+	var broken []types.SettingsLevel
+	for _, name := range editableLevels {
+		if level := getLevelByName(m, name); level.LoadError != "" {
+			broken = append(broken, *level)
+		}
+	}
+	return broken
+}
+
+// OfferLoadErrors shows why settings files could not be loaded, if any
+func OfferLoadErrors(m *types.Model) {
+	if broken := brokenLevels(m); len(broken) > 0 {
+		m.ActiveModal = &LoadErrorModal{levels: broken}
+	}
+}
+
+// LoadErrorModal implements types.Modal for settings files that could not be loaded,
+// offering to open the first one in an editor or to continue with the levels read-only
+type LoadErrorModal struct {
+	levels []types.SettingsLevel
+}
+
+// RenderModal renders the load error of each broken level
+func (lm *LoadErrorModal) RenderModal(width, height int) string {
+	contentWidth := min(80, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	textStyle := lipgloss.NewStyle().Width(contentWidth - 4)
+	lines := []string{titleStyle.Render("Settings File Error"), ""}
+	for _, level := range lm.levels {
+		lines = append(lines,
+			fmt.Sprintf("%s %s", getLevelStyledText(level.Name), level.Path),
+			textStyle.Render(ErrorStyle.Render(level.LoadError)),
+			"")
+	}
+	lines = append(lines, textStyle.Render(
+		"These levels are shown empty and read-only until the file is fixed."))
+
+	instructions := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(contentWidth-4).
+		Padding(1, 0, 0, 0).
+		Render(joinFooterActions([]string{
+			formatFooterAction("O", "Open in editor"),
+			formatFooterAction("ENTER", "Continue read-only"),
+		}))
+	lines = append(lines, instructions)
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// HandleInput opens the first broken file or closes the modal
+func (lm *LoadErrorModal) HandleInput(key string) (handled bool, result interface{}) {
+	switch key {
+	case keyOpenFile:
+		return true, "open_settings_file"
+	case keyEnter, keyEscapeLong, keyEscape:
+		return true, "cancel"
+	}
+	return false, nil
+}
+
+// handleLoadErrorInput handles the load error modal, which unlike other modals can start
+// an external editor
+func handleLoadErrorInput(m *types.Model, lm *LoadErrorModal, key string) (*types.Model, tea.Cmd) {
+	handled, result := lm.HandleInput(key)
+	if !handled {
+		return m, nil
+	}
+	m.ActiveModal = nil
+	if result != "open_settings_file" {
+		return m, nil
+	}
+	return m, openInEditor(lm.levels[0].Path)
+}
+
+// openInEditor suspends the TUI to edit path with $VISUAL or $EDITOR, falling back to vi
+func openInEditor(path string) tea.Cmd {
+	editor := strings.Fields(os.Getenv("VISUAL"))
+	if len(editor) == 0 {
+		editor = strings.Fields(os.Getenv("EDITOR"))
+	}
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	cmd := exec.Command(editor[0], append(editor[1:], path)...) // #nosec G204 - user's editor
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return settingsFileEditedMsg{path: path, err: err}
+	})
+}
+
+// handleSettingsFileEdited reloads the project after the editor exits, offering the load
+// errors again for files that are still broken
+func handleSettingsFileEdited(m *types.Model, msg settingsFileEditedMsg) *types.Model {
+	log := logFor(m, logging.ComponentProject)
+	if msg.err != nil {
+		log.Warn("editor_failed", logging.Path(msg.path), logging.Err(msg.err))
+		m.Notifications.Warn("Editor failed: %v", msg.err)
+	}
+	if m.ProjectLoader == nil {
+		return m
+	}
+	if err := m.ProjectLoader(m, m.ProjectRoot); err != nil {
+		log.Warn("project_reload_failed", logging.Project(m.ProjectRoot), logging.Err(err))
+		m.Notifications.Warn("Reload failed: %v", err)
+		return m
+	}
+	m.SessionDirty = false
+	OfferLoadErrors(m)
+	if m.ActiveModal == nil {
+		m.Notifications.Info("Reloaded %s", msg.path)
+	}
+	return m
+}
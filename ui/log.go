@@ -0,0 +1,24 @@
+package ui
+
+import (
+	"log/slog"
+
+	"claude-permissions/logging"
+	"claude-permissions/types"
+)
+
+// screenLogNames are the values of the screen log attribute
+var screenLogNames = map[int]string{
+	types.ScreenDuplicates:   "duplicates",
+	types.ScreenOrganization: "organization",
+	types.ScreenHistory:      "history",
+	types.ScreenHooks:        "hooks",
+	types.ScreenEnv:          "env",
+	types.ScreenDirectories:  "directories",
+	types.ScreenPolicy:       "policy",
+}
+
+// logFor returns a logger for component tagged with the current screen
+func logFor(m *types.Model, component string) *slog.Logger {
+	return logging.For(component).With(logging.Screen(screenLogNames[m.CurrentScreen]))
+}
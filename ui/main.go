@@ -2,19 +2,24 @@ package ui
 
 import (
 	"fmt"
-	"os"
+	"path/filepath"
 	"strings"
 
 	"claude-permissions/debug"
+	"claude-permissions/docs"
+	"claude-permissions/paths"
 	"claude-permissions/types"
 
 	tea "github.com/charmbracelet/bubbletea/v2"
 	"github.com/charmbracelet/lipgloss/v2"
 )
 
-// Init initializes the model
-func Init(_ *types.Model) tea.Cmd {
+// Init initializes the model, starting the release check when it was requested
+func Init(m *types.Model) tea.Cmd {
 	// WindowSizeMsg will be sent automatically in v2
+	if m.CheckUpdate {
+		return checkForUpdate()
+	}
 	return nil
 }
 
@@ -28,14 +33,66 @@ func Update(m *types.Model, msg tea.Msg) (*types.Model, tea.Cmd) {
 		// Update terminal dimensions - no layout engine needed
 		m.Width = msg.Width
 		m.Height = msg.Height
+		showScreenHint(m)
+		scrollColumnsToCursor(m)
+		scrollDuplicatesToCursor(m)
+		scrollHistory(m)
+		scrollPolicy(m)
 		return m, nil
 
 	case tea.KeyMsg:
-		return handleKeyPress(m, msg)
+		newModel, cmd := handleKeyPress(m, msg)
+		showScreenHint(newModel)
+		scrollColumnsToCursor(newModel)
+		scrollDuplicatesToCursor(newModel)
+		persistSession(newModel)
+		return newModel, tea.Batch(cmd, scheduleNotificationTick(newModel), ringBell(newModel))
+
+	case NotificationsChangedMsg, notificationTickMsg:
+		return m, scheduleNotificationTick(m)
+
+	case flashEndMsg:
+		return m, nil
+
+	case clipboardReadMsg:
+		newModel := handlePastedText(m, msg.text)
+		persistSession(newModel)
+		return newModel, tea.Batch(scheduleNotificationTick(newModel), ringBell(newModel))
+
+	case tea.ClipboardMsg:
+		newModel := handlePastedText(m, msg.String())
+		persistSession(newModel)
+		return newModel, tea.Batch(scheduleNotificationTick(newModel), ringBell(newModel))
+
+	case saveProgressMsg:
+		return handleSaveProgress(m, msg)
+
+	case saveDoneMsg:
+		newModel, cmd := handleSaveDone(m, msg)
+		persistSession(newModel)
+		return newModel, tea.Batch(cmd, scheduleNotificationTick(newModel), ringBell(newModel))
+
+	case gitCommitDoneMsg:
+		newModel := handleGitCommitDone(m, msg)
+		return newModel, tea.Batch(scheduleNotificationTick(newModel), ringBell(newModel))
+
+	case settingsFileEditedMsg:
+		newModel := handleSettingsFileEdited(m, msg)
+		return newModel, scheduleNotificationTick(newModel)
+
+	case updateAvailableMsg:
+		m.UpdateAvailable = msg.version
+		return m, nil
 
 	case debug.LaunchConfirmChangesMsg:
 		return handleLaunchConfirmChanges(m, msg), nil
 
+	case debug.LoadStateMsg:
+		newModel := handleLoadState(m, msg)
+		scrollColumnsToCursor(newModel)
+		scrollDuplicatesToCursor(newModel)
+		return newModel, nil
+
 	default:
 		return m, nil
 	}
@@ -62,144 +119,164 @@ func View(m *types.Model) string {
 	return baseContent
 }
 
-// renderMainLayout renders the main UI using pure lipgloss composition
-func renderMainLayout(m *types.Model) string {
-	// Create header component
+// layoutChrome holds the rendered sections surrounding the main content area
+type layoutChrome struct {
+	header string
+	footer string
+	status string
+	hint   string // Empty when no hint is active
+}
+
+// renderLayoutChrome renders the header, footer, status bar, and hint bar
+func renderLayoutChrome(m *types.Model) layoutChrome {
 	header := NewHeaderComponent(m.Width)
 	header.SetContent(renderHeaderContent(m))
 
-	// Create footer component
 	footer := NewFooterComponent(m.Width)
 	footer.SetContent(renderFooterContent(m))
 
+	return layoutChrome{
+		header: header.View(),
+		footer: footer.View(),
+		status: renderStatusBarContent(m),
+		hint:   renderHintBar(m),
+	}
+}
+
+// contentHeight returns the height left for content once the chrome is placed
+func (lc layoutChrome) contentHeight(totalHeight int) int {
 	// Use lipgloss dynamic height calculation (following best practices)
-	headerContent := header.View()
-	footerContent := footer.View()
-	headerHeight := lipgloss.Height(headerContent)
-	footerHeight := lipgloss.Height(footerContent)
+	height := totalHeight - lipgloss.Height(lc.header) - lipgloss.Height(lc.footer) -
+		lipgloss.Height(lc.status)
+	if lc.hint != "" {
+		height -= lipgloss.Height(lc.hint)
+	}
+	return height
+}
 
-	// Create and render status bar
-	statusContent := renderStatusBarContent(m)
-	statusHeight := lipgloss.Height(statusContent)
+// layoutSection is one rendered section of the main layout
+type layoutSection struct {
+	name string
+	view string
+}
+
+// mainLayoutSections renders the sections of the main layout, top to bottom
+func mainLayoutSections(m *types.Model) []layoutSection {
+	chrome := renderLayoutChrome(m)
+	content := NewContentComponent(m.Width, chrome.contentHeight(m.Height), m)
 
-	// Calculate content height: total minus header, footer, and status
-	contentHeight := m.Height - headerHeight - footerHeight - statusHeight
+	sections := []layoutSection{{"header", chrome.header}, {"content", content.View()}}
+	if chrome.hint != "" {
+		sections = append(sections, layoutSection{"hint", chrome.hint})
+	}
+	return append(sections, layoutSection{"status", chrome.status},
+		layoutSection{"footer", chrome.footer})
+}
 
-	// Create content component
-	content := NewContentComponent(m.Width, contentHeight, m)
+// renderMainLayout renders the main UI using pure lipgloss composition
+func renderMainLayout(m *types.Model) string {
+	var views []string
+	for _, section := range mainLayoutSections(m) {
+		views = append(views, section.view)
+	}
 
 	// Join all components vertically using pure lipgloss
-	return lipgloss.JoinVertical(lipgloss.Top,
-		headerContent,
-		content.View(),
-		statusContent,
-		footerContent,
-	)
+	return lipgloss.JoinVertical(lipgloss.Top, views...)
 }
 
 // renderHeaderContent generates the header content string with file status and current directory
 func renderHeaderContent(m *types.Model) string {
-	// File status indicators using centralized theme
-	userStatus := "X"
-	userStatusStyle := ErrorStyle
-	if m.UserLevel.Exists {
-		userStatus = "OK"
-		userStatusStyle = SuccessStyle
-	}
-
-	repoStatus := "X"
-	repoStatusStyle := ErrorStyle
-	if m.RepoLevel.Exists {
-		repoStatus = "OK"
-		repoStatusStyle = SuccessStyle
-	}
-
-	localStatus := "X"
-	localStatusStyle := ErrorStyle
-	if m.LocalLevel.Exists {
-		localStatus = "OK"
-		localStatusStyle = SuccessStyle
-	}
-
 	// Build file info with themed styling
 	fileInfo := fmt.Sprintf(
 		"Files: Local:%s%s Repo:%s%s User:%s%s",
-		localStatusStyle.Render(localStatus),
+		renderFileStatus(m.LocalLevel),
 		CountStyle.Render(fmt.Sprintf("(%d)", len(m.LocalLevel.Permissions))),
-		repoStatusStyle.Render(repoStatus),
+		renderFileStatus(m.RepoLevel),
 		CountStyle.Render(fmt.Sprintf("(%d)", len(m.RepoLevel.Permissions))),
-		userStatusStyle.Render(userStatus),
+		renderFileStatus(m.UserLevel),
 		CountStyle.Render(fmt.Sprintf("(%d)", len(m.UserLevel.Permissions))),
 	)
 
-	// Current working directory with accent color
-	cwd, _ := os.Getwd()
-	currentDir := fmt.Sprintf("%s %s", AccentStyle.Render("Current:"), cwd)
+	// Managed policy is only shown when present since most users have none
+	if m.ManagedLevel.Exists {
+		managedCount := len(m.ManagedLevel.Permissions) + len(m.ManagedLevel.Deny)
+		fileInfo += fmt.Sprintf(" Managed:%s%s",
+			SuccessStyle.Render("OK"), CountStyle.Render(fmt.Sprintf("(%d)", managedCount)))
+	}
+
+	// Active project root with accent color
+	currentDir := fmt.Sprintf("%s %s", AccentStyle.Render("Project:"), m.ProjectRoot)
 
 	// Build header text with themed styling
 	title := TitleStyle.Render("Claude Code Permission Editor")
 
-	return fmt.Sprintf("%s\n%s | %s", title, fileInfo, currentDir)
+	return fmt.Sprintf("%s\n%s | %s\n%s", title, fileInfo, currentDir, renderPathsLine(m))
 }
 
-// renderFooterContent generates the footer content string with context-sensitive hotkeys
-func renderFooterContent(m *types.Model) string {
-	var row1Actions, row2Actions []string
+// renderFileStatus renders the header badge of a level's file: OK when loaded, X when
+// missing, and ERR when it could not be loaded
+func renderFileStatus(level types.SettingsLevel) string {
+	switch {
+	case level.LoadError != "":
+		return ErrorStyle.Bold(true).Render("ERR")
+	case level.Exists:
+		return SuccessStyle.Render("OK")
+	}
+	return ErrorStyle.Render("X")
+}
 
-	switch m.CurrentScreen {
-	case types.ScreenDuplicates:
-		row1Actions = []string{
-			formatFooterAction("TAB", "Switch panel"),
-			formatFooterAction("↑↓", "Navigate"),
-		}
-		row2Actions = []string{
-			formatFooterAction("ENTER", "Save"),
-			formatFooterAction("ESC", "Reset changes"),
-			formatFooterAction("1/2/3", "Keep in LOCAL/REPO/USER"),
-		}
-	case types.ScreenOrganization:
-		row1Actions = []string{
-			formatFooterAction("TAB", "Switch panel"),
-			formatFooterAction("↑↓", "Navigate within column"),
-			formatFooterAction("←→", "Switch between columns"),
-		}
-		row2Actions = []string{
-			formatFooterAction("ENTER", "Save"),
-			formatFooterAction("ESC", "Reset changes"),
-			formatFooterAction("1/2/3", "Move to LOCAL/REPO/USER"),
-		}
-	default:
-		// Generic footer
-		row1Actions = []string{
-			formatFooterAction("TAB", "Switch panel"),
-			formatFooterAction("↑↓", "Navigate"),
-		}
-		row2Actions = []string{
-			formatFooterAction("SPACE", "Select"),
-			formatFooterAction("ENTER", "Confirm"),
-			formatFooterAction("Q", "Quit"),
+// renderPathsLine lists the resolved settings file of each level, relative to the project
+// root where possible
+func renderPathsLine(m *types.Model) string {
+	levels := []types.SettingsLevel{m.LocalLevel, m.RepoLevel, m.UserLevel}
+	entries := make([]string, 0, len(levels))
+	for _, level := range levels {
+		entries = append(entries, fmt.Sprintf("%s %s",
+			getLevelStyledText(level.Name), displayPath(m.ProjectRoot, level.Path)))
+	}
+	return fmt.Sprintf("%s %s", AccentStyle.Render("Paths:"), strings.Join(entries, "  "))
+}
+
+// displayPath shortens a settings path for the header
+func displayPath(projectRoot, path string) string {
+	if path == "" {
+		return "(none)"
+	}
+	if projectRoot != "" {
+		rel, err := filepath.Rel(projectRoot, path)
+		if err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
 		}
 	}
+	return paths.Abbreviate(path)
+}
 
-	return buildTwoRowFooter(row1Actions, row2Actions)
+// renderFooterContent generates the footer with the most common keys of the current screen,
+// followed by a newer release when the update check found one; the help overlay lists the
+// rest
+func renderFooterContent(m *types.Model) string {
+	actions := currentScreen(m).Keymap(m)
+	if m.UpdateAvailable != "" {
+		actions = append(actions, SuccessStyle.Render("Update available: "+m.UpdateAvailable))
+	}
+	return joinFooterActions(actions)
 }
 
 // renderStatusBarContent generates the status bar with contextual information
 func renderStatusBarContent(m *types.Model) string {
-	var statusText string
+	statusText := currentScreen(m).StatusText(m)
 
-	switch m.CurrentScreen {
-	case types.ScreenDuplicates:
-		statusText = renderDuplicatesStatusText(m)
-	case types.ScreenOrganization:
-		statusText = renderOrganizationStatusText(m)
-	default:
-		statusText = "Claude Code Permission Editor"
+	// Notifications take over the status bar while they are shown
+	if notification := renderNotification(m); notification != "" {
+		statusText = notification
 	}
 
-	// Style the status bar using centralized theme
-	statusBarStyle := StatusBarStyle.Width(m.Width)
-	return statusBarStyle.Render(statusText)
+	// Style the status bar using centralized theme; a flash signals rejected input
+	statusBarStyle := StatusBarStyle
+	if statusBarFlashing(m) {
+		statusBarStyle = flashStyle
+	}
+	return statusBarStyle.Width(m.Width).Render(statusText)
 }
 
 // renderDuplicatesStatusText generates status text for duplicates screen
@@ -230,12 +307,22 @@ func renderOrganizationStatusText(m *types.Model) string {
 	columnPerms := getColumnPermissions(m)
 	if len(columnPerms) > 0 && m.ColumnSelections[m.FocusedColumn] < len(columnPerms) {
 		selectedPerm := columnPerms[m.ColumnSelections[m.FocusedColumn]]
-		return fmt.Sprintf(
+		status := fmt.Sprintf(
 			"%s (originally %s → in %s)",
 			selectedPerm.Name,
 			selectedPerm.OriginalLevel,
 			selectedPerm.CurrentLevel,
 		)
+		if p := selectedPerm.Provenance; p != nil {
+			added := p.AddedAt.Format("2006-01-02")
+			status += fmt.Sprintf(" ◆ added by %s on %s", p.Source, added)
+		}
+		// The matching summary is dropped rather than wrapping the status bar
+		withDocs := status + " ◆ " + docs.For(selectedPerm.Name).Summary
+		if lipgloss.Width(withDocs) <= m.Width-StatusBarStyle.GetHorizontalPadding() {
+			return withDocs
+		}
+		return status
 	}
 	return "Ready to organize permissions"
 }
@@ -1,10 +1,15 @@
 package ui
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 
+	"claude-permissions/paths"
+	"claude-permissions/rules"
 	"claude-permissions/types"
 
+	"github.com/charmbracelet/bubbles/v2/table"
 	"github.com/charmbracelet/lipgloss/v2"
 )
 
@@ -13,6 +18,7 @@ type SmallModal struct {
 	Title  string
 	Body   string
 	Action string // "continue", "exit", etc.
+	body   scrollBody
 }
 
 // NewSmallModal creates a new small modal dialog
@@ -21,13 +27,14 @@ func NewSmallModal(title, body, action string) *SmallModal {
 		Title:  title,
 		Body:   body,
 		Action: action,
+		body:   newScrollBody(),
 	}
 }
 
 // RenderModal renders the small modal content (extracted from renderModal function)
 func (sm *SmallModal) RenderModal(width, height int) string {
-	// Calculate modal dimensions
-	contentWidth := 60
+	// Calculate modal dimensions, narrowing on small terminals
+	contentWidth := min(60, width-4)
 
 	// Create modal content with high contrast styling
 	modalStyle := lipgloss.NewStyle().
@@ -46,7 +53,6 @@ func (sm *SmallModal) RenderModal(width, height int) string {
 
 	bodyStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(ColorTitle)).
-		Width(contentWidth-4). // Account for padding
 		Padding(1, 0)
 
 	// Style instructions consistently with footer hints using AccentStyle
@@ -56,14 +62,19 @@ func (sm *SmallModal) RenderModal(width, height int) string {
 		Padding(1, 0, 0, 0)
 
 	title := titleStyle.Render(sm.Title)
-	body := bodyStyle.Render(sm.Body)
+	// The border and padding take six columns; they, the title, the body padding, and the
+	// instructions take nine rows
+	body := bodyStyle.Render(sm.body.render(sm.Body, contentWidth-6, height-9))
 
 	// Use consistent footer formatting
-	confirmAction := formatFooterAction("ENTER", "Confirm")
-	cancelAction := formatFooterAction("ESC", "Cancel")
-	instructions := instructionsStyle.Render(
-		joinFooterActions([]string{confirmAction, cancelAction}),
-	)
+	actions := []string{
+		formatFooterAction("ENTER", "Confirm"),
+		formatFooterAction("ESC", "Cancel"),
+	}
+	if sm.body.scrollable() {
+		actions = append(actions, formatFooterAction("↑↓", "Scroll"))
+	}
+	instructions := instructionsStyle.Render(joinFooterActions(actions))
 
 	modalContent := modalStyle.Render(
 		lipgloss.JoinVertical(lipgloss.Left, title, body, instructions),
@@ -80,20 +91,90 @@ func (sm *SmallModal) HandleInput(key string) (handled bool, result interface{})
 	case "n", "N", keyEscapeLong, keyEscape:
 		return true, "no"
 	default:
+		sm.body.scroll(key)
 		return false, nil
 	}
 }
 
 // ConfirmChangesModal implements types.Modal for full-screen confirm changes dialog
 type ConfirmChangesModal struct {
-	model *types.Model
+	model    *types.Model
+	selected map[string]bool // Changed levels ticked for saving, all of them at first
+	armed    bool            // ENTER was pressed once with risky changes listed
+	body     scrollBody
 }
 
 // NewConfirmChangesModal creates a new confirm changes modal
 func NewConfirmChangesModal(model *types.Model) *ConfirmChangesModal {
+	selected := make(map[string]bool)
+	for _, level := range changedLevels(model) {
+		selected[level.Name] = true
+	}
 	return &ConfirmChangesModal{
-		model: model,
+		model:    model,
+		selected: selected,
+		body:     newScrollBody(),
+	}
+}
+
+// Selection returns the levels ticked for saving in LOCAL, REPO, USER order
+func (ccm *ConfirmChangesModal) Selection() []string {
+	var levels []string
+	for _, name := range editableLevels {
+		if ccm.selected[name] {
+			levels = append(levels, name)
+		}
+	}
+	return levels
+}
+
+// selectedLevels returns the changed levels ticked for saving
+func (ccm *ConfirmChangesModal) selectedLevels() []*types.SettingsLevel {
+	return slices.DeleteFunc(changedLevels(ccm.model), func(level *types.SettingsLevel) bool {
+		return !ccm.selected[level.Name]
+	})
+}
+
+// buildRiskList lists the risky changes of the ticked files in the warning color
+func (ccm *ConfirmChangesModal) buildRiskList() []string {
+	risks := riskyChanges(ccm.model, ccm.selectedLevels())
+	if len(risks) == 0 {
+		return nil
 	}
+	lines := []string{WarningStyle.Render("Risky Changes (press ENTER twice to save):")}
+	for _, risk := range risks {
+		lines = append(lines, WarningStyle.Render(risk))
+	}
+	return append(lines, "")
+}
+
+// toggle ticks or unticks a changed level together with the levels its moves touch
+func (ccm *ConfirmChangesModal) toggle(level string) {
+	if _, ok := ccm.selected[level]; !ok {
+		return
+	}
+	ccm.armed = false
+	save := !ccm.selected[level]
+	for _, name := range linkedLevels(ccm.model, level) {
+		if _, ok := ccm.selected[name]; ok {
+			ccm.selected[name] = save
+		}
+	}
+}
+
+// buildFileList lists the files a save writes with a checkbox each
+func (ccm *ConfirmChangesModal) buildFileList() []string {
+	lines := []string{"Files (1/2/3 to toggle):"}
+	for _, level := range changedLevels(ccm.model) {
+		box, note := "[ ]", " (kept pending)"
+		if ccm.selected[level.Name] {
+			box, note = "[x]", ""
+		}
+		lines = append(lines, fmt.Sprintf("%s %s%s %s%s", box, getLevelStyledText(level.Name),
+			strings.Repeat(" ", len(types.LevelLocal)-len(level.Name)),
+			paths.Abbreviate(level.Path), note))
+	}
+	return lines
 }
 
 // RenderModal renders the confirm changes content (extracted from renderConfirmation function)
@@ -134,13 +215,25 @@ func (ccm *ConfirmChangesModal) RenderModal(width, height int) string {
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color(ColorBorderNormal)).
 		Padding(1)
-	content := contentStyle.Render(strings.Join(changeLines, "\n"))
+	changeLines = slices.Concat(ccm.buildFileList(), []string{""}, ccm.buildRiskList(),
+		changeLines)
+	// The border and padding take two columns and two rows on each side
+	body := ccm.body.render(strings.Join(changeLines, "\n"), width-4, height-10)
+	content := contentStyle.Render(body)
 
 	// Instructions using consistent footer formatting
+	confirm := formatFooterAction("ENTER", "Confirm")
+	if ccm.armed {
+		confirm = WarningStyle.Render("ENTER again · Save with risky changes")
+	}
 	row1Actions := []string{
-		formatFooterAction("ENTER", "Confirm"),
+		confirm,
+		formatFooterAction("1-3", "Toggle file"),
 		formatFooterAction("ESC", "Cancel"),
 	}
+	if ccm.body.scrollable() {
+		row1Actions = append(row1Actions, formatFooterAction("↑↓", "Scroll"))
+	}
 	row2Actions := []string{
 		formatFooterAction("Q", "Quit without saving"),
 	}
@@ -157,12 +250,257 @@ func (ccm *ConfirmChangesModal) RenderModal(width, height int) string {
 func (ccm *ConfirmChangesModal) HandleInput(key string) (handled bool, result interface{}) {
 	switch key {
 	case keyEnter:
+		if len(ccm.Selection()) == 0 {
+			return false, nil
+		}
+		// Risky changes take a second ENTER
+		if !ccm.armed && len(riskyChanges(ccm.model, ccm.selectedLevels())) > 0 {
+			ccm.armed = true
+			return false, nil
+		}
 		return true, "execute"
 	case keyEscapeLong, keyEscape:
 		return true, "cancel"
 	case "q", "Q":
 		return true, "quit"
+	case "1", "2", "3":
+		ccm.toggle(getTargetLevel(key))
+		return false, nil
 	default:
+		ccm.body.scroll(key)
 		return false, nil
 	}
 }
+
+// SimulationModal implements types.Modal for displaying permission prompt simulation results
+type SimulationModal struct {
+	decisions []rules.Decision
+	table     table.Model
+}
+
+// NewSimulationModal creates a new simulation results modal
+func NewSimulationModal(decisions []rules.Decision) *SimulationModal {
+	columns := []table.Column{
+		{Title: "Invocation", Width: 40},
+		{Title: "Outcome", Width: 10},
+		{Title: "Rule", Width: 30},
+		{Title: "Level", Width: 8},
+	}
+
+	rows := make([]table.Row, 0, len(decisions))
+	for _, d := range decisions {
+		rows = append(rows, table.Row{d.Invocation, string(d.Outcome), d.Rule, d.Level})
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithFocused(true),
+	)
+	t.SetStyles(CreateTableStyles())
+
+	return &SimulationModal{
+		decisions: decisions,
+		table:     t,
+	}
+}
+
+// RenderModal renders the simulation results as a full-screen table
+func (sm *SimulationModal) RenderModal(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Align(lipgloss.Center).
+		Width(width).
+		Padding(1)
+	title := titleStyle.Render("Permission Prompt Simulation")
+
+	summary := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(width).
+		Render(sm.renderSummary())
+
+	// Title (3) + summary (1) + border and padding (4) + footer (1)
+	sm.table.SetHeight(max(height-9, 3))
+	sm.table.SetWidth(width - 4)
+
+	contentStyle := lipgloss.NewStyle().
+		Width(width).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorBorderNormal)).
+		Padding(0, 1)
+	content := contentStyle.Render(sm.table.View())
+
+	instructions := joinFooterActions([]string{
+		formatFooterAction("↑↓", "Navigate"),
+		formatFooterAction("ESC", "Close"),
+	})
+	footer := lipgloss.NewStyle().Align(lipgloss.Center).Width(width).Render(instructions)
+
+	return lipgloss.JoinVertical(lipgloss.Top, title, summary, content, footer)
+}
+
+// renderSummary renders colored outcome totals
+func (sm *SimulationModal) renderSummary() string {
+	counts := make(map[rules.Outcome]int)
+	for _, d := range sm.decisions {
+		counts[d.Outcome]++
+	}
+
+	return fmt.Sprintf("%s  %s  %s",
+		SuccessStyle.Render(fmt.Sprintf("%d allowed", counts[rules.OutcomeAllow])),
+		ErrorStyle.Render(fmt.Sprintf("%d denied", counts[rules.OutcomeDeny])),
+		WarningStyle.Render(fmt.Sprintf("%d prompt", counts[rules.OutcomePrompt])),
+	)
+}
+
+// HandleInput processes keyboard input for the simulation modal
+func (sm *SimulationModal) HandleInput(key string) (handled bool, result interface{}) {
+	switch key {
+	case keyUp, "k":
+		sm.table.MoveUp(1)
+		return false, nil
+	case keyDown, "j":
+		sm.table.MoveDown(1)
+		return false, nil
+	case keyEnter, keyEscapeLong, keyEscape:
+		return true, "cancel"
+	default:
+		return false, nil
+	}
+}
+
+// ProjectModal implements types.Modal for switching the project whose settings are edited
+type ProjectModal struct {
+	current string
+	recent  []string
+	cursor  int
+	input   string
+	err     string
+	pending bool // Pending changes are discarded when switching
+}
+
+// NewProjectModal creates a project switcher listing recently opened projects
+func NewProjectModal(current string, recent []string, pending bool) *ProjectModal {
+	others := make([]string, 0, len(recent))
+	for _, dir := range recent {
+		if dir != current {
+			others = append(others, dir)
+		}
+	}
+	return &ProjectModal{current: current, recent: others, pending: pending}
+}
+
+// Selected returns the typed path, or the highlighted recent project when nothing was typed
+func (pm *ProjectModal) Selected() string {
+	if pm.input != "" {
+		return pm.input
+	}
+	if pm.cursor < len(pm.recent) {
+		return pm.recent[pm.cursor]
+	}
+	return ""
+}
+
+// SetError shows a load failure so the user can correct the path
+func (pm *ProjectModal) SetError(err error) {
+	pm.err = err.Error()
+}
+
+// CapturesText reports that printable keys are typed into the path input
+func (pm *ProjectModal) CapturesText() bool {
+	return true
+}
+
+// RenderModal renders the project switcher dialog
+func (pm *ProjectModal) RenderModal(width, height int) string {
+	contentWidth := min(80, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	lines := []string{
+		titleStyle.Render("Switch Project"),
+		"",
+		fmt.Sprintf("%s %s", AccentStyle.Render("Current:"), pm.current),
+		fmt.Sprintf("%s %s█", AccentStyle.Render("Path:"), pm.input),
+		"",
+	}
+
+	if len(pm.recent) == 0 {
+		lines = append(lines, CountStyle.Render("No recent projects"))
+	} else {
+		lines = append(lines, AccentStyle.Render("Recent projects:"))
+		for i, dir := range pm.recent {
+			if i == pm.cursor && pm.input == "" {
+				lines = append(lines, SelectedItemStyle.Render("> "+dir))
+			} else {
+				lines = append(lines, "  "+dir)
+			}
+		}
+	}
+
+	if pm.pending {
+		lines = append(lines, "", WarningStyle.Render("Pending changes will be discarded"))
+	}
+	if pm.err != "" {
+		lines = append(lines, "", ErrorStyle.Render(pm.err))
+	}
+
+	instructions := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(contentWidth-4).
+		Padding(1, 0, 0, 0).
+		Render(joinFooterActions([]string{
+			formatFooterAction("↑↓", "Recent"),
+			formatFooterAction("ENTER", "Open"),
+			formatFooterAction("ESC", "Cancel"),
+		}))
+	lines = append(lines, instructions)
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// HandleInput processes keyboard input for the project switcher
+func (pm *ProjectModal) HandleInput(key string) (handled bool, result interface{}) {
+	switch key {
+	case keyUp:
+		if pm.cursor > 0 {
+			pm.cursor--
+		}
+	case keyDown:
+		if pm.cursor < len(pm.recent)-1 {
+			pm.cursor++
+		}
+	case keyEnter:
+		if pm.Selected() == "" {
+			return false, nil
+		}
+		return true, "switch_project"
+	case keyEscapeLong, keyEscape:
+		return true, "cancel"
+	case "backspace":
+		if runes := []rune(pm.input); len(runes) > 0 {
+			pm.input = string(runes[:len(runes)-1])
+		}
+	case "space":
+		pm.input += " "
+	default:
+		if len([]rune(key)) == 1 {
+			pm.input += key
+		}
+	}
+	pm.err = ""
+	return false, nil
+}
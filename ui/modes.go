@@ -0,0 +1,265 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/settingsfile"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// keyModes opens the permission modes of every level
+const keyModes = "m"
+
+// Columns of the modes modal
+const (
+	modeColumnDefault = iota
+	modeColumnDisableBypass
+)
+
+// modeUnset is how an unset mode is shown
+const modeUnset = "(unset)"
+
+// modesChanged reports whether a level's permission modes differ from those loaded
+func modesChanged(m *types.Model, level string) bool {
+	return getLevelByName(m, level).CurrentModes() != m.OriginalModes[level]
+}
+
+// hasModeChanges reports whether any level's permission modes were changed
+func hasModeChanges(m *types.Model) bool {
+	return slices.ContainsFunc(editableLevels, func(level string) bool {
+		return modesChanged(m, level)
+	})
+}
+
+// resetModes restores every level's permission modes as loaded
+func resetModes(m *types.Model) {
+	for _, name := range editableLevels {
+		if level := getLevelByName(m, name); level.Modes != nil {
+			*level.Modes = m.OriginalModes[name]
+		}
+	}
+}
+
+// openModes shows the permission modes of the editable levels for editing
+func openModes(m *types.Model) *types.Model {
+	modes := make([]types.PermissionModes, len(editableLevels))
+	originals := make([]types.PermissionModes, len(editableLevels))
+	for i, name := range editableLevels {
+		modes[i] = getLevelByName(m, name).CurrentModes()
+		originals[i] = m.OriginalModes[name]
+	}
+	m.ActiveModal = &ModesModal{modes: modes, originals: originals}
+	return m
+}
+
+// applyModes stores the modes edited in the modal on their levels
+func applyModes(m *types.Model, mm *ModesModal) {
+	for i, name := range editableLevels {
+		level := getLevelByName(m, name)
+		if level.CurrentModes() == mm.modes[i] {
+			continue
+		}
+		modes := mm.modes[i]
+		level.Modes = &modes
+	}
+}
+
+// modeText shows a mode value, marking values Claude Code does not accept
+func modeText(value string, column int) string {
+	if value == "" {
+		return modeUnset
+	}
+	modes := types.PermissionModes{DefaultMode: value}
+	if column == modeColumnDisableBypass {
+		modes = types.PermissionModes{DisableBypass: value}
+	}
+	if settingsfile.ValidateModes(modes) != nil {
+		return value + " (invalid)"
+	}
+	return value
+}
+
+// modeAuditEntries returns an audit entry for each permission mode changed; Rule is the
+// setting and its new value
+func modeAuditEntries(m *types.Model, now time.Time) []audit.Entry {
+	var entries []audit.Entry
+	for _, name := range editableLevels {
+		current, original := getLevelByName(m, name).CurrentModes(), m.OriginalModes[name]
+		for _, change := range []struct{ key, from, to string }{
+			{"defaultMode", original.DefaultMode, current.DefaultMode},
+			{"disableBypassPermissionsMode", original.DisableBypass, current.DisableBypass},
+		} {
+			if change.from == change.to {
+				continue
+			}
+			to := change.to
+			if to == "" {
+				to = modeUnset
+			}
+			entries = append(entries, audit.Entry{
+				Timestamp: now,
+				Rule:      change.key + "=" + to,
+				From:      name,
+				To:        name,
+				Action:    audit.ActionSetMode,
+			})
+		}
+	}
+	return entries
+}
+
+// modeChanges lists the permission modes changed
+func modeChanges(m *types.Model) []types.PendingChange {
+	var changes []types.PendingChange
+	add := func(level, setting string, column int, before, after string) {
+		if before == after {
+			return
+		}
+		changes = append(changes, types.PendingChange{
+			Kind:   types.ChangeMode,
+			Rule:   setting,
+			Level:  level,
+			Before: before,
+			After:  after,
+			Line: fmt.Sprintf("• %s %s: %s → %s", getLevelStyledText(level), setting,
+				modeText(before, column), modeText(after, column)),
+		})
+	}
+	for _, name := range editableLevels {
+		current, original := getLevelByName(m, name).CurrentModes(), m.OriginalModes[name]
+		add(name, "defaultMode", modeColumnDefault, original.DefaultMode, current.DefaultMode)
+		add(name, "disableBypassPermissionsMode", modeColumnDisableBypass,
+			original.DisableBypass, current.DisableBypass)
+	}
+	return changes
+}
+
+// ModesModal implements types.Modal for editing defaultMode and disableBypassPermissionsMode
+// of each editable level; values cycle through those Claude Code accepts
+type ModesModal struct {
+	modes     []types.PermissionModes // Edited modes, in editableLevels order
+	originals []types.PermissionModes
+	row       int
+	column    int
+}
+
+// RenderModal renders a table of levels and their modes with the selected cell highlighted
+func (mm *ModesModal) RenderModal(width, height int) string {
+	contentWidth := min(80, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	cell := lipgloss.NewStyle().Width(28)
+	lines := []string{
+		titleStyle.Render("Permission Modes"),
+		"",
+		lipgloss.NewStyle().Width(8).Render("") +
+			cell.Render("  "+AccentStyle.Render("defaultMode")) +
+			"  " + AccentStyle.Render("disableBypassPermissionsMode"),
+	}
+	for i, name := range editableLevels {
+		row := lipgloss.NewStyle().Width(8).Render(getLevelStyledText(name))
+		values := []string{mm.modes[i].DefaultMode, mm.modes[i].DisableBypass}
+		originals := []string{mm.originals[i].DefaultMode, mm.originals[i].DisableBypass}
+		for column, value := range values {
+			text := modeText(value, column)
+			if value != originals[column] {
+				text += " *"
+			}
+			if i == mm.row && column == mm.column {
+				text = SelectedItemStyle.Render("> " + text)
+			} else {
+				text = "  " + text
+			}
+			if column == modeColumnDefault {
+				text = cell.Render(text)
+			}
+			row += text
+		}
+		lines = append(lines, row)
+	}
+	lines = append(lines, "", CountStyle.Render("SPACE cycles the accepted values; * marks "+
+		"values changed since loading"))
+
+	instructions := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(contentWidth-4).
+		Padding(1, 0, 0, 0).
+		Render(joinFooterActions([]string{
+			formatFooterAction("SPACE", "Change"),
+			formatFooterAction("DELETE", "Unset"),
+			formatFooterAction("ENTER", "Apply"),
+			formatFooterAction("ESC", "Cancel"),
+		}))
+	lines = append(lines, instructions)
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// HandleInput moves between cells and cycles or clears the selected mode
+func (mm *ModesModal) HandleInput(key string) (handled bool, result interface{}) {
+	switch key {
+	case keyUp, "k":
+		mm.row = max(mm.row-1, 0)
+	case keyDown, "j":
+		mm.row = min(mm.row+1, len(mm.modes)-1)
+	case "left", "h":
+		mm.column = modeColumnDefault
+	case "right", "l":
+		mm.column = modeColumnDisableBypass
+	case "space":
+		mm.cycle()
+	case "delete", "backspace":
+		mm.set("")
+	case keyEnter:
+		return true, "apply_modes"
+	case keyEscapeLong, keyEscape:
+		return true, "cancel"
+	}
+	return false, nil
+}
+
+// cycle sets the selected mode to the next accepted value after its current one, then unset
+func (mm *ModesModal) cycle() {
+	values := []string{settingsfile.DisableBypass}
+	current := mm.modes[mm.row].DisableBypass
+	if mm.column == modeColumnDefault {
+		values = settingsfile.DefaultModes
+		current = mm.modes[mm.row].DefaultMode
+	}
+
+	// An unset or invalid value moves to the first accepted one
+	next := values[0]
+	if i := slices.Index(values, current); i == len(values)-1 {
+		next = ""
+	} else if i >= 0 {
+		next = values[i+1]
+	}
+	mm.set(next)
+}
+
+// set stores value in the selected cell
+func (mm *ModesModal) set(value string) {
+	if mm.column == modeColumnDefault {
+		mm.modes[mm.row].DefaultMode = value
+	} else {
+		mm.modes[mm.row].DisableBypass = value
+	}
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"claude-permissions/types"
+)
+
+// Vim-style motions within the focused column of the Organization screen
+const (
+	keyTop          = "g"
+	keyBottom       = "G"
+	keyHalfPageDown = "ctrl+d"
+	keyHalfPageUp   = "ctrl+u"
+	keyJump         = "f"
+)
+
+// isMotionKey reports whether key is one of the column motions
+func isMotionKey(key string) bool {
+	switch key {
+	case keyTop, keyBottom, keyHalfPageDown, keyHalfPageUp, keyJump:
+		return true
+	}
+	return false
+}
+
+// isJumpLetter reports whether a key pressed on its own jumps to a rule, which is any
+// letter not bound to an action
+func isJumpLetter(m *types.Model, key string) bool {
+	r, size := utf8.DecodeRuneInString(key)
+	return m.CurrentScreen == types.ScreenOrganization && size == len(key) &&
+		unicode.IsLetter(r) && m.Keys.Action(key) == ""
+}
+
+// canMoveInColumn reports whether the focused column accepts motions, hinting why not when
+// unresolved duplicates block the Organization screen
+func canMoveInColumn(m *types.Model) bool {
+	if m.CurrentScreen != types.ScreenOrganization {
+		return false
+	}
+	if hasUnresolvedDuplicates(m) {
+		showHint(m, hintOrganizationBlocked)
+		return false
+	}
+	return len(getColumnPermissions(m)) > 0
+}
+
+// handleColumnMotion moves the selection to the top or bottom of the focused column or by
+// half a page, or waits for the letter to jump to
+func handleColumnMotion(m *types.Model, key string) *types.Model {
+	if !canMoveInColumn(m) {
+		return rejectInput(m)
+	}
+
+	last := len(getColumnPermissions(m)) - 1
+	selection := &m.ColumnSelections[m.FocusedColumn]
+	halfPage := max(visibleColumnRows(m)/2, 1)
+	switch key {
+	case keyTop:
+		*selection = 0
+	case keyBottom:
+		*selection = last
+	case keyHalfPageDown:
+		*selection = min(*selection+halfPage, last)
+	case keyHalfPageUp:
+		*selection = max(*selection-halfPage, 0)
+	case keyJump:
+		m.JumpPending = true
+	}
+	return m
+}
+
+// handleJumpToLetter selects the next rule in the focused column starting with the letter
+// of key, wrapping around to the top. Any key that is not a letter cancels a pending jump.
+func handleJumpToLetter(m *types.Model, key string) *types.Model {
+	m.JumpPending = false
+	r, size := utf8.DecodeRuneInString(key)
+	if size != len(key) || !unicode.IsLetter(r) || !canMoveInColumn(m) {
+		return rejectInput(m)
+	}
+
+	perms := getColumnPermissions(m)
+	selection := m.ColumnSelections[m.FocusedColumn]
+	for step := 1; step <= len(perms); step++ {
+		i := (selection + step) % len(perms)
+		first, _ := utf8.DecodeRuneInString(perms[i].Name)
+		if strings.EqualFold(string(first), string(r)) {
+			m.ColumnSelections[m.FocusedColumn] = i
+			return m
+		}
+	}
+	return rejectInput(m)
+}
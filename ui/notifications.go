@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"claude-permissions/notify"
+	"claude-permissions/types"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// NotificationsChangedMsg tells the editor that a notification was pushed from outside the
+// update loop, e.g. by the debug server
+type NotificationsChangedMsg struct{}
+
+// notificationTickMsg fires when the notification being shown expires
+type notificationTickMsg struct{}
+
+// notificationIcons prefix each notification by severity
+var notificationIcons = map[notify.Severity]string{
+	notify.SeverityInfo:  "ℹ",
+	notify.SeverityWarn:  "⚠",
+	notify.SeverityError: "✗",
+}
+
+// notificationStyles color each notification by severity
+var notificationStyles = map[notify.Severity]lipgloss.Style{
+	notify.SeverityInfo:  InfoStyle,
+	notify.SeverityWarn:  WarningStyle,
+	notify.SeverityError: ErrorStyle,
+}
+
+// scheduleNotificationTick returns a command that redraws once the current notification
+// expires, or nil when nothing is queued
+func scheduleNotificationTick(m *types.Model) tea.Cmd {
+	now := time.Now()
+	if _, _, ok := m.Notifications.Current(now); !ok {
+		return nil
+	}
+	return tea.Tick(m.Notifications.Remaining(now), func(time.Time) tea.Msg {
+		return notificationTickMsg{}
+	})
+}
+
+// renderNotification renders the current notification for the status bar, or "" when the
+// queue is empty
+func renderNotification(m *types.Model) string {
+	current, waiting, ok := m.Notifications.Current(time.Now())
+	if !ok {
+		return ""
+	}
+
+	style := notificationStyles[current.Severity].Background(
+		lipgloss.Color(ColorBackgroundSecondary))
+	text := style.Render(notificationIcons[current.Severity] + " " + current.Message)
+	if waiting > 0 {
+		text += fmt.Sprintf(" (+%d more)", waiting)
+	}
+	return text
+}
@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"claude-permissions/audit"
+	"claude-permissions/permset"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// Keys exporting and importing permission sets
+const (
+	keyExport = "e"
+	keyImport = "i"
+)
+
+// defaultPermsetFile is the file offered for export and import, relative to the project root
+const defaultPermsetFile = "claude-permissions.json"
+
+// PathModal implements types.Modal for entering the file to export to or import from
+type PathModal struct {
+	title  string
+	result string // Returned from HandleInput on ENTER
+	input  string
+	err    string
+}
+
+// NewPathModal creates a file path prompt prefilled with path
+func NewPathModal(title, result, path string) *PathModal {
+	return &PathModal{title: title, result: result, input: path}
+}
+
+// Path returns the entered path
+func (pm *PathModal) Path() string {
+	return pm.input
+}
+
+// SetError shows err below the input, keeping the prompt open
+func (pm *PathModal) SetError(err error) {
+	pm.err = err.Error()
+}
+
+// CapturesText reports that printable keys are typed into the path input
+func (pm *PathModal) CapturesText() bool {
+	return true
+}
+
+// RenderModal renders the path prompt
+func (pm *PathModal) RenderModal(width, height int) string {
+	contentWidth := min(80, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	lines := []string{
+		titleStyle.Render(pm.title),
+		"",
+		fmt.Sprintf("%s %s█", AccentStyle.Render("File:"), pm.input),
+		CountStyle.Render("JSON, or YAML for .yaml/.yml files; relative to the project root"),
+	}
+	if pm.err != "" {
+		lines = append(lines, "", ErrorStyle.Render(pm.err))
+	}
+
+	instructions := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(contentWidth-4).
+		Padding(1, 0, 0, 0).
+		Render(joinFooterActions([]string{
+			formatFooterAction("ENTER", "Confirm"),
+			formatFooterAction("ESC", "Cancel"),
+		}))
+	lines = append(lines, instructions)
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// HandleInput processes keyboard input for the path prompt
+func (pm *PathModal) HandleInput(key string) (handled bool, result interface{}) {
+	switch key {
+	case keyEnter:
+		if pm.input == "" {
+			return false, nil
+		}
+		return true, pm.result
+	case keyEscapeLong, keyEscape:
+		return true, "cancel"
+	case "backspace":
+		if runes := []rune(pm.input); len(runes) > 0 {
+			pm.input = string(runes[:len(runes)-1])
+		}
+	case "space":
+		pm.input += " "
+	default:
+		if len([]rune(key)) == 1 {
+			pm.input += key
+		}
+	}
+	pm.err = ""
+	return false, nil
+}
+
+// openPermsetPrompt asks for the file to export to or import from
+func openPermsetPrompt(m *types.Model, key string) *types.Model {
+	if key == keyImport {
+		m.ActiveModal = NewPathModal("Import Permission Set", "import", defaultPermsetFile)
+		return m
+	}
+	m.ActiveModal = NewPathModal("Export Permission Set", "export", defaultPermsetFile)
+	return m
+}
+
+// permsetPath resolves a path entered in the prompt against the project root
+func permsetPath(m *types.Model, path string) string {
+	if filepath.IsAbs(path) || m.ProjectRoot == "" {
+		return path
+	}
+	return filepath.Join(m.ProjectRoot, path)
+}
+
+// exportPermset writes the rules of all editable levels, as currently shown, to the entered file
+func exportPermset(m *types.Model, pm *PathModal) *types.Model {
+	path := permsetPath(m, pm.Path())
+	set := permset.FromLevels(m.UserLevel, m.RepoLevel, m.LocalLevel)
+	if err := permset.Write(path, set); err != nil {
+		pm.SetError(err)
+		return m
+	}
+	m.ActiveModal = nil
+	m.Notifications.Info("Exported %d rules to %s", len(set.Rules), path)
+	return m
+}
+
+// importPermset stages the rules of the entered permission set as pending additions to
+// their levels
+func importPermset(m *types.Model, pm *PathModal) *types.Model {
+	path := permsetPath(m, pm.Path())
+	set, err := permset.Read(path)
+	if err != nil {
+		pm.SetError(err)
+		return m
+	}
+
+	additions, err := planRuleSet(m, set, permset.Source(path), audit.ActionImport)
+	if err != nil {
+		pm.SetError(err)
+		return m
+	}
+	m.ActiveModal = nil
+	if len(additions) == 0 {
+		m.Notifications.Info("%s adds no new rules", filepath.Base(path))
+		return m
+	}
+
+	stageRuleAdditions(m, additions)
+	m.Notifications.Info("Import of %s: %d addition(s) pending; press ENTER to review",
+		filepath.Base(path), len(additions))
+	return m
+}
+
+// planRuleSet merges the set into copies of the editable levels and returns the rules it
+// would add. Adding rules to a level that failed to load is refused, since writing it would
+// replace the parts that could not be read.
+func planRuleSet(
+	m *types.Model,
+	set permset.Set,
+	source, action string,
+) ([]types.RuleAddition, error) {
+	var additions []types.RuleAddition
+	for _, level := range []types.SettingsLevel{m.UserLevel, m.RepoLevel, m.LocalLevel} {
+		updated := level
+		updated.Permissions = slices.Clone(level.Permissions)
+		updated.Deny = slices.Clone(level.Deny)
+		updated.Provenance = maps.Clone(level.Provenance)
+		added := set.Merge(&updated, source)
+		if len(added) == 0 {
+			continue
+		}
+		if level.Path == "" {
+			return nil, fmt.Errorf(
+				"cannot add rules to the %s level: project is not a git repository", level.Name)
+		}
+		if level.LoadError != "" {
+			return nil, fmt.Errorf(
+				"%s settings could not be loaded and are read-only", level.Name)
+		}
+		for _, rule := range added {
+			additions = append(additions, types.RuleAddition{
+				Level:  level.Name,
+				Rule:   rule.Rule,
+				Deny:   rule.List == permset.ListDeny,
+				Source: source,
+				Action: action,
+			})
+		}
+	}
+	return additions, nil
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// RepoPicker is a standalone tea.Model for choosing one repository from a scan
+type RepoPicker struct {
+	roots     []string
+	summaries []string
+	cursor    int
+	height    int
+
+	// Selected is the chosen repository root, or "" when the picker was cancelled
+	Selected string
+}
+
+// NewRepoPicker creates a picker over repository roots with one summary line each
+func NewRepoPicker(roots, summaries []string) *RepoPicker {
+	return &RepoPicker{roots: roots, summaries: summaries}
+}
+
+// Init implements tea.Model
+func (p *RepoPicker) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (p *RepoPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.height = msg.Height
+	case tea.KeyMsg:
+		switch msg.String() {
+		case keyUp, "k":
+			if p.cursor > 0 {
+				p.cursor--
+			}
+		case keyDown, "j":
+			if p.cursor < len(p.roots)-1 {
+				p.cursor++
+			}
+		case keyEnter:
+			p.Selected = p.roots[p.cursor]
+			return p, tea.Quit
+		case keyEscape, keyEscapeLong, "q", "ctrl+c":
+			return p, tea.Quit
+		}
+	}
+	return p, nil
+}
+
+// View implements tea.Model, keeping the cursor visible on short terminals
+func (p *RepoPicker) View() string {
+	title := TitleStyle.Render("Open a repository in the editor")
+
+	// Title, blank line, and footer take three lines; each entry takes two
+	visible := max((p.height-3)/2, 1)
+	start := max(0, min(p.cursor-visible+1, len(p.roots)-visible))
+	end := min(len(p.roots), start+visible)
+
+	var lines []string
+	for i := start; i < end; i++ {
+		root := "  " + p.roots[i]
+		if i == p.cursor {
+			root = SelectedItemStyle.Render("> " + p.roots[i])
+		}
+		lines = append(lines, root, "    "+CountStyle.Render(p.summaries[i]))
+	}
+
+	footer := joinFooterActions([]string{
+		formatFooterAction("↑↓", "Navigate"),
+		formatFooterAction("ENTER", "Open"),
+		formatFooterAction("ESC", "Cancel"),
+	})
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, "", strings.Join(lines, "\n"), footer)
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"claude-permissions/keymap"
+	"claude-permissions/policy"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// policyViolations checks the levels as they stand, pending changes included, against the
+// project's policy file
+func policyViolations(m *types.Model) []types.PolicyViolation {
+	if m.Policy == nil {
+		return nil
+	}
+	return policy.Check(m.Policy, m.LocalLevel, m.RepoLevel, m.UserLevel)
+}
+
+// policyScreen lists the allow rules that break the project's policy file; it is read-only
+type policyScreen struct{}
+
+// Available hides Policy when the project has no policy file
+func (policyScreen) Available(m *types.Model) bool {
+	return m.Policy != nil
+}
+
+func (policyScreen) Init(m *types.Model) {
+	m.PolicyOffset = 0
+}
+
+func (policyScreen) Update(m *types.Model, key string) *types.Model {
+	switch key {
+	case keyUp, "k":
+		m.PolicyOffset--
+	case keyDown, "j":
+		m.PolicyOffset++
+	default:
+		return rejectInput(m)
+	}
+	scrollPolicy(m)
+	return m
+}
+
+func (policyScreen) View(c *ContentComponent) string {
+	return c.renderPolicyContent()
+}
+
+func (policyScreen) Keymap(m *types.Model) []string {
+	return []string{
+		formatFooterAction(keyLabel(m, keymap.SwitchScreen), "Switch panel"),
+		formatFooterAction(keyLabel(m, keymap.Up, keymap.Down), "Scroll"),
+		formatFooterAction(keyLabel(m, keymap.Help), "All keys"),
+	}
+}
+
+func (policyScreen) StatusText(m *types.Model) string {
+	if m.Policy == nil {
+		return "No policy file loaded"
+	}
+	errors, warnings := 0, 0
+	for _, v := range policyViolations(m) {
+		if v.Severity == policy.SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	return fmt.Sprintf("%d error(s), %d warning(s) from %s", errors, warnings, m.Policy.Path)
+}
+
+// visiblePolicyRows returns how many violations fit below the Policy header
+func (c *ContentComponent) visiblePolicyRows() int {
+	vertical, _ := c.panelPadding()
+	rows := c.height - NormalBorderStyle.GetVerticalBorderSize() - 2*vertical - 1
+	return max(rows, 1)
+}
+
+// renderPolicyContent lists each violation with its severity, level, and the policy rule
+// it breaks
+func (c *ContentComponent) renderPolicyContent() string {
+	if c.width <= 0 || c.height <= 0 {
+		return ""
+	}
+
+	contentWidth := max(c.getConsistentContentWidth(), 20)
+
+	violations := policyViolations(c.model)
+	if len(violations) == 0 {
+		return BlockingMessageStyle.
+			Width(contentWidth).
+			Height(c.height).
+			Render("No policy violations")
+	}
+
+	_, horizontal := c.panelPadding()
+	rowWidth := contentWidth - NormalBorderStyle.GetHorizontalBorderSize() - 2*horizontal
+	rowStyle := lipgloss.NewStyle().MaxWidth(rowWidth)
+	lines := []string{TitleStyle.Render(
+		fmt.Sprintf("%-8s  %-6s  %-30s  %s", "Severity", "Level", "Permission", "Policy"))}
+
+	rows := c.visiblePolicyRows()
+	start := max(min(c.model.PolicyOffset, len(violations)-rows), 0)
+	end := min(start+rows, len(violations))
+	for _, v := range violations[start:end] {
+		severity := WarningStyle.Render(fmt.Sprintf("%-8s", v.Severity))
+		if v.Severity == policy.SeverityError {
+			severity = ErrorStyle.Render(fmt.Sprintf("%-8s", v.Severity))
+		}
+		rule := v.Rule
+		if v.Description != "" {
+			rule += ": " + v.Description
+		}
+		lines = append(lines, rowStyle.Render(fmt.Sprintf("%s  %-6s  %-30s  %s",
+			severity, v.Level, v.Permission, rule)))
+	}
+
+	return lipgloss.NewStyle().
+		Width(contentWidth).
+		Height(c.height).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(ColorBorderFocused)).
+		Padding(c.panelPadding()).
+		Render(strings.Join(lines, "\n"))
+}
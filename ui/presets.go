@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"claude-permissions/audit"
+	"claude-permissions/presets"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// keyPresets opens the preset library
+const keyPresets = "t"
+
+// presetLevels are the levels a preset can be applied to, in the order of the 1/2/3 keys
+var presetLevels = []string{types.LevelLocal, types.LevelRepo, types.LevelUser}
+
+// PresetModal implements types.Modal for previewing and applying a toolchain preset
+type PresetModal struct {
+	existing map[string][]string // Allow rules already in each level
+	cursor   int
+	level    string
+	err      string
+}
+
+// NewPresetModal creates the preset picker, targeting the Repo level by default
+func NewPresetModal(existing map[string][]string) *PresetModal {
+	return &PresetModal{existing: existing, level: types.LevelRepo}
+}
+
+// Selected returns the highlighted preset
+func (pm *PresetModal) Selected() presets.Preset {
+	return presets.All[pm.cursor]
+}
+
+// Level returns the level the preset is applied to
+func (pm *PresetModal) Level() string {
+	return pm.level
+}
+
+// SetError shows err in the modal, keeping it open
+func (pm *PresetModal) SetError(err error) {
+	pm.err = err.Error()
+}
+
+// RenderModal renders the preset list, target level, and the rules the preset would add
+func (pm *PresetModal) RenderModal(width, height int) string {
+	contentWidth := min(80, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	lines := []string{titleStyle.Render("Apply Preset"), ""}
+	for i, preset := range presets.All {
+		line := fmt.Sprintf("%-8s %s", preset.Title, preset.Description)
+		if i == pm.cursor {
+			lines = append(lines, SelectedItemStyle.Render("> "+line))
+		} else {
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	levels := make([]string, 0, len(presetLevels))
+	for i, level := range presetLevels {
+		label := fmt.Sprintf("%d %s", i+1, level)
+		if level == pm.level {
+			label = fmt.Sprintf("%d [%s]", i+1, getLevelStyledText(level))
+		}
+		levels = append(levels, label)
+	}
+	lines = append(lines, "", AccentStyle.Render("Level:")+" "+strings.Join(levels, "  "), "")
+
+	added := 0
+	for _, rule := range pm.Selected().Rules {
+		if slices.Contains(pm.existing[pm.level], rule) {
+			lines = append(lines, CountStyle.Render("  = "+rule+" (already present)"))
+			continue
+		}
+		lines = append(lines, SuccessStyle.Render("  + "+rule))
+		added++
+	}
+	lines = append(lines, "", fmt.Sprintf("%d new rule(s) for %s", added, pm.level))
+
+	if pm.err != "" {
+		lines = append(lines, "", ErrorStyle.Render(pm.err))
+	}
+
+	instructions := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(contentWidth-4).
+		Padding(1, 0, 0, 0).
+		Render(joinFooterActions([]string{
+			formatFooterAction("↑↓", "Preset"),
+			formatFooterAction("1/2/3", "Level"),
+			formatFooterAction("ENTER", "Apply"),
+			formatFooterAction("ESC", "Cancel"),
+		}))
+	lines = append(lines, instructions)
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// HandleInput processes keyboard input for the preset picker
+func (pm *PresetModal) HandleInput(key string) (handled bool, result interface{}) {
+	switch key {
+	case keyUp, "k":
+		if pm.cursor > 0 {
+			pm.cursor--
+		}
+	case keyDown, "j":
+		if pm.cursor < len(presets.All)-1 {
+			pm.cursor++
+		}
+	case "1", "2", "3":
+		pm.level = presetLevels[key[0]-'1']
+	case keyEnter:
+		return true, "apply_preset"
+	case keyEscapeLong, keyEscape:
+		return true, "cancel"
+	}
+	pm.err = ""
+	return false, nil
+}
+
+// openPresets shows the preset library
+func openPresets(m *types.Model) *types.Model {
+	m.ActiveModal = NewPresetModal(map[string][]string{
+		types.LevelLocal: m.LocalLevel.Permissions,
+		types.LevelRepo:  m.RepoLevel.Permissions,
+		types.LevelUser:  m.UserLevel.Permissions,
+	})
+	return m
+}
+
+// applyPreset stages the selected preset's missing rules as pending additions to the chosen
+// level
+func applyPreset(m *types.Model, pm *PresetModal) *types.Model {
+	preset := pm.Selected()
+	additions, err := planRuleSet(m, preset.Set(pm.Level()), preset.Source(),
+		audit.ActionPreset)
+	if err != nil {
+		pm.SetError(err)
+		return m
+	}
+	m.ActiveModal = nil
+	if len(additions) == 0 {
+		m.Notifications.Info("%s already has every %s preset rule", pm.Level(), preset.Title)
+		return m
+	}
+
+	stageRuleAdditions(m, additions)
+	m.Notifications.Info("%s preset: %d addition(s) to %s pending; press ENTER to review",
+		preset.Title, len(additions), pm.Level())
+	return m
+}
@@ -0,0 +1,346 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"claude-permissions/permset"
+	"claude-permissions/profiles"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// keyProfiles opens the saved permission profiles
+const keyProfiles = "w"
+
+// ProfileModal implements types.Modal for saving the current rules as a named profile and
+// restoring a saved one. The first row takes the name of a new profile; the rows below list
+// the saved profiles.
+type ProfileModal struct {
+	profiles []profiles.Profile
+	cursor   int // 0 is the new profile row, i+1 the saved profile i
+	name     string
+	err      string
+}
+
+// NewProfileModal creates the profile picker over the saved profiles
+func NewProfileModal(saved []profiles.Profile) *ProfileModal {
+	pm := &ProfileModal{profiles: saved}
+	if len(saved) > 0 {
+		pm.cursor = 1
+	}
+	return pm
+}
+
+// Name returns the name entered for a new profile
+func (pm *ProfileModal) Name() string {
+	return pm.name
+}
+
+// Selected returns the highlighted saved profile, if any
+func (pm *ProfileModal) Selected() (profiles.Profile, bool) {
+	if pm.cursor == 0 {
+		return profiles.Profile{}, false
+	}
+	return pm.profiles[pm.cursor-1], true
+}
+
+// SetError shows err in the modal, keeping it open
+func (pm *ProfileModal) SetError(err error) {
+	pm.err = err.Error()
+}
+
+// CapturesText reports that printable keys are typed into the name of a new profile
+func (pm *ProfileModal) CapturesText() bool {
+	return true
+}
+
+// RenderModal renders the new profile row and the saved profiles
+func (pm *ProfileModal) RenderModal(width, height int) string {
+	contentWidth := min(80, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	row := func(i int, line string) string {
+		if i == pm.cursor {
+			return SelectedItemStyle.Render("> " + line)
+		}
+		return "  " + line
+	}
+
+	lines := []string{
+		titleStyle.Render("Permission Profiles"),
+		"",
+		row(0, fmt.Sprintf("Save current rules as: %s█", pm.name)),
+		"",
+	}
+	if len(pm.profiles) == 0 {
+		lines = append(lines, CountStyle.Render("  No saved profiles"))
+	}
+	for i, profile := range pm.profiles {
+		lines = append(lines, row(i+1, fmt.Sprintf("%-20s %4d rules  saved %s", profile.Name,
+			profile.Rules, profile.SavedAt.Format("2006-01-02 15:04"))))
+	}
+	lines = append(lines, "",
+		CountStyle.Render("Restoring stages moves, additions, and deletions for review"))
+	if pm.err != "" {
+		lines = append(lines, "", ErrorStyle.Render(pm.err))
+	}
+
+	action := "Restore"
+	if pm.cursor == 0 {
+		action = "Save"
+	}
+	instructions := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(contentWidth-4).
+		Padding(1, 0, 0, 0).
+		Render(joinFooterActions([]string{
+			formatFooterAction("↑↓", "Select"),
+			formatFooterAction("ENTER", action),
+			formatFooterAction("DEL", "Delete"),
+			formatFooterAction("ESC", "Cancel"),
+		}))
+	lines = append(lines, instructions)
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// HandleInput processes keyboard input for the profile picker; typing selects the new
+// profile row
+func (pm *ProfileModal) HandleInput(key string) (handled bool, result interface{}) {
+	switch key {
+	case keyUp:
+		if pm.cursor > 0 {
+			pm.cursor--
+		}
+	case keyDown:
+		if pm.cursor < len(pm.profiles) {
+			pm.cursor++
+		}
+	case keyEnter:
+		if pm.cursor > 0 {
+			return true, "profile_restore"
+		}
+		if pm.name == "" {
+			return false, nil
+		}
+		return true, "profile_save"
+	case keyDelete:
+		if pm.cursor > 0 {
+			return true, "profile_delete"
+		}
+	case keyEscapeLong, keyEscape:
+		return true, "cancel"
+	case "backspace":
+		pm.cursor = 0
+		if runes := []rune(pm.name); len(runes) > 0 {
+			pm.name = string(runes[:len(runes)-1])
+		}
+	default:
+		if len([]rune(key)) == 1 {
+			pm.cursor = 0
+			pm.name += key
+		}
+	}
+	pm.err = ""
+	return false, nil
+}
+
+// remove drops the saved profile at the cursor from the list
+func (pm *ProfileModal) remove() {
+	pm.profiles = slices.Delete(pm.profiles, pm.cursor-1, pm.cursor)
+	pm.cursor = min(pm.cursor, len(pm.profiles))
+}
+
+// openProfiles shows the saved profiles
+func openProfiles(m *types.Model) *types.Model {
+	saved, err := profiles.List()
+	if err != nil {
+		m.Notifications.Warn("Cannot list profiles: %v", err)
+		return m
+	}
+	m.ActiveModal = NewProfileModal(saved)
+	return m
+}
+
+// saveProfile snapshots the allow rules of each level, pending changes included, under the
+// entered name
+func saveProfile(m *types.Model, pm *ProfileModal) *types.Model {
+	set := profiles.Snapshot(pendingLevel(m, types.LevelUser), pendingLevel(m, types.LevelRepo),
+		pendingLevel(m, types.LevelLocal))
+	if err := profiles.Save(pm.Name(), set); err != nil {
+		pm.SetError(err)
+		return m
+	}
+	m.ActiveModal = nil
+	m.Notifications.Info("Saved profile %s (%d rules)", pm.Name(), len(set.Rules))
+	return m
+}
+
+// deleteProfile removes the selected profile, keeping the picker open
+func deleteProfile(m *types.Model, pm *ProfileModal) *types.Model {
+	profile, ok := pm.Selected()
+	if !ok {
+		return m
+	}
+	if err := profiles.Delete(profile.Name); err != nil {
+		pm.SetError(err)
+		return m
+	}
+	pm.remove()
+	m.Notifications.Info("Deleted profile %s", profile.Name)
+	return m
+}
+
+// profileMove is a rule moved between levels by a profile restore
+type profileMove struct {
+	rule, from, to string
+}
+
+// profileRestore is the set of pending changes that turns the current rules into a profile
+type profileRestore struct {
+	keep    map[int]string // Duplicate index to the level kept
+	moves   []profileMove
+	deletes []types.RuleEdit
+	adds    []permset.Rule
+}
+
+// count returns the number of changes the restore stages
+func (r profileRestore) count() int {
+	return len(r.keep) + len(r.moves) + len(r.deletes) + len(r.adds)
+}
+
+// levels returns every level the restore changes
+func (r profileRestore) levels() []string {
+	var names []string
+	for _, move := range r.moves {
+		names = append(names, move.from, move.to)
+	}
+	for _, edit := range r.deletes {
+		names = append(names, edit.Level)
+	}
+	for _, rule := range r.adds {
+		names = append(names, rule.Level)
+	}
+	return names
+}
+
+// planProfileRestore works out the changes that leave each rule of set at its level and no
+// other allow rule. A rule saved at several levels is restored to the first. Only the kept
+// copy of a duplicate is moved or deleted, as resolving it removes the other copies.
+func planProfileRestore(m *types.Model, set permset.Set) profileRestore {
+	want := make(map[string]string)
+	for _, rule := range set.Rules {
+		if _, ok := want[rule.Rule]; !ok {
+			want[rule.Rule] = rule.Level
+		}
+	}
+
+	restore := profileRestore{keep: make(map[int]string)}
+	duplicated := make(map[string]bool)
+	for i, dup := range m.Duplicates {
+		duplicated[dup.Name] = true
+		keep := dup.KeepLevel
+		if keep == "" {
+			keep = dup.Levels[0]
+		}
+		level, wanted := want[dup.Name]
+		switch {
+		case wanted && slices.Contains(dup.Levels, level):
+			keep = level
+		case wanted:
+			restore.moves = append(restore.moves, profileMove{dup.Name, keep, level})
+		default:
+			restore.deletes = append(restore.deletes, types.RuleEdit{Level: keep, Rule: dup.Name})
+		}
+		if keep != dup.KeepLevel {
+			restore.keep[i] = keep
+		}
+	}
+
+	present := make(map[string]bool)
+	for _, perm := range m.Permissions {
+		present[perm.Name] = true
+		level, wanted := want[perm.Name]
+		switch {
+		case duplicated[perm.Name]:
+		case !wanted:
+			restore.deletes = append(restore.deletes,
+				types.RuleEdit{Level: perm.CurrentLevel, Rule: perm.Name})
+		case level != perm.CurrentLevel:
+			restore.moves = append(restore.moves, profileMove{perm.Name, perm.CurrentLevel, level})
+		}
+	}
+
+	for _, rule := range set.Rules {
+		if !present[rule.Rule] && want[rule.Rule] == rule.Level {
+			restore.adds = append(restore.adds, rule)
+			present[rule.Rule] = true
+		}
+	}
+	return restore
+}
+
+// restoreProfile stages the changes that restore the selected profile as pending changes,
+// to be reviewed and saved like any other; pending changes must be saved or reset first
+func restoreProfile(m *types.Model, pm *ProfileModal) *types.Model {
+	profile, ok := pm.Selected()
+	if !ok {
+		return m
+	}
+	if hasUserChanges(m) {
+		pm.SetError(errors.New("save or reset pending changes before restoring a profile"))
+		return m
+	}
+	set, err := profiles.Load(profile.Name)
+	if err != nil {
+		pm.SetError(err)
+		return m
+	}
+
+	restore := planProfileRestore(m, set)
+	for _, name := range restore.levels() {
+		if level := getLevelByName(m, name); level.LoadError != "" {
+			pm.SetError(fmt.Errorf("%s settings could not be loaded and are read-only", name))
+			return m
+		}
+	}
+	m.ActiveModal = nil
+	if restore.count() == 0 {
+		m.Notifications.Info("The rules already match profile %s", profile.Name)
+		return m
+	}
+
+	for i, level := range restore.keep {
+		m.Duplicates[i].KeepLevel = level
+	}
+	updateDuplicatesTableData(m)
+	for _, move := range restore.moves {
+		movePermissionBetweenLevels(m, move.rule, move.from, move.to)
+	}
+	for _, edit := range restore.deletes {
+		setRuleEdit(m, edit)
+	}
+	for _, rule := range restore.adds {
+		addPastedRule(m, rule.Level, rule.Rule)
+	}
+	m.Notifications.Info("Profile %s: %d move(s), %d deletion(s), %d addition(s) pending; "+
+		"press ENTER to review", profile.Name, len(restore.moves), len(restore.deletes),
+		len(restore.adds))
+	return m
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"encoding/json"
+
+	"claude-permissions/debug"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// Register the editor's timer, clipboard, and release check messages with the session
+// recorder, so a replay delivers them when they arrived rather than when its own timers fire
+func init() {
+	debug.RegisterMsgCodec(debug.SignalMsgCodec[notificationTickMsg]("notification_tick"))
+	debug.RegisterMsgCodec(debug.SignalMsgCodec[NotificationsChangedMsg]("notifications_changed"))
+	debug.RegisterMsgCodec(debug.SignalMsgCodec[flashEndMsg]("flash_end"))
+	debug.RegisterMsgCodec(debug.MsgCodec{
+		Kind: "clipboard_read",
+		Encode: func(msg tea.Msg) (interface{}, bool) {
+			read, ok := msg.(clipboardReadMsg)
+			return read.text, ok
+		},
+		Decode: func(data json.RawMessage) (tea.Msg, error) {
+			var read clipboardReadMsg
+			err := json.Unmarshal(data, &read.text)
+			return read, err
+		},
+	})
+	debug.RegisterMsgCodec(debug.MsgCodec{
+		Kind: "update_available",
+		Encode: func(msg tea.Msg) (interface{}, bool) {
+			update, ok := msg.(updateAvailableMsg)
+			return update.version, ok
+		},
+		Decode: func(data json.RawMessage) (tea.Msg, error) {
+			var update updateAvailableMsg
+			err := json.Unmarshal(data, &update.version)
+			return update, err
+		},
+	})
+}
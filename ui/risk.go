@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/rules"
+	"claude-permissions/types"
+)
+
+// ruleActions are the audit actions whose Rule is a permission rule rather than a hook,
+// env variable, directory, or mode
+var ruleActions = []string{
+	audit.ActionMove,
+	audit.ActionRemoveDuplicate,
+	audit.ActionResolveContradiction,
+	audit.ActionConsolidate,
+	audit.ActionRemoveStale,
+	audit.ActionEditRule,
+	audit.ActionDeleteRule,
+	audit.ActionAddRule,
+}
+
+// riskyChanges describes the changes a save of levels makes that deserve a second look:
+// broad rules moved to the user level, where they apply to every project, deny rules
+// removed, and rules that overlap managed policy
+func riskyChanges(m *types.Model, levels []*types.SettingsLevel) []string {
+	var lines []string
+	add := func(line string) {
+		if !slices.Contains(lines, line) {
+			lines = append(lines, line)
+		}
+	}
+
+	for _, entry := range savedEntries(collectAuditEntries(m, "", time.Time{}), levels) {
+		if !slices.Contains(ruleActions, entry.Action) {
+			continue
+		}
+		if entry.Action == audit.ActionMove && entry.To == types.LevelUser {
+			if risk, ok := rules.AssessRisk(entry.Rule); ok {
+				add(fmt.Sprintf("• %s: Moved to User, where it %s in every project",
+					entry.Rule, risk.Reason))
+			}
+		}
+		if entry.Action == audit.ActionResolveContradiction && allowWins(m, entry.Rule) {
+			add(fmt.Sprintf("• %s: Deny rule removed from %s", entry.Rule, entry.From))
+		}
+		for _, f := range m.ManagedFindings {
+			if f.Name == entry.Rule {
+				add(fmt.Sprintf("• %s: %s by managed policy rule %s",
+					entry.Rule, managedFindingLabel(f), f.ManagedRule))
+			}
+		}
+	}
+	return lines
+}
+
+// allowWins reports whether the contradiction over rule was resolved for the allow side
+func allowWins(m *types.Model, rule string) bool {
+	for _, c := range m.Contradictions {
+		if c.Name == rule {
+			return c.Winner == types.WinnerAllow
+		}
+	}
+	return false
+}
+
+// managedFindingLabel describes how managed policy overlaps a rule
+func managedFindingLabel(f types.ManagedFinding) string {
+	if f.Kind == types.ManagedContradicted {
+		return "Contradicted"
+	}
+	return "Already covered"
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/types"
+)
+
+// sameLevelCount returns how many extra occurrences of rules the next save removes
+func sameLevelCount(m *types.Model) int {
+	count := 0
+	for _, removed := range m.SameLevelDuplicates {
+		count += len(removed)
+	}
+	return count
+}
+
+// sameLevelOccurrences returns each rule listed more than once in the level with how many
+// times the file lists it, in the order the rules first repeat
+func sameLevelOccurrences(m *types.Model, level string) ([]string, map[string]int) {
+	var rules []string
+	occurrences := make(map[string]int)
+	for _, rule := range m.SameLevelDuplicates[level] {
+		if occurrences[rule] == 0 {
+			rules = append(rules, rule)
+			occurrences[rule] = 1
+		}
+		occurrences[rule]++
+	}
+	return rules, occurrences
+}
+
+// renderSameLevelDuplicates lists the rules listed more than once within a level, which
+// the next save collapses to one entry
+func (c *ContentComponent) renderSameLevelDuplicates() string {
+	m := c.model
+	count := sameLevelCount(m)
+	if count == 0 {
+		return ""
+	}
+
+	lines := []string{
+		TitleStyle.Render(fmt.Sprintf("Same-Level Duplicates (%d to remove on save)", count)),
+	}
+	for _, name := range editableLevels {
+		rules, occurrences := sameLevelOccurrences(m, name)
+		if len(rules) == 0 {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s (%d):", getLevelStyledText(name),
+			len(m.SameLevelDuplicates[name])))
+		for _, rule := range rules {
+			lines = append(lines, fmt.Sprintf("  • %s listed %d times", rule, occurrences[rule]))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sameLevelAuditEntries returns an audit entry for each extra occurrence the save removes
+func sameLevelAuditEntries(m *types.Model, now time.Time) []audit.Entry {
+	var entries []audit.Entry
+	for _, name := range editableLevels {
+		for _, rule := range m.SameLevelDuplicates[name] {
+			entries = append(entries, audit.Entry{
+				Timestamp: now,
+				Rule:      rule,
+				From:      name,
+				To:        name,
+				Action:    audit.ActionRemoveDuplicate,
+			})
+		}
+	}
+	return entries
+}
+
+// sameLevelChanges lists the rules listed more than once in one file
+func sameLevelChanges(m *types.Model) []types.PendingChange {
+	var changes []types.PendingChange
+	for _, name := range editableLevels {
+		rules, occurrences := sameLevelOccurrences(m, name)
+		for _, rule := range rules {
+			changes = append(changes, types.PendingChange{
+				Kind:  types.ChangeSameLevel,
+				Rule:  rule,
+				Level: name,
+				Count: occurrences[rule],
+				Line: fmt.Sprintf("• %s: Keep 1 of %d in %s",
+					rule, occurrences[rule], getLevelStyledText(name)),
+			})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,278 @@
+package ui
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/logging"
+	"claude-permissions/metadata"
+	"claude-permissions/settingsfile"
+	"claude-permissions/types"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// Small modal actions used by the save flow
+const (
+	actionCreateFiles = "create_files"
+	actionSaveFailed  = "save_failed"
+)
+
+// startSave writes pending changes, first asking before creating settings files that
+// do not exist yet
+func startSave(m *types.Model) (*types.Model, tea.Cmd) {
+	var missing []string
+	for _, level := range savingLevels(m) {
+		if level.LoadError != "" {
+			return showSaveError(m, logFor(m, logging.ComponentSave), fmt.Errorf(
+				"the %s level is read-only because %s could not be loaded; "+
+					"reset the changes to it or fix the file and restart",
+				level.Name, level.Path)), nil
+		}
+		if level.Path == "" {
+			return showSaveError(m, logFor(m, logging.ComponentSave), fmt.Errorf(
+				"the %s level has no settings file because the project is not a git repository",
+				level.Name)), nil
+		}
+		if !level.Exists {
+			missing = append(missing, level.Path)
+		}
+	}
+
+	if len(missing) > 0 {
+		m.ActiveModal = NewSmallModal(
+			"Create Missing Files",
+			"These settings files do not exist yet and will be created:\n\n"+
+				strings.Join(missing, "\n"),
+			actionCreateFiles,
+		)
+		return m, nil
+	}
+
+	return saveChanges(m)
+}
+
+// pendingSave is what finishSave needs once the files of a save are written
+type pendingSave struct {
+	log          *slog.Logger
+	levels       []types.SettingsLevel // As written, pending changes applied
+	staged       stagedWork
+	partial      bool
+	auditEntries []audit.Entry
+	moved        int
+	resolved     int
+}
+
+// saveChanges backs up the changed levels selected for saving, applies the pending changes
+// to copies of them, and starts writing the copies in one transaction behind a progress
+// modal. finishSave completes the save once the writes are done; until then, and for good if
+// they fail, the model keeps the levels as loaded.
+func saveChanges(m *types.Model) (*types.Model, tea.Cmd) {
+	changeID := logging.NewChangeID()
+	log := logFor(m, logging.ComponentSave).With(logging.ChangeID(changeID))
+	levels := savingLevels(m)
+	staged, partial := stageUnsaved(m, levels)
+
+	originals := make([]types.SettingsLevel, 0, len(levels))
+	for _, level := range levels {
+		originals = append(originals, *level)
+	}
+	backupDir, err := settingsfile.Backup(originals, time.Now())
+	if err != nil {
+		return showSaveError(m, log, err), nil
+	}
+	if backupDir != "" {
+		m.Session.BackupDirs = append(m.Session.BackupDirs, backupDir)
+	}
+
+	auditEntries := savedEntries(collectAuditEntries(m, changeID, time.Now().UTC()), levels)
+	moved, resolved := countPendingChanges(auditEntries)
+
+	applied := pendingLevels(m)
+	updated := make([]types.SettingsLevel, 0, len(levels))
+	for _, level := range levels {
+		updated = append(updated, applied[level.Name])
+	}
+	m.ActiveModal = NewSaveProgressModal(updated)
+	return m, writeLevels(updated, backupDir, pendingSave{
+		log:          log,
+		levels:       updated,
+		staged:       staged,
+		partial:      partial,
+		auditEntries: auditEntries,
+		moved:        moved,
+		resolved:     resolved,
+	})
+}
+
+// finishSave takes the levels a save wrote into the model, writes their sidecars, reloads
+// the project, and offers to commit the repo settings file. The pending
+// changes of the levels left out stay pending.
+func finishSave(m *types.Model, save pendingSave, err error) (*types.Model, tea.Cmd) {
+	log := save.log
+	if err != nil {
+		return showSaveError(m, log, err), nil
+	}
+	for _, level := range save.levels {
+		level.Exists = true
+		*getLevelByName(m, level.Name) = level
+		if err := metadata.Save(level); err != nil {
+			return showSaveError(m, log, err), nil
+		}
+		m.Session.RecordWrite(level.Path)
+		log.Info("level_saved", logging.LevelName(level.Name), logging.Path(level.Path))
+	}
+	for _, entry := range save.auditEntries {
+		log.Info("rule_changed", logging.Permission(entry.Rule), logging.LevelName(entry.From),
+			slog.String("to", entry.To), slog.String("action", entry.Action))
+	}
+	m.Session.RulesMoved += save.moved
+	m.Session.DuplicatesResolved += save.resolved
+
+	// The files are already written, so a failed audit append only warns
+	if err := m.AuditLog.Append(save.auditEntries); err != nil {
+		log.Warn("audit_log_write_failed", logging.Path(m.AuditLog.Path()), logging.Err(err))
+		m.Notifications.Warn("Audit log not updated: %v", err)
+	}
+
+	// Reload so the model reflects exactly what is now on disk
+	if m.ProjectLoader != nil {
+		if err := m.ProjectLoader(m, m.ProjectRoot); err != nil {
+			return showSaveError(m, log, err), nil
+		}
+	}
+	m.SaveSelection = nil
+	if save.partial {
+		restoreUnsaved(m, save.staged)
+		m.Notifications.Info("Saved %d settings file(s); %d still have pending changes",
+			len(save.levels), len(save.staged.levels))
+	} else {
+		m.Notifications.Info("Saved %d settings file(s)", len(save.levels))
+	}
+	return offerRepoCommit(m, save)
+}
+
+// changedLevels returns the editable levels whose files a save would rewrite,
+// in LOCAL, REPO, USER order
+func changedLevels(m *types.Model) []*types.SettingsLevel {
+	changed := make(map[string]bool)
+	for _, perm := range m.Permissions {
+		if perm.CurrentLevel != perm.OriginalLevel {
+			changed[perm.CurrentLevel] = true
+			changed[perm.OriginalLevel] = true
+		}
+	}
+	for _, dup := range m.Duplicates {
+		if dup.KeepLevel == "" {
+			continue
+		}
+		for _, level := range dup.Levels {
+			if level != dup.KeepLevel {
+				changed[level] = true
+			}
+		}
+	}
+	for _, c := range m.Contradictions {
+		for _, level := range losingLevels(c) {
+			changed[level] = true
+		}
+	}
+	for _, c := range m.Consolidations {
+		changed[c.Level] = true
+	}
+	for _, stale := range pendingStaleRemovals(m) {
+		changed[stale.Level] = true
+	}
+	for _, edit := range pendingRuleEdits(m) {
+		changed[edit.Level] = true
+	}
+	for _, perm := range pastedRules(m) {
+		changed[perm.CurrentLevel] = true
+	}
+	for _, name := range ruleAdditionLevels(m) {
+		changed[name] = true
+	}
+	for name := range m.SameLevelDuplicates {
+		changed[name] = true
+	}
+	for _, name := range editableLevels {
+		if hooksChanged(m, name) || envChanged(m, name) || directoriesChanged(m, name) ||
+			modesChanged(m, name) {
+			changed[name] = true
+		}
+	}
+	for name, keep := range m.EnvKeepLevels {
+		for _, level := range envLevels(m, name) {
+			if level != keep {
+				changed[level] = true
+			}
+		}
+	}
+
+	var levels []*types.SettingsLevel
+	for _, name := range []string{types.LevelLocal, types.LevelRepo, types.LevelUser} {
+		if changed[name] {
+			levels = append(levels, getLevelByName(m, name))
+		}
+	}
+	return levels
+}
+
+// countPendingChanges returns how many rules the audit entries of a save moved and how many
+// duplicates they resolved
+func countPendingChanges(entries []audit.Entry) (int, int) {
+	moved := 0
+	resolved := make(map[string]bool)
+	for _, entry := range entries {
+		switch entry.Action {
+		case audit.ActionMove:
+			moved++
+		case audit.ActionRemoveDuplicate:
+			resolved[entry.Rule] = true
+		}
+	}
+	return moved, len(resolved)
+}
+
+// applyPendingChanges applies the pending changes that are only carried out on save to
+// the levels
+func applyPendingChanges(m *types.Model) {
+	applyDuplicateResolutions(m)
+	applyContradictionResolutions(m)
+	applyConsolidations(m)
+	applyStaleRemovals(m)
+	applyRuleEdits(m)
+	applyEnvResolutions(m)
+}
+
+// applyDuplicateResolutions removes each resolved duplicate from every level except the kept
+// one. With soft delete configured, removed rules are kept as disabled in the level's sidecar.
+func applyDuplicateResolutions(m *types.Model) {
+	now, ttl := time.Now(), m.Config.SoftDeleteTTL()
+	for _, dup := range m.Duplicates {
+		if dup.KeepLevel == "" {
+			continue
+		}
+		for _, name := range dup.Levels {
+			if name == dup.KeepLevel {
+				continue
+			}
+			if level := getLevelByName(m, name); level != nil {
+				level.Permissions = removePermission(level.Permissions, dup.Name)
+				if ttl > 0 {
+					metadata.Disable(level, dup.Name, softDeleteReason(dup.KeepLevel), now, ttl)
+				}
+			}
+		}
+	}
+}
+
+// showSaveError reports a failed save in a small modal
+func showSaveError(m *types.Model, log *slog.Logger, err error) *types.Model {
+	log.Error("save_failed", logging.Err(err))
+	m.ActiveModal = NewSmallModal("Save Failed", err.Error(), actionSaveFailed)
+	return m
+}
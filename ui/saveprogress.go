@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+
+	"claude-permissions/settingsfile"
+	"claude-permissions/types"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// saveProgressMsg reports a settings file changing state while a save writes it
+type saveProgressMsg struct {
+	path    string
+	state   settingsfile.FileState
+	updates <-chan tea.Msg
+}
+
+// saveDoneMsg reports that the save goroutine has finished with every file
+type saveDoneMsg struct {
+	save pendingSave
+	err  error
+}
+
+// saveStateLabels names each file state in the progress modal
+var saveStateLabels = map[settingsfile.FileState]string{
+	settingsfile.FilePending: scrollIndicatorStyle.Render("pending"),
+	settingsfile.FileWriting: AccentStyle.Render("writing"),
+	settingsfile.FileDone:    SuccessStyle.Render("done"),
+	settingsfile.FileFailed:  ErrorStyle.Render("error"),
+}
+
+// writeLevels writes levels in one transaction on a goroutine, returning the command that
+// delivers its first message. Each saveProgressMsg carries the channel to wait on for the
+// next, and the last message is a saveDoneMsg carrying save.
+func writeLevels(levels []types.SettingsLevel, backupDir string, save pendingSave) tea.Cmd {
+	updates := make(chan tea.Msg)
+	go func() {
+		err := settingsfile.WriteAll(levels, backupDir,
+			func(path string, state settingsfile.FileState) {
+				updates <- saveProgressMsg{path: path, state: state, updates: updates}
+			})
+		updates <- saveDoneMsg{save: save, err: err}
+	}()
+	return waitForSave(updates)
+}
+
+// waitForSave returns the command delivering the next message of a running save
+func waitForSave(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-updates
+	}
+}
+
+// handleSaveProgress shows a file's new state and waits for the next message of the save
+func handleSaveProgress(m *types.Model, msg saveProgressMsg) (*types.Model, tea.Cmd) {
+	if progress, ok := m.ActiveModal.(*SaveProgressModal); ok {
+		progress.states[msg.path] = msg.state
+	}
+	return m, waitForSave(msg.updates)
+}
+
+// handleSaveDone closes the progress modal and completes the save
+func handleSaveDone(m *types.Model, msg saveDoneMsg) (*types.Model, tea.Cmd) {
+	if _, ok := m.ActiveModal.(*SaveProgressModal); ok {
+		m.ActiveModal = nil
+	}
+	return finishSave(m, msg.save, msg.err)
+}
+
+// SaveProgressModal implements types.Modal for the files a save is writing. It takes no
+// input and stays open until the save goroutine has written every file or given up.
+type SaveProgressModal struct {
+	paths  []string
+	states map[string]settingsfile.FileState
+}
+
+// NewSaveProgressModal creates the progress modal with every file of levels pending
+func NewSaveProgressModal(levels []types.SettingsLevel) *SaveProgressModal {
+	sm := &SaveProgressModal{states: make(map[string]settingsfile.FileState)}
+	for _, level := range levels {
+		sm.paths = append(sm.paths, level.Path)
+		sm.states[level.Path] = settingsfile.FilePending
+	}
+	return sm
+}
+
+// RenderModal lists each file being saved with its state
+func (sm *SaveProgressModal) RenderModal(width, height int) string {
+	contentWidth := min(70, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	// The border and padding take six columns, and the state column nine
+	pathStyle := lipgloss.NewStyle().MaxWidth(contentWidth - 15)
+
+	done := 0
+	lines := []string{titleStyle.Render("Saving Changes"), ""}
+	for _, path := range sm.paths {
+		state := sm.states[path]
+		if state == settingsfile.FileDone {
+			done++
+		}
+		lines = append(lines, fmt.Sprintf("%s  %s",
+			lipgloss.NewStyle().Width(7).Render(saveStateLabels[state]), pathStyle.Render(path)))
+	}
+	lines = append(lines, "", scrollIndicatorStyle.Render(
+		fmt.Sprintf("%d of %d file(s) written", done, len(sm.paths))))
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// HandleInput ignores every key; the modal closes when the save finishes
+func (sm *SaveProgressModal) HandleInput(string) (handled bool, result interface{}) {
+	return false, nil
+}
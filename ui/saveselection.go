@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/state"
+	"claude-permissions/types"
+)
+
+// linkedLevels returns level and every level a pending move ties it to, since a move only
+// saves cleanly when the rule is added to one file and removed from the other together
+func linkedLevels(m *types.Model, level string) []string {
+	var moves []audit.Entry
+	for _, entry := range collectAuditEntries(m, "", time.Time{}) {
+		switch entry.Action {
+		case audit.ActionMove, audit.ActionMoveHook, audit.ActionMoveDirectory:
+			moves = append(moves, entry)
+		}
+	}
+
+	linked := []string{level}
+	for i := 0; i < len(linked); i++ {
+		for _, move := range moves {
+			for _, pair := range [][2]string{{move.From, move.To}, {move.To, move.From}} {
+				if pair[0] == linked[i] && pair[1] != "" && !slices.Contains(linked, pair[1]) {
+					linked = append(linked, pair[1])
+				}
+			}
+		}
+	}
+	return linked
+}
+
+// savingLevels returns the changed levels the next save writes, limited to the levels
+// selected in the confirm modal
+func savingLevels(m *types.Model) []*types.SettingsLevel {
+	levels := changedLevels(m)
+	if m.SaveSelection == nil {
+		return levels
+	}
+	return slices.DeleteFunc(levels, func(level *types.SettingsLevel) bool {
+		return !slices.Contains(m.SaveSelection, level.Name)
+	})
+}
+
+// entryLevel returns the level whose file an audit entry changes
+func entryLevel(entry audit.Entry) string {
+	if slices.Contains(editableLevels, entry.From) {
+		return entry.From
+	}
+	return entry.To
+}
+
+// savedEntries returns the audit entries for changes written to one of levels
+func savedEntries(entries []audit.Entry, levels []*types.SettingsLevel) []audit.Entry {
+	return slices.DeleteFunc(entries, func(entry audit.Entry) bool {
+		return !slices.ContainsFunc(levels, func(level *types.SettingsLevel) bool {
+			return level.Name == entryLevel(entry)
+		})
+	})
+}
+
+// stagedLevel holds the pending edits of a level left out of a save
+type stagedLevel struct {
+	name        string
+	hooks       []types.Hook
+	env         map[string]string
+	directories []string
+	modes       *types.PermissionModes
+}
+
+// stagedWork is the pending work of the levels left out of a save, restored once the saved
+// files are reloaded
+type stagedWork struct {
+	session   state.Session
+	levels    []stagedLevel
+	envKeeps  map[string]string
+	ruleEdits []types.RuleEdit
+	pasted    []types.Permission
+	additions []types.RuleAddition
+}
+
+// stageUnsaved captures the pending work of the changed levels not in saving
+func stageUnsaved(m *types.Model, saving []*types.SettingsLevel) (stagedWork, bool) {
+	var work stagedWork
+	for _, level := range changedLevels(m) {
+		if slices.Contains(saving, level) {
+			continue
+		}
+		staged := stagedLevel{
+			name:        level.Name,
+			hooks:       slices.Clone(level.Hooks),
+			env:         maps.Clone(level.Env),
+			directories: slices.Clone(level.Directories),
+		}
+		if level.Modes != nil {
+			modes := *level.Modes
+			staged.modes = &modes
+		}
+		work.levels = append(work.levels, staged)
+	}
+	if len(work.levels) == 0 {
+		return work, false
+	}
+	work.session, _ = captureSession(m)
+	work.envKeeps = maps.Clone(m.EnvKeepLevels)
+	work.ruleEdits = slices.Clone(m.RuleEdits)
+	isSaving := func(name string) bool {
+		return slices.ContainsFunc(saving, func(level *types.SettingsLevel) bool {
+			return level.Name == name
+		})
+	}
+	for _, perm := range pastedRules(m) {
+		if _, added := ruleAdditionOf(m, perm); !added && !isSaving(perm.CurrentLevel) {
+			work.pasted = append(work.pasted, perm)
+		}
+	}
+	for _, addition := range m.RuleAdditions {
+		if !isSaving(addition.Level) {
+			work.additions = append(work.additions, addition)
+		}
+	}
+	return work, true
+}
+
+// restoreUnsaved reapplies staged work to the reloaded project. Changes the save already
+// wrote no longer match the files, so only those of the unsaved levels are restored.
+func restoreUnsaved(m *types.Model, work stagedWork) {
+	for _, staged := range work.levels {
+		level := getLevelByName(m, staged.name)
+		level.Hooks = staged.hooks
+		level.Env = staged.env
+		level.Directories = staged.directories
+		level.Modes = staged.modes
+	}
+	for name, keep := range work.envKeeps {
+		if m.EnvKeepLevels == nil {
+			m.EnvKeepLevels = make(map[string]string)
+		}
+		m.EnvKeepLevels[name] = keep
+	}
+	pruneEnvKeepLevels(m)
+	m.RuleEdits = work.ruleEdits
+	for _, perm := range work.pasted {
+		addPastedRule(m, perm.CurrentLevel, perm.Name)
+	}
+	stageRuleAdditions(m, work.additions)
+	applySession(m, work.session)
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"claude-permissions/types"
+)
+
+// Screen is one of the pages TAB cycles through. The router hands the current screen its
+// content, footer, and status bar, and every key that no global binding claims, so a new
+// screen only needs an implementation and a place in screenOrder.
+type Screen interface {
+	// Init runs when TAB switches to the screen
+	Init(m *types.Model)
+
+	// Update handles a key that means something on this screen only, such as moving the
+	// cursor, 1/2/3, ENTER, or ESC, rejecting keys it has no use for
+	Update(m *types.Model, key string) *types.Model
+
+	// View renders the screen's content at the component's size
+	View(c *ContentComponent) string
+
+	// Keymap returns the footer actions for the screen's most common keys
+	Keymap(m *types.Model) []string
+
+	// StatusText describes the selected entry for the status bar
+	StatusText(m *types.Model) string
+}
+
+// optionalScreen is implemented by screens TAB skips when they have nothing to show
+type optionalScreen interface {
+	Available(m *types.Model) bool
+}
+
+// screens maps each types.Screen* constant to its implementation
+var screens = map[int]Screen{
+	types.ScreenDuplicates:   duplicatesScreen{},
+	types.ScreenOrganization: organizationScreen{},
+	types.ScreenHooks:        hooksScreen{},
+	types.ScreenEnv:          envScreen{},
+	types.ScreenDirectories:  directoriesScreen{},
+	types.ScreenPolicy:       policyScreen{},
+	types.ScreenHistory:      historyScreen{},
+}
+
+// screenOrder is the order TAB cycles through the screens
+var screenOrder = []int{
+	types.ScreenDuplicates,
+	types.ScreenOrganization,
+	types.ScreenHooks,
+	types.ScreenEnv,
+	types.ScreenDirectories,
+	types.ScreenPolicy,
+	types.ScreenHistory,
+}
+
+// currentScreen returns the screen being shown, falling back to Duplicates
+func currentScreen(m *types.Model) Screen {
+	if screen, ok := screens[m.CurrentScreen]; ok {
+		return screen
+	}
+	return screens[types.ScreenDuplicates]
+}
+
+// handleNumberKeys rejects 1/2/3 aimed at a level that could not be loaded, then lets the
+// screen move or keep its selected entry there
+func handleNumberKeys(m *types.Model, key string) *types.Model {
+	if level := getLevelByName(m, getTargetLevel(key)); level != nil && level.LoadError != "" {
+		m.Notifications.Warn("%s settings could not be loaded and are read-only", level.Name)
+		return rejectInput(m)
+	}
+	return currentScreen(m).Update(m, key)
+}
+
+// confirmSave opens the confirm modal when there is something to save
+func confirmSave(m *types.Model) *types.Model {
+	if !hasPendingChanges(m) {
+		return rejectInput(m)
+	}
+	m.ActiveModal = NewConfirmChangesModal(m)
+	return m
+}
+
+// confirmExit asks whether to discard pending changes and exit; without any, ESC does
+// nothing since Q quits
+func confirmExit(m *types.Model) *types.Model {
+	if !hasPendingChanges(m) {
+		return rejectInput(m)
+	}
+	m.ActiveModal = NewSmallModal(
+		"Exit with Pending Changes",
+		"You have pending permission moves or duplicate resolutions.\n\n"+
+			"Do you want to discard these changes and exit?",
+		"exit",
+	)
+	return m
+}
+
+// confirmReset asks whether to undo every pending change
+func confirmReset(m *types.Model) *types.Model {
+	if !hasPendingChanges(m) {
+		return rejectInput(m)
+	}
+	m.ActiveModal = NewSmallModal(
+		"Reset All Changes",
+		"Are you sure you want to reset all permission moves and duplicate resolutions?\n\n"+
+			"This will undo all pending changes and return permissions to their original state.",
+		"reset",
+	)
+	return m
+}
@@ -0,0 +1,297 @@
+package ui
+
+import (
+	"claude-permissions/keymap"
+	"claude-permissions/types"
+)
+
+// duplicatesScreen resolves rules found at several levels and rules both allowed and denied
+type duplicatesScreen struct{}
+
+func (duplicatesScreen) Init(*types.Model) {}
+
+func (duplicatesScreen) Update(m *types.Model, key string) *types.Model {
+	switch key {
+	case keyUp, "k", keyDown, "j":
+		if contradictionsFocused(m) {
+			return handleContradictionNavigation(m, key)
+		}
+		return handleDuplicatesNavigation(m, key)
+	case "1", "2", "3":
+		return handleDuplicateResolution(m, key)
+	case keyAllowWins, keyDenyWins:
+		return handleContradictionResolution(m, key)
+	case keyBulkResolve:
+		return openBulkResolve(m)
+	case keyEnter:
+		return confirmSave(m)
+	case keyEscapeLong, keyEscape:
+		return confirmExit(m)
+	}
+	return rejectInput(m)
+}
+
+func (duplicatesScreen) Regions(*types.Model) []string {
+	return []string{"Duplicates", "Contradictions"}
+}
+
+func (duplicatesScreen) Focus(m *types.Model) int {
+	if contradictionsFocused(m) {
+		return 1
+	}
+	return 0
+}
+
+// SetFocus moves between the duplicates table and the contradictions below it
+func (duplicatesScreen) SetFocus(m *types.Model, region int) *types.Model {
+	if (region == 0 && len(m.Duplicates) == 0) || (region == 1 && len(m.Contradictions) == 0) {
+		return rejectInput(m)
+	}
+	m.ContradictionsFocused = region == 1
+	return m
+}
+
+func (duplicatesScreen) View(c *ContentComponent) string {
+	return c.renderDuplicatesContent()
+}
+
+func (duplicatesScreen) Keymap(m *types.Model) []string {
+	actions := []string{
+		formatFooterAction(keyLabel(m, keymap.SwitchScreen), "Switch panel"),
+		formatFooterAction(keyLabel(m, keymap.MoveLocal, keymap.MoveRepo, keymap.MoveUser),
+			"Keep in LOCAL/REPO/USER"),
+		formatFooterAction(keyLabel(m, keymap.Save), "Save"),
+		formatFooterAction(keyLabel(m, keymap.Help), "All keys"),
+	}
+	if contradictionsFocused(m) {
+		actions[1] = formatFooterAction(keyLabel(m, keymap.AllowWins, keymap.DenyWins),
+			"Allow/Deny wins")
+	}
+	return actions
+}
+
+func (duplicatesScreen) StatusText(m *types.Model) string {
+	if contradictionsFocused(m) {
+		return renderContradictionsStatusText(m)
+	}
+	return renderDuplicatesStatusText(m)
+}
+
+// organizationScreen moves rules between the Local, Repo, and User columns
+type organizationScreen struct{}
+
+func (organizationScreen) Init(*types.Model) {}
+
+func (organizationScreen) Update(m *types.Model, key string) *types.Model {
+	switch key {
+	case keyUp, "k", keyDown, "j":
+		return handleOrganizationNavigation(m, key)
+	case "1", "2", "3":
+		// Block permission moves if there are unresolved duplicates
+		if hasUnresolvedDuplicates(m) {
+			showHint(m, hintOrganizationBlocked)
+			return rejectInput(m)
+		}
+		return handlePermissionMove(m, key)
+	case keyEnter:
+		return confirmSave(m)
+	case keyEscapeLong, keyEscape:
+		return confirmReset(m)
+	}
+	return rejectInput(m)
+}
+
+func (organizationScreen) Regions(*types.Model) []string {
+	return editableLevels
+}
+
+func (organizationScreen) Focus(m *types.Model) int {
+	return m.FocusedColumn
+}
+
+// SetFocus focuses a column unless duplicates still block the screen
+func (organizationScreen) SetFocus(m *types.Model, region int) *types.Model {
+	if hasUnresolvedDuplicates(m) {
+		showHint(m, hintOrganizationBlocked)
+		return rejectInput(m)
+	}
+	m.FocusedColumn = region
+	return m
+}
+
+func (organizationScreen) View(c *ContentComponent) string {
+	return c.renderOrganizationContent()
+}
+
+func (organizationScreen) Keymap(m *types.Model) []string {
+	return []string{
+		formatFooterAction(keyLabel(m, keymap.SwitchScreen), "Switch panel"),
+		formatFooterAction(keyLabel(m, keymap.MoveLocal, keymap.MoveRepo, keymap.MoveUser),
+			"Move to LOCAL/REPO/USER"),
+		formatFooterAction(keyLabel(m, keymap.Save), "Save"),
+		formatFooterAction(keyLabel(m, keymap.Help), "All keys"),
+	}
+}
+
+func (organizationScreen) StatusText(m *types.Model) string {
+	return renderOrganizationStatusText(m)
+}
+
+// hooksScreen moves and reorders hooks
+type hooksScreen struct{}
+
+func (hooksScreen) Init(*types.Model) {}
+
+func (hooksScreen) Update(m *types.Model, key string) *types.Model {
+	switch key {
+	case keyUp, "k", keyDown, "j":
+		return handleHookNavigation(m, key)
+	case "1", "2", "3":
+		return handleHookMove(m, key)
+	case keyMoveHookUp, keyMoveHookDown:
+		return handleHookReorder(m, key)
+	case keyEnter:
+		return confirmSave(m)
+	case keyEscapeLong, keyEscape:
+		return confirmReset(m)
+	}
+	return rejectInput(m)
+}
+
+func (hooksScreen) View(c *ContentComponent) string {
+	return c.renderHooksContent()
+}
+
+func (hooksScreen) Keymap(m *types.Model) []string {
+	return []string{
+		formatFooterAction(keyLabel(m, keymap.SwitchScreen), "Switch panel"),
+		formatFooterAction(keyLabel(m, keymap.MoveLocal, keymap.MoveRepo, keymap.MoveUser),
+			"Move to LOCAL/REPO/USER"),
+		formatFooterAction(keyLabel(m, keymap.MoveHookUp, keymap.MoveHookDown), "Reorder"),
+		formatFooterAction(keyLabel(m, keymap.Save), "Save"),
+		formatFooterAction(keyLabel(m, keymap.Help), "All keys"),
+	}
+}
+
+func (hooksScreen) StatusText(m *types.Model) string {
+	return renderHooksStatusText(m)
+}
+
+// envScreen edits env variables and picks which level keeps a variable set at several
+type envScreen struct{}
+
+func (envScreen) Init(*types.Model) {}
+
+func (envScreen) Update(m *types.Model, key string) *types.Model {
+	switch key {
+	case keyUp, "k", keyDown, "j":
+		return handleEnvNavigation(m, key)
+	case "1", "2", "3":
+		return handleEnvKeep(m, key)
+	case keyAdd, keyEdit, keyDelete:
+		return handleEnvKey(m, key)
+	case keyEnter:
+		return confirmSave(m)
+	case keyEscapeLong, keyEscape:
+		return confirmReset(m)
+	}
+	return rejectInput(m)
+}
+
+func (envScreen) View(c *ContentComponent) string {
+	return c.renderEnvContent()
+}
+
+func (envScreen) Keymap(m *types.Model) []string {
+	return []string{
+		formatFooterAction(keyLabel(m, keymap.SwitchScreen), "Switch panel"),
+		formatFooterAction(keyLabel(m, keymap.Add, keymap.Edit, keymap.Delete),
+			"Add/Edit/Delete"),
+		formatFooterAction(keyLabel(m, keymap.MoveLocal, keymap.MoveRepo, keymap.MoveUser),
+			"Keep level"),
+		formatFooterAction(keyLabel(m, keymap.Save), "Save"),
+		formatFooterAction(keyLabel(m, keymap.Help), "All keys"),
+	}
+}
+
+func (envScreen) StatusText(m *types.Model) string {
+	return renderEnvStatusText(m)
+}
+
+// directoriesScreen edits and moves additional directories
+type directoriesScreen struct{}
+
+func (directoriesScreen) Init(*types.Model) {}
+
+func (directoriesScreen) Update(m *types.Model, key string) *types.Model {
+	switch key {
+	case keyUp, "k", keyDown, "j":
+		return handleDirectoryNavigation(m, key)
+	case "1", "2", "3":
+		return handleDirectoryMove(m, key)
+	case keyAdd, keyEdit, keyDelete:
+		return handleDirectoryKey(m, key)
+	case keyEnter:
+		return confirmSave(m)
+	case keyEscapeLong, keyEscape:
+		return confirmReset(m)
+	}
+	return rejectInput(m)
+}
+
+func (directoriesScreen) View(c *ContentComponent) string {
+	return c.renderDirectoriesContent()
+}
+
+func (directoriesScreen) Keymap(m *types.Model) []string {
+	return []string{
+		formatFooterAction(keyLabel(m, keymap.SwitchScreen), "Switch panel"),
+		formatFooterAction(keyLabel(m, keymap.Add, keymap.Edit, keymap.Delete),
+			"Add/Edit/Delete"),
+		formatFooterAction(keyLabel(m, keymap.MoveLocal, keymap.MoveRepo, keymap.MoveUser),
+			"Move level"),
+		formatFooterAction(keyLabel(m, keymap.Save), "Save"),
+		formatFooterAction(keyLabel(m, keymap.Help), "All keys"),
+	}
+}
+
+func (directoriesScreen) StatusText(m *types.Model) string {
+	return renderDirectoriesStatusText(m)
+}
+
+// historyScreen lists the audit log; it is read-only
+type historyScreen struct{}
+
+// Available hides History when there is no audit log
+func (historyScreen) Available(m *types.Model) bool {
+	return m.AuditLog != nil
+}
+
+// Init reads the latest audit entries each time the screen is shown
+func (historyScreen) Init(m *types.Model) {
+	loadHistory(m)
+}
+
+func (historyScreen) Update(m *types.Model, key string) *types.Model {
+	switch key {
+	case keyUp, "k", keyDown, "j":
+		return handleHistoryNavigation(m, key)
+	}
+	return rejectInput(m)
+}
+
+func (historyScreen) View(c *ContentComponent) string {
+	return c.renderHistoryContent()
+}
+
+func (historyScreen) Keymap(m *types.Model) []string {
+	return []string{
+		formatFooterAction(keyLabel(m, keymap.SwitchScreen), "Switch panel"),
+		formatFooterAction(keyLabel(m, keymap.Up, keymap.Down), "Scroll"),
+		formatFooterAction(keyLabel(m, keymap.Help), "All keys"),
+	}
+}
+
+func (historyScreen) StatusText(m *types.Model) string {
+	return renderHistoryStatusText(m)
+}
@@ -0,0 +1,79 @@
+package ui
+
+import "claude-permissions/types"
+
+// scrollColumnsToCursor moves each column's scroll window just enough to keep its
+// selection visible
+func scrollColumnsToCursor(m *types.Model) {
+	if m.Width == 0 || m.Height == 0 {
+		return
+	}
+
+	rows := visibleColumnRows(m)
+	for i, selection := range m.ColumnSelections {
+		offset := m.ColumnOffsets[i]
+		switch {
+		case selection < offset:
+			offset = selection
+		case selection >= offset+rows:
+			offset = selection - rows + 1
+		}
+		m.ColumnOffsets[i] = max(offset, 0)
+	}
+}
+
+// visibleColumnRows returns how many rules fit in a column at the current window size
+func visibleColumnRows(m *types.Model) int {
+	if m.Width == 0 || m.Height == 0 {
+		return 0
+	}
+	contentHeight := renderLayoutChrome(m).contentHeight(m.Height)
+	return paneComponent(m, types.ScreenOrganization, contentHeight).visibleColumnRows()
+}
+
+// scrollHistory keeps the History scroll offset within the loaded entries
+func scrollHistory(m *types.Model) {
+	if m.Width == 0 || m.Height == 0 {
+		return
+	}
+
+	contentHeight := renderLayoutChrome(m).contentHeight(m.Height)
+	rows := NewContentComponent(m.Width, contentHeight, m).visibleHistoryRows()
+	m.HistoryOffset = max(min(m.HistoryOffset, len(m.History)-rows), 0)
+}
+
+// scrollDuplicatesToCursor moves the duplicates table's page just enough to keep the
+// selected duplicate visible
+func scrollDuplicatesToCursor(m *types.Model) {
+	if m.Width == 0 || m.Height == 0 {
+		return
+	}
+
+	contentHeight := renderLayoutChrome(m).contentHeight(m.Height)
+	rows := paneComponent(m, types.ScreenDuplicates, contentHeight).visibleDuplicateRows()
+	m.DuplicatesOffset = pageOffset(m.DuplicatesOffset, m.DuplicatesTable.Cursor(), rows,
+		len(m.Duplicates))
+}
+
+// pageOffset moves a window of rows starting at offset just enough to show the cursor,
+// keeping it within the total rows
+func pageOffset(offset, cursor, rows, total int) int {
+	switch {
+	case cursor < offset:
+		offset = cursor
+	case cursor >= offset+rows:
+		offset = cursor - rows + 1
+	}
+	return max(min(offset, total-rows), 0)
+}
+
+// scrollPolicy keeps the Policy scroll offset within the current violations
+func scrollPolicy(m *types.Model) {
+	if m.Width == 0 || m.Height == 0 {
+		return
+	}
+
+	contentHeight := renderLayoutChrome(m).contentHeight(m.Height)
+	rows := NewContentComponent(m.Width, contentHeight, m).visiblePolicyRows()
+	m.PolicyOffset = max(min(m.PolicyOffset, len(policyViolations(m))-rows), 0)
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/v2/viewport"
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// scrollIndicatorStyle renders the counts of lines hidden above and below a scroll body
+var scrollIndicatorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorTextSecondary))
+
+// scrollBody is a modal body that keeps within the height the terminal leaves it, scrolling
+// once its content is taller. It is laid out again on every render, so a resized terminal
+// re-wraps the content instead of clipping it.
+type scrollBody struct {
+	viewport viewport.Model
+}
+
+// newScrollBody creates an empty scroll body
+func newScrollBody() scrollBody {
+	return scrollBody{viewport: viewport.New()}
+}
+
+// render wraps content to width and shows at most height lines of it. Content that does not
+// fit is scrolled, with a line above and below counting the lines out of view.
+func (sb *scrollBody) render(content string, width, height int) string {
+	wrapped := lipgloss.NewStyle().Width(width).Render(content)
+	total := lipgloss.Height(wrapped)
+	if total <= height {
+		sb.viewport.SetYOffset(0)
+		sb.viewport.SetHeight(total)
+		sb.viewport.SetContent(wrapped)
+		return wrapped
+	}
+
+	// The indicators take a line each
+	sb.viewport.SetWidth(width)
+	sb.viewport.SetHeight(max(height-2, 1))
+	sb.viewport.SetContent(wrapped)
+	// Re-clamp the offset, which may be past the bottom after the terminal grew
+	sb.viewport.SetYOffset(sb.viewport.YOffset)
+
+	above := sb.viewport.YOffset
+	below := max(total-above-sb.viewport.Height(), 0)
+	return lipgloss.JoinVertical(lipgloss.Left,
+		scrollIndicator("↑", above, width),
+		sb.viewport.View(),
+		scrollIndicator("↓", below, width))
+}
+
+// scrollIndicator counts the lines hidden in one direction, or is blank when there are none
+func scrollIndicator(arrow string, hidden, width int) string {
+	style := scrollIndicatorStyle.Width(width).Align(lipgloss.Right)
+	if hidden == 0 {
+		return style.Render("")
+	}
+	return style.Render(fmt.Sprintf("%s %d more", arrow, hidden))
+}
+
+// scrollable reports whether the last render cut content off
+func (sb *scrollBody) scrollable() bool {
+	return !sb.viewport.AtTop() || !sb.viewport.AtBottom()
+}
+
+// scroll moves the body for the scroll keys and reports whether key was one of them
+func (sb *scrollBody) scroll(key string) bool {
+	switch key {
+	case keyUp, "k":
+		sb.viewport.LineUp(1)
+	case keyDown, "j":
+		sb.viewport.LineDown(1)
+	case "pgup":
+		sb.viewport.ViewUp()
+	case "pgdown", "space":
+		sb.viewport.ViewDown()
+	default:
+		return false
+	}
+	return true
+}
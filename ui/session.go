@@ -0,0 +1,279 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	"claude-permissions/logging"
+	"claude-permissions/state"
+	"claude-permissions/types"
+)
+
+// actionResumeSession is the small modal action offering to restore a previous session
+const actionResumeSession = "resume_session"
+
+// OfferSessionRestore asks whether to restore the unsaved work a previous run left for the
+// loaded project
+func OfferSessionRestore(m *types.Model) {
+	if m.Sessions == nil {
+		return
+	}
+	session, ok := m.Sessions.Get(m.ProjectRoot)
+	if !ok {
+		return
+	}
+
+	m.ActiveModal = NewSmallModal(
+		"Resume Previous Session?",
+		fmt.Sprintf("%d move(s), %d duplicate or contradiction resolution(s), %d "+
+			"suggestion(s), and %d stale rule removal(s) from %s were not saved.\n\n"+
+			"Restore them? Declining discards them.",
+			len(session.Moves), len(session.Resolutions)+len(session.Contradictions),
+			len(session.Consolidations), len(session.StaleRemovals),
+			session.SavedAt.Format("2006-01-02 15:04")),
+		actionResumeSession,
+	)
+}
+
+// captureSession returns the unsaved work in m, and false when there is none
+func captureSession(m *types.Model) (state.Session, bool) {
+	session := state.Session{
+		Screen:           m.CurrentScreen,
+		FocusedColumn:    m.FocusedColumn,
+		ColumnSelections: m.ColumnSelections,
+	}
+	for _, perm := range m.Permissions {
+		if perm.CurrentLevel != perm.OriginalLevel {
+			session.Moves = append(session.Moves, state.PendingMove{
+				Rule: perm.Name,
+				From: perm.OriginalLevel,
+				To:   perm.CurrentLevel,
+			})
+		}
+	}
+	for _, dup := range m.Duplicates {
+		if dup.KeepLevel != dup.DefaultKeepLevel {
+			session.Resolutions = append(session.Resolutions, state.PendingResolution{
+				Rule:      dup.Name,
+				KeepLevel: dup.KeepLevel,
+			})
+		}
+	}
+	for _, c := range m.Contradictions {
+		if c.Winner != "" {
+			session.Contradictions = append(session.Contradictions, state.PendingContradiction{
+				Rule:   c.Name,
+				Winner: c.Winner,
+			})
+		}
+	}
+	for _, c := range m.Consolidations {
+		session.Consolidations = append(session.Consolidations, state.PendingConsolidation{
+			Level:       c.Level,
+			Replacement: c.Replacement,
+			Rules:       c.Rules,
+		})
+	}
+	for _, stale := range pendingStaleRemovals(m) {
+		session.StaleRemovals = append(session.StaleRemovals, state.PendingStaleRemoval{
+			Level:  stale.Level,
+			Rule:   stale.Rule,
+			Target: stale.Target,
+		})
+	}
+	return session, len(session.Moves) > 0 || len(session.Resolutions) > 0 ||
+		len(session.Contradictions) > 0 || len(session.Consolidations) > 0 ||
+		len(session.StaleRemovals) > 0
+}
+
+// persistSession stores the project's unsaved work after each change, and removes it once
+// the work this run stored is saved or reset
+func persistSession(m *types.Model) {
+	if m.Sessions == nil || m.ProjectRoot == "" {
+		return
+	}
+	// Leave the stored session alone until the user answers the restore prompt
+	if modal, ok := m.ActiveModal.(*SmallModal); ok && modal.Action == actionResumeSession {
+		return
+	}
+
+	var err error
+	if session, ok := captureSession(m); ok {
+		err = m.Sessions.Put(m.ProjectRoot, session)
+		m.SessionDirty = true
+	} else if m.SessionDirty {
+		err = m.Sessions.Delete(m.ProjectRoot)
+		m.SessionDirty = false
+	}
+	if err != nil {
+		logFor(m, logging.ComponentSession).Warn("session_save_failed",
+			logging.Project(m.ProjectRoot), logging.Err(err))
+	}
+}
+
+// restoreSession applies the stored session to the freshly loaded project. Moves and
+// resolutions that no longer match the files on disk are skipped.
+func restoreSession(m *types.Model) *types.Model {
+	session, ok := m.Sessions.Get(m.ProjectRoot)
+	if !ok {
+		return m
+	}
+
+	applied, skipped := applySession(m, session)
+
+	// A screen this version does not have, or one with nothing to show, leaves the starting
+	// screen the load chose
+	if screen, ok := screens[session.Screen]; ok {
+		if optional, ok := screen.(optionalScreen); !ok || optional.Available(m) {
+			m.CurrentScreen = session.Screen
+		}
+	}
+	currentScreen(m).Init(m)
+	if session.FocusedColumn >= 0 && session.FocusedColumn < len(m.ColumnSelections) {
+		m.FocusedColumn = session.FocusedColumn
+	}
+	for i, selection := range session.ColumnSelections {
+		m.ColumnSelections[i] = max(min(selection, columnLength(m, i)-1), 0)
+	}
+
+	if skipped > 0 {
+		m.Notifications.Warn("Restored %d change(s); %d no longer apply", applied, skipped)
+	} else {
+		m.Notifications.Info("Restored %d change(s)", applied)
+	}
+	return m
+}
+
+// applySession applies the pending changes of session, returning how many were applied and
+// how many no longer match the project
+func applySession(m *types.Model, session state.Session) (applied, skipped int) {
+	for _, move := range session.Moves {
+		if restoreMove(m, move) {
+			applied++
+		} else {
+			skipped++
+		}
+	}
+	for _, resolution := range session.Resolutions {
+		if restoreResolution(m, resolution) {
+			applied++
+		} else {
+			skipped++
+		}
+	}
+	for _, contradiction := range session.Contradictions {
+		if restoreContradiction(m, contradiction) {
+			applied++
+		} else {
+			skipped++
+		}
+	}
+	for _, consolidation := range session.Consolidations {
+		if restoreConsolidation(m, consolidation) {
+			applied++
+		} else {
+			skipped++
+		}
+	}
+	for _, stale := range session.StaleRemovals {
+		if restoreStaleRemoval(m, stale) {
+			applied++
+		} else {
+			skipped++
+		}
+	}
+	updateDuplicatesTableData(m)
+	return applied, skipped
+}
+
+// restoreMove moves a rule that is still at its original level
+func restoreMove(m *types.Model, move state.PendingMove) bool {
+	for _, perm := range m.Permissions {
+		if perm.Name == move.Rule && perm.OriginalLevel == move.From &&
+			perm.CurrentLevel == move.From {
+			movePermissionBetweenLevels(m, move.Rule, move.From, move.To)
+			return true
+		}
+	}
+	return false
+}
+
+// restoreResolution sets the keep level of a duplicate that still spans that level
+func restoreResolution(m *types.Model, resolution state.PendingResolution) bool {
+	for i := range m.Duplicates {
+		dup := &m.Duplicates[i]
+		if dup.Name != resolution.Rule {
+			continue
+		}
+		if resolution.KeepLevel == "" || slices.Contains(dup.Levels, resolution.KeepLevel) {
+			dup.KeepLevel = resolution.KeepLevel
+			return true
+		}
+		return false
+	}
+	return false
+}
+
+// restoreContradiction picks the winner of a contradiction that still exists
+func restoreContradiction(m *types.Model, pending state.PendingContradiction) bool {
+	for i := range m.Contradictions {
+		if m.Contradictions[i].Name == pending.Rule {
+			m.Contradictions[i].Winner = pending.Winner
+			return true
+		}
+	}
+	return false
+}
+
+// restoreConsolidation accepts a consolidation whose rules are all still in its level
+func restoreConsolidation(m *types.Model, pending state.PendingConsolidation) bool {
+	level := getLevelByName(m, pending.Level)
+	if level == nil {
+		return false
+	}
+	for _, rule := range pending.Rules {
+		if !slices.Contains(level.Permissions, rule) {
+			return false
+		}
+	}
+	m.Consolidations = append(m.Consolidations, types.Consolidation{
+		Level:       pending.Level,
+		Replacement: pending.Replacement,
+		Rules:       pending.Rules,
+	})
+	return true
+}
+
+// restoreStaleRemoval marks a stale rule for removal again if its level still has it
+func restoreStaleRemoval(m *types.Model, pending state.PendingStaleRemoval) bool {
+	level := getLevelByName(m, pending.Level)
+	if level == nil || !slices.Contains(level.Permissions, pending.Rule) {
+		return false
+	}
+	m.StaleRemovals = append(m.StaleRemovals, types.StaleRule{
+		Level:  pending.Level,
+		Rule:   pending.Rule,
+		Target: pending.Target,
+	})
+	return true
+}
+
+// discardSession forgets the stored session after the user declined to restore it
+func discardSession(m *types.Model) {
+	if err := m.Sessions.Delete(m.ProjectRoot); err != nil {
+		logFor(m, logging.ComponentSession).Warn("session_save_failed",
+			logging.Project(m.ProjectRoot), logging.Err(err))
+	}
+}
+
+// columnLength returns how many rules are currently shown in the column
+func columnLength(m *types.Model, column int) int {
+	level := [...]string{types.LevelLocal, types.LevelRepo, types.LevelUser}[column]
+	count := 0
+	for _, perm := range m.Permissions {
+		if perm.CurrentLevel == level {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/paths"
+	"claude-permissions/rules"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// keyStale opens the review of rules whose scripts or paths no longer exist
+const keyStale = "r"
+
+// StaleModal implements types.Modal for picking stale rules to remove
+type StaleModal struct {
+	stale    []types.StaleRule
+	selected []bool
+	cursor   int
+}
+
+// NewStaleModal lists stale rules, marking every one for removal unless removals are already
+// pending, in which case only those stay marked
+func NewStaleModal(stale, pending []types.StaleRule) *StaleModal {
+	selected := make([]bool, len(stale))
+	for i, rule := range stale {
+		selected[i] = len(pending) == 0 || slices.Contains(pending, rule)
+	}
+	return &StaleModal{stale: stale, selected: selected}
+}
+
+// Selected returns the stale rules marked for removal
+func (sm *StaleModal) Selected() []types.StaleRule {
+	var selected []types.StaleRule
+	for i, rule := range sm.stale {
+		if sm.selected[i] {
+			selected = append(selected, rule)
+		}
+	}
+	return selected
+}
+
+// RenderModal renders the stale rules with their missing targets and removal marks
+func (sm *StaleModal) RenderModal(width, height int) string {
+	contentWidth := min(80, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	lines := []string{titleStyle.Render("Stale Rules"), ""}
+	if len(sm.stale) == 0 {
+		lines = append(lines, CountStyle.Render(
+			"Every script and path referenced by an allow rule exists"))
+	}
+
+	// Keep the selected rule visible when the list overflows the terminal
+	visible := len(sm.stale)
+	if height > 0 {
+		visible = max(height-14, 1)
+	}
+	first := max(sm.cursor-visible+1, 0)
+	last := min(first+visible, len(sm.stale))
+	ruleWidth := 0
+	for _, rule := range sm.stale {
+		ruleWidth = max(ruleWidth, len(rule.Rule))
+	}
+	ruleWidth = min(ruleWidth, contentWidth/2)
+	for i := first; i < last; i++ {
+		rule := sm.stale[i]
+		mark := "[ ]"
+		if sm.selected[i] {
+			mark = "[x]"
+		}
+		line := fmt.Sprintf("%s %-5s %-*s", mark, rule.Level, ruleWidth, rule.Rule)
+		missing := CountStyle.Render("  missing " + rule.Target)
+		if i == sm.cursor {
+			lines = append(lines, SelectedItemStyle.Render("> "+line)+missing)
+		} else {
+			lines = append(lines, "  "+line+missing)
+		}
+	}
+
+	instructions := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(contentWidth-4).
+		Padding(1, 0, 0, 0).
+		Render(joinFooterActions([]string{
+			formatFooterAction("SPACE", "Toggle"),
+			formatFooterAction("A", "All"),
+			formatFooterAction("ENTER", "Remove marked"),
+			formatFooterAction("ESC", "Cancel"),
+		}))
+	lines = append(lines, instructions)
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// HandleInput processes keyboard input for the stale rule list
+func (sm *StaleModal) HandleInput(key string) (handled bool, result interface{}) {
+	switch key {
+	case keyUp, "k":
+		if sm.cursor > 0 {
+			sm.cursor--
+		}
+	case keyDown, "j":
+		if sm.cursor < len(sm.stale)-1 {
+			sm.cursor++
+		}
+	case "space":
+		if sm.cursor < len(sm.stale) {
+			sm.selected[sm.cursor] = !sm.selected[sm.cursor]
+		}
+	case "a":
+		// Mark everything, or clear the marks when everything is already marked
+		all := !slices.Contains(sm.selected, false)
+		for i := range sm.selected {
+			sm.selected[i] = !all
+		}
+	case keyEnter:
+		return true, "remove_stale"
+	case keyEscapeLong, keyEscape:
+		return true, "cancel"
+	}
+	return false, nil
+}
+
+// openStaleReview checks the allow rules of every editable level against the working tree
+func openStaleReview(m *types.Model) *types.Model {
+	home, _, err := paths.HomeDir()
+	if err != nil {
+		home = ""
+	}
+	var stale []types.StaleRule
+	for _, name := range editableLevels {
+		stale = append(stale, rules.FindStale(*getLevelByName(m, name), m.ProjectRoot, home)...)
+	}
+	m.ActiveModal = NewStaleModal(stale, m.StaleRemovals)
+	return m
+}
+
+// pendingStaleRemovals returns the marked stale rules still present in their level; rules
+// moved away since they were marked are left alone
+func pendingStaleRemovals(m *types.Model) []types.StaleRule {
+	var present []types.StaleRule
+	for _, stale := range m.StaleRemovals {
+		level := getLevelByName(m, stale.Level)
+		if level != nil && slices.Contains(level.Permissions, stale.Rule) {
+			present = append(present, stale)
+		}
+	}
+	return present
+}
+
+// applyStaleRemovals removes the marked stale rules from their levels
+func applyStaleRemovals(m *types.Model) {
+	for _, stale := range pendingStaleRemovals(m) {
+		level := getLevelByName(m, stale.Level)
+		level.Permissions = removePermission(level.Permissions, stale.Rule)
+	}
+}
+
+// staleAuditEntries returns an audit entry for each stale rule removed
+func staleAuditEntries(m *types.Model, now time.Time) []audit.Entry {
+	var entries []audit.Entry
+	for _, stale := range pendingStaleRemovals(m) {
+		entries = append(entries, audit.Entry{
+			Timestamp: now,
+			Rule:      stale.Rule,
+			From:      stale.Level,
+			Action:    audit.ActionRemoveStale,
+		})
+	}
+	return entries
+}
+
+// staleRemovalChanges lists the stale rules marked for removal
+func staleRemovalChanges(m *types.Model) []types.PendingChange {
+	var changes []types.PendingChange
+	for _, stale := range pendingStaleRemovals(m) {
+		changes = append(changes, types.PendingChange{
+			Kind:   types.ChangeStale,
+			Rule:   stale.Rule,
+			From:   []string{stale.Level},
+			Detail: stale.Target,
+			Line: fmt.Sprintf("• %s: Remove from %s (missing %s)",
+				stale.Rule, getLevelStyledText(stale.Level), stale.Target),
+		})
+	}
+	return changes
+}
@@ -0,0 +1,249 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"claude-permissions/audit"
+	"claude-permissions/metadata"
+	"claude-permissions/rules"
+	"claude-permissions/types"
+
+	"github.com/charmbracelet/lipgloss/v2"
+)
+
+// keySuggest opens the consolidation suggestions
+const keySuggest = "s"
+
+// suggestionSource is the provenance recorded for rules added by an accepted suggestion
+const suggestionSource = "suggestion:consolidate"
+
+// Decisions on a consolidation suggestion
+const (
+	suggestionUndecided = iota
+	suggestionAccepted
+	suggestionRejected
+)
+
+// SuggestionsModal implements types.Modal for reviewing consolidation suggestions one by one
+type SuggestionsModal struct {
+	suggestions []types.Consolidation
+	decisions   []int
+	cursor      int
+}
+
+// NewSuggestionsModal lists suggestions, marking those already pending as accepted
+func NewSuggestionsModal(suggestions, pending []types.Consolidation) *SuggestionsModal {
+	decisions := make([]int, len(suggestions))
+	for i, suggestion := range suggestions {
+		for _, p := range pending {
+			if p.Level == suggestion.Level && p.Replacement == suggestion.Replacement {
+				decisions[i] = suggestionAccepted
+			}
+		}
+	}
+	return &SuggestionsModal{suggestions: suggestions, decisions: decisions}
+}
+
+// Accepted returns the suggestions marked as accepted
+func (sm *SuggestionsModal) Accepted() []types.Consolidation {
+	var accepted []types.Consolidation
+	for i, suggestion := range sm.suggestions {
+		if sm.decisions[i] == suggestionAccepted {
+			accepted = append(accepted, suggestion)
+		}
+	}
+	return accepted
+}
+
+// RenderModal renders the suggestion list with the decision on each
+func (sm *SuggestionsModal) RenderModal(width, height int) string {
+	contentWidth := min(80, width-4)
+
+	modalStyle := lipgloss.NewStyle().
+		Width(contentWidth).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(lipgloss.Color(ColorAccent)).
+		Background(lipgloss.Color(ColorBackground)).
+		Foreground(lipgloss.Color(ColorTitle)).
+		Padding(1, 2)
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(ColorAccent)).
+		Align(lipgloss.Center).
+		Width(contentWidth - 4)
+
+	lines := []string{titleStyle.Render("Suggestions"), ""}
+	if len(sm.suggestions) == 0 {
+		lines = append(lines, CountStyle.Render(fmt.Sprintf(
+			"No level has %d or more rules that one broader rule could replace",
+			rules.MinConsolidation)))
+	}
+
+	// Keep the selected suggestion visible when its rules overflow the terminal
+	first := 0
+	if height > 0 && len(sm.suggestions) > 0 {
+		budget := max(height-14, 2)
+		for first < sm.cursor && sm.linesBetween(first, sm.cursor) > budget {
+			first++
+		}
+	}
+	for i := first; i < len(sm.suggestions); i++ {
+		suggestion := sm.suggestions[i]
+		decision := "      "
+		switch sm.decisions[i] {
+		case suggestionAccepted:
+			decision = "[keep]"
+		case suggestionRejected:
+			decision = "[skip]"
+		}
+		header := fmt.Sprintf("%s %s: %s replaces %d rules", decision, suggestion.Level,
+			suggestion.Replacement, len(suggestion.Rules))
+		if i == sm.cursor {
+			lines = append(lines, SelectedItemStyle.Render("> "+header))
+		} else {
+			lines = append(lines, "  "+header)
+		}
+		for _, rule := range suggestion.Rules {
+			lines = append(lines, CountStyle.Render("         "+rule))
+		}
+	}
+
+	instructions := lipgloss.NewStyle().
+		Align(lipgloss.Center).
+		Width(contentWidth-4).
+		Padding(1, 0, 0, 0).
+		Render(joinFooterActions([]string{
+			formatFooterAction("↑↓", "Select"),
+			formatFooterAction("A/R", "Accept/Reject"),
+			formatFooterAction("ENTER", "Apply"),
+			formatFooterAction("ESC", "Cancel"),
+		}))
+	lines = append(lines, instructions)
+
+	return modalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+// linesBetween counts the rendered lines from suggestion first through suggestion last
+func (sm *SuggestionsModal) linesBetween(first, last int) int {
+	count := 0
+	for i := first; i <= last; i++ {
+		count += 1 + len(sm.suggestions[i].Rules)
+	}
+	return count
+}
+
+// HandleInput processes keyboard input for the suggestions list
+func (sm *SuggestionsModal) HandleInput(key string) (handled bool, result interface{}) {
+	switch key {
+	case keyUp, "k":
+		if sm.cursor > 0 {
+			sm.cursor--
+		}
+	case keyDown, "j":
+		if sm.cursor < len(sm.suggestions)-1 {
+			sm.cursor++
+		}
+	case "a", "y":
+		sm.decide(suggestionAccepted)
+	case "r", "n":
+		sm.decide(suggestionRejected)
+	case keyEnter:
+		return true, "apply_suggestions"
+	case keyEscapeLong, keyEscape:
+		return true, "cancel"
+	}
+	return false, nil
+}
+
+// decide records a decision for the selected suggestion and moves to the next one
+func (sm *SuggestionsModal) decide(decision int) {
+	if sm.cursor >= len(sm.suggestions) {
+		return
+	}
+	sm.decisions[sm.cursor] = decision
+	if sm.cursor < len(sm.suggestions)-1 {
+		sm.cursor++
+	}
+}
+
+// openSuggestions shows consolidation suggestions for every editable level
+func openSuggestions(m *types.Model) *types.Model {
+	var suggestions []types.Consolidation
+	for _, name := range []string{types.LevelLocal, types.LevelRepo, types.LevelUser} {
+		suggestions = append(suggestions, rules.SuggestConsolidations(*getLevelByName(m, name))...)
+	}
+	m.ActiveModal = NewSuggestionsModal(suggestions, m.Consolidations)
+	return m
+}
+
+// pendingConsolidationRules returns the rules of a consolidation still present in its level;
+// rules moved away since it was accepted are left alone
+func pendingConsolidationRules(m *types.Model, c types.Consolidation) []string {
+	level := getLevelByName(m, c.Level)
+	if level == nil {
+		return nil
+	}
+	var present []string
+	for _, rule := range c.Rules {
+		if slices.Contains(level.Permissions, rule) {
+			present = append(present, rule)
+		}
+	}
+	return present
+}
+
+// applyConsolidations replaces the narrow rules of each accepted suggestion with its broader
+// rule, recording where the broader rule came from
+func applyConsolidations(m *types.Model) {
+	for _, c := range m.Consolidations {
+		level := getLevelByName(m, c.Level)
+		if level == nil {
+			continue
+		}
+		for _, rule := range pendingConsolidationRules(m, c) {
+			level.Permissions = removePermission(level.Permissions, rule)
+		}
+		if !slices.Contains(level.Permissions, c.Replacement) {
+			level.Permissions = append(level.Permissions, c.Replacement)
+			metadata.Record(level, c.Replacement, suggestionSource)
+		}
+	}
+}
+
+// consolidationAuditEntries returns an audit entry for each rule an accepted suggestion replaces
+func consolidationAuditEntries(m *types.Model, now time.Time) []audit.Entry {
+	var entries []audit.Entry
+	for _, c := range m.Consolidations {
+		for _, rule := range pendingConsolidationRules(m, c) {
+			entries = append(entries, audit.Entry{
+				Timestamp: now,
+				Rule:      rule,
+				From:      c.Level,
+				To:        c.Replacement,
+				Action:    audit.ActionConsolidate,
+			})
+		}
+	}
+	return entries
+}
+
+// consolidationChanges lists the accepted consolidations
+func consolidationChanges(m *types.Model) []types.PendingChange {
+	changes := make([]types.PendingChange, 0, len(m.Consolidations))
+	for _, c := range m.Consolidations {
+		rules := pendingConsolidationRules(m, c)
+		changes = append(changes, types.PendingChange{
+			Kind:  types.ChangeConsolidation,
+			Level: c.Level,
+			After: c.Replacement,
+			Rules: rules,
+			Line: fmt.Sprintf("• %s: %s replaces %s", getLevelStyledText(c.Level),
+				c.Replacement, strings.Join(rules, ", ")),
+		})
+	}
+	return changes
+}
@@ -108,6 +108,14 @@ var (
 	UserLevelStyle  = SuccessStyle // Green for User
 )
 
+// JSON syntax highlighting styles
+var (
+	JSONKeyStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccent))
+	JSONStringStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorSuccess))
+	JSONLiteralStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorCount))
+	JSONPunctuationStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(ColorTextSecondary))
+)
+
 // Darker level styles for origin indicators to match gray text contrast
 var (
 	LocalOriginStyle = lipgloss.NewStyle().
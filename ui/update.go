@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"claude-permissions/version"
+
+	tea "github.com/charmbracelet/bubbletea/v2"
+)
+
+// updateCheckTimeout bounds the release check so a slow network never keeps it running
+const updateCheckTimeout = 5 * time.Second
+
+// updateAvailableMsg carries the tag of a release newer than the running build
+type updateAvailableMsg struct {
+	version string
+}
+
+// checkForUpdate asks GitHub for the latest release in the background. Failures are
+// ignored, as the check is only a convenience; nothing is reported unless a newer release
+// exists.
+func checkForUpdate() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+		defer cancel()
+		latest, err := version.Latest(ctx, http.DefaultClient)
+		if err != nil || !version.Newer(version.Get().Version, latest) {
+			return nil
+		}
+		return updateAvailableMsg{version: latest}
+	}
+}
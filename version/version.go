@@ -0,0 +1,122 @@
+// Package version describes the running build and checks GitHub for a newer release
+package version
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// Build details set at link time, e.g.
+//
+//	go build -ldflags "-X claude-permissions/version.Version=v1.2.0 \
+//	  -X claude-permissions/version.Commit=$(git rev-parse HEAD) \
+//	  -X claude-permissions/version.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Empty values fall back to the module version and VCS stamp Go embeds in the binary.
+var (
+	Version string
+	Commit  string
+	Date    string
+)
+
+// releasesURL is the GitHub API endpoint for the latest published release
+const releasesURL = "https://api.github.com/repos/rcdailey/claude-code-permission-editor/" +
+	"releases/latest"
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build details, preferring those set at link time over the module version
+// and VCS stamp, and "dev" when neither names a version
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, Date: Date, GoVersion: runtime.Version()}
+	if build, ok := debug.ReadBuildInfo(); ok {
+		if info.Version == "" && build.Main.Version != "(devel)" {
+			info.Version = build.Main.Version
+		}
+		for _, setting := range build.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = setting.Value
+			case setting.Key == "vcs.time" && info.Date == "":
+				info.Date = setting.Value
+			}
+		}
+	}
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	return info
+}
+
+// String describes the build on one line, e.g.
+// "claude-permissions v1.2.0 (commit 1a2b3c4, built 2025-01-02T03:04:05Z, go1.24.5)"
+func (i Info) String() string {
+	details := []string{}
+	if i.Commit != "" {
+		details = append(details, "commit "+i.Commit[:min(len(i.Commit), 7)])
+	}
+	if i.Date != "" {
+		details = append(details, "built "+i.Date)
+	}
+	details = append(details, i.GoVersion)
+	return fmt.Sprintf("claude-permissions %s (%s)", i.Version, strings.Join(details, ", "))
+}
+
+// Latest returns the tag of the latest GitHub release
+func Latest(ctx context.Context, client *http.Client) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, releasesURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s responded %s", releasesURL, resp.Status)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", fmt.Errorf("invalid release response: %w", err)
+	}
+	return release.TagName, nil
+}
+
+// Newer reports whether latest is a later semantic version than current. Builds without a
+// release version, such as "dev" or the pseudo-version of a local checkout, are never
+// reported as outdated.
+func Newer(current, latest string) bool {
+	current, latest = canonical(current), canonical(latest)
+	if !semver.IsValid(current) || !semver.IsValid(latest) ||
+		module.IsPseudoVersion(current) || semver.Build(current) != "" {
+		return false
+	}
+	return semver.Compare(latest, current) > 0
+}
+
+// canonical adds the "v" prefix semver expects to tags written without one
+func canonical(v string) string {
+	if v != "" && !strings.HasPrefix(v, "v") {
+		return "v" + v
+	}
+	return v
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"claude-permissions/version"
+)
+
+// runVersionCommand prints the build's version, commit, and build date
+func runVersionCommand(_ []string) (int, error) {
+	info := version.Get()
+	if *outputFormat == formatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(info); err != nil {
+			return 1, err
+		}
+		return 0, nil
+	}
+	fmt.Println(info)
+	return 0, nil
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strings"
+	"time"
+
+	"claude-permissions/types"
+)
+
+// Flags registered by registerWatchFlags when the watch command runs
+var (
+	watchInterval *time.Duration
+	watchNotify   *bool
+	watchWebhook  *string
+)
+
+// webhookTimeout bounds each webhook POST so a slow endpoint cannot stall the watch loop
+const webhookTimeout = 10 * time.Second
+
+// registerWatchFlags adds the watch command's flags to fs
+func registerWatchFlags(fs *flag.FlagSet) {
+	watchInterval = fs.Duration("interval", time.Second, "watch: how often to check the files")
+	watchNotify = fs.Bool("notify", false, "watch: also show a desktop notification per change")
+	watchWebhook = fs.String("webhook", "", "watch: also POST each change as JSON to URL")
+}
+
+// watchEvent is a change to one settings file observed by the watch command
+type watchEvent struct {
+	Time time.Time `json:"time"`
+	Host string    `json:"host,omitempty"` // Lets a webhook receiver tell machines apart
+	levelChange
+}
+
+// watchedFile tracks the last seen state of one settings file
+type watchedFile struct {
+	level   types.SettingsLevel
+	modTime time.Time
+	size    int64
+}
+
+// runWatchCommand prints a diff, and optionally POSTs it to a webhook, whenever one of the
+// settings files changes, managed policy included
+func runWatchCommand(_ []string) (int, error) {
+	if *watchWebhook != "" {
+		if err := validateWebhook(*watchWebhook); err != nil {
+			return 1, err
+		}
+	}
+
+	projectDir, err := resolveProjectDir(*projectFlag)
+	if err != nil {
+		return 1, err
+	}
+
+	userLevel, repoLevel, localLevel, err := loadRawLevels(projectDir)
+	if err != nil {
+		return 1, err
+	}
+	managedLevel, err := loadManagedLevel()
+	if err != nil {
+		return 1, err
+	}
+
+	var files []*watchedFile
+	levels := []types.SettingsLevel{managedLevel, userLevel, repoLevel, localLevel}
+	for _, level := range levels {
+		if level.Path == "" {
+			continue
+		}
+		file := &watchedFile{level: level}
+		file.modTime, file.size = statFile(level.Path)
+		files = append(files, file)
+		if *outputFormat != formatJSON {
+			fmt.Printf("Watching %s (%s)\n", level.Name, level.Path)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(*watchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, nil
+		case <-ticker.C:
+			for _, file := range files {
+				if event, ok := pollWatchedFile(file); ok {
+					reportWatchEvent(event)
+				}
+			}
+		}
+	}
+}
+
+// pollWatchedFile reloads a file whose size or modification time changed and returns the diff
+func pollWatchedFile(file *watchedFile) (watchEvent, bool) {
+	modTime, size := statFile(file.level.Path)
+	if modTime.Equal(file.modTime) && size == file.size {
+		return watchEvent{}, false
+	}
+
+	level, err := loadSettingsLevel(file.level.Name, file.level.Path)
+	if err != nil {
+		// Usually a write in progress; keep the old snapshot and retry on the next tick
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return watchEvent{}, false
+	}
+	file.modTime, file.size = modTime, size
+
+	change := diffLevels(file.level, level)
+	file.level = level
+	if change.empty() {
+		return watchEvent{}, false
+	}
+	host, _ := os.Hostname()
+	return watchEvent{Time: time.Now(), Host: host, levelChange: change}, true
+}
+
+// statFile returns the modification time and size of path, or zero values when it is missing
+func statFile(path string) (time.Time, int64) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, 0
+	}
+	return info.ModTime(), info.Size()
+}
+
+// diffLevels compares two snapshots of the same level
+func diffLevels(before, after types.SettingsLevel) levelChange {
+	change := levelChange{Level: after.Name, Path: after.Path}
+	change.AddAllow, change.RemoveAllow = diffRules(before.Permissions, after.Permissions)
+	change.AddDeny, change.RemoveDeny = diffRules(before.Deny, after.Deny)
+	return change
+}
+
+// diffRules returns the rules only in after (added) and only in before (removed)
+func diffRules(before, after []string) ([]string, []string) {
+	inBefore := make(map[string]bool, len(before))
+	for _, rule := range before {
+		inBefore[rule] = true
+	}
+	inAfter := make(map[string]bool, len(after))
+	for _, rule := range after {
+		inAfter[rule] = true
+	}
+
+	var added, removed []string
+	for _, rule := range after {
+		if !inBefore[rule] {
+			added = append(added, rule)
+		}
+	}
+	for _, rule := range before {
+		if !inAfter[rule] {
+			removed = append(removed, rule)
+		}
+	}
+	return added, removed
+}
+
+// reportWatchEvent prints the event and optionally raises a desktop notification
+func reportWatchEvent(event watchEvent) {
+	if *outputFormat == formatJSON {
+		if err := json.NewEncoder(os.Stdout).Encode(event); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	} else {
+		fmt.Printf("\n[%s] %s (%s)\n", event.Time.Format("15:04:05"), event.Level, event.Path)
+		printRuleChanges("+", "allow", event.AddAllow)
+		printRuleChanges("-", "allow", event.RemoveAllow)
+		printRuleChanges("+", "deny", event.AddDeny)
+		printRuleChanges("-", "deny", event.RemoveDeny)
+	}
+
+	if *watchNotify {
+		if err := desktopNotify(event); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: desktop notification failed: %v\n", err)
+		}
+	}
+	if *watchWebhook != "" {
+		if err := postWebhook(*watchWebhook, event); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: webhook failed: %v\n", err)
+		}
+	}
+}
+
+// validateWebhook checks that target is an absolute http or https URL
+func validateWebhook(target string) error {
+	u, err := url.Parse(target)
+	if err != nil {
+		return fmt.Errorf("invalid webhook URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL %q: must be an http or https URL", target)
+	}
+	return nil
+}
+
+// postWebhook sends event to target as a JSON body, failing on a non-2xx response
+func postWebhook(target string, event watchEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	client := http.Client{Timeout: webhookTimeout}
+	resp, err := client.Post(target, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s responded %s", target, resp.Status)
+	}
+	return nil
+}
+
+// desktopNotify shows a notification summarizing the event using the platform notifier
+func desktopNotify(event watchEvent) error {
+	title := fmt.Sprintf("%s permissions changed", event.Level)
+
+	var lines []string
+	for _, rule := range event.AddAllow {
+		lines = append(lines, "+ "+rule)
+	}
+	for _, rule := range event.RemoveAllow {
+		lines = append(lines, "- "+rule)
+	}
+	for _, rule := range event.AddDeny {
+		lines = append(lines, "+ deny "+rule)
+	}
+	for _, rule := range event.RemoveDeny {
+		lines = append(lines, "- deny "+rule)
+	}
+	body := strings.Join(lines, "\n")
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", body, title)
+		cmd = exec.Command("osascript", "-e", script)
+	case "linux":
+		cmd = exec.Command("notify-send", title, body)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+	return cmd.Run()
+}